@@ -0,0 +1,39 @@
+package api
+
+import (
+	"sync"
+)
+
+// BuiltIn is a built-in plugin registered with RegisterBuiltIn.
+type BuiltIn struct {
+	Name           string
+	PluginServer   PluginServer
+	ServiceServers []ServiceServer
+}
+
+var builtIns struct {
+	sync.Mutex
+	list []BuiltIn
+}
+
+// RegisterBuiltIn registers the built-in plugin made of the given plugin and
+// service servers. The NewBuiltIn functions generated by
+// protoc-gen-go-extension register into it. The registered plugins are
+// retrieved with catalog.DefaultBuiltInPluginRegistry.
+func RegisterBuiltIn(name string, pluginServer PluginServer, serviceServers ...ServiceServer) {
+	builtIns.Lock()
+	defer builtIns.Unlock()
+	builtIns.list = append(builtIns.list, BuiltIn{
+		Name:           name,
+		PluginServer:   pluginServer,
+		ServiceServers: serviceServers,
+	})
+}
+
+// BuiltIns returns the built-in plugins registered with RegisterBuiltIn, in
+// registration order.
+func BuiltIns() []BuiltIn {
+	builtIns.Lock()
+	defer builtIns.Unlock()
+	return append([]BuiltIn(nil), builtIns.list...)
+}
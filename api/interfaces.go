@@ -29,6 +29,13 @@ type PluginClient interface {
 	Type() string
 }
 
+// BuiltInRegistrar registers built-in plugins made of the given plugin and
+// service servers.
+// It is implemented by catalog.BuiltInPluginRegistry.
+type BuiltInRegistrar interface {
+	RegisterBuiltIn(name string, pluginServer PluginServer, serviceServers ...ServiceServer)
+}
+
 // ServiceServer defines the server side of a service, which can be embedded by a
 // PluginServer. It is used to define shared functionality in a proto definition,
 // which does not make up a plugin on its own.
@@ -76,6 +83,27 @@ type NeedsHostServices interface {
 	BrokerHostServices(ServiceBroker) error
 }
 
+// HasCapabilities enables a plugin implementation to advertise its version,
+// build information and capabilities to the plugin loader during plugin
+// initialization, i.e. before the plugin is configured.
+// The implementation is optional.
+type HasCapabilities interface {
+	Capabilities() Capabilities
+}
+
+// Capabilities are advertised by a plugin during plugin initialization.
+type Capabilities struct {
+	// Version is the semantic version of the plugin.
+	Version string
+
+	// BuildInfo describes the build of the plugin.
+	BuildInfo string
+
+	// Metadata describes what the plugin supports, e.g. the key algorithms
+	// or feature flags.
+	Metadata map[string]string
+}
+
 type ServiceBroker interface {
 	// BrokerClient initializes the passed in host service client. If the
 	// host service is not available, the host service client will
@@ -116,6 +144,13 @@ type Info interface {
 
 	// Version of the plugin
 	Version() uint
+
+	// SDKVersion is the version of the plugin SDK the plugin was built
+	// with, if advertised by the plugin
+	SDKVersion() string
+
+	// Capabilities advertised by the plugin during initialization
+	Capabilities() Capabilities
 }
 
 // Version represents a plugin or service version. It is used to instantiate
@@ -1,6 +1,8 @@
 package pluginoption
 
 import (
+	"crypto/tls"
+
 	"github.com/hashicorp/go-hclog"
 	"google.golang.org/grpc"
 
@@ -20,6 +22,34 @@ type ServerConfiguration struct {
 
 	ValidateInput  bool
 	ValidateOutput bool
+
+	// UnaryInterceptors and StreamInterceptors are chained after the
+	// interceptors of the SDK, in the order they are given.
+	UnaryInterceptors  []grpc.UnaryServerInterceptor
+	StreamInterceptors []grpc.StreamServerInterceptor
+
+	// ExitAfterPanics is the number of panics of the handlers after which
+	// the plugin exits, so that the host restarts it. Panics are recovered
+	// from and returned as internal errors, except those of an interceptor
+	// set with grpc.UnaryInterceptor or grpc.StreamInterceptor. Zero never
+	// exits.
+	ExitAfterPanics int
+
+	// TLSConfig is the TLS configuration of a remote plugin server. It takes
+	// precedence over the certificate files. It must set ClientCAs, hosts
+	// being required to present a certificate signed by them.
+	TLSConfig *tls.Config
+
+	// TLSCertFile, TLSKeyFile and TLSCAFile are the files the mutual TLS
+	// configuration of a remote plugin server is loaded from.
+	TLSCertFile string
+	TLSKeyFile  string
+	TLSCAFile   string
+
+	// InsecureUnixSocket allows a remote plugin server without TLS to serve
+	// plaintext over a Unix socket, relying on the permissions of the socket
+	// file to restrict who connects to the plugin.
+	InsecureUnixSocket bool
 }
 
 type ServerOption func(*ServerConfiguration)
@@ -36,9 +66,41 @@ func EnableOutputValidation() ServerOption {
 	}
 }
 
+// SetServerOption adds gRPC server options. Interceptors are better added
+// with WithUnaryInterceptors and WithStreamInterceptors, which compose with
+// the interceptors of the SDK in a defined order. Interceptors chained with
+// grpc.ChainUnaryInterceptor or grpc.ChainStreamInterceptor run after all of
+// them, while an interceptor set with grpc.UnaryInterceptor or
+// grpc.StreamInterceptor runs before them, outside of the panic recovery.
 func SetServerOption(opts ...grpc.ServerOption) ServerOption {
 	return func(gs *ServerConfiguration) {
-		gs.ServerOptions = opts
+		gs.ServerOptions = append(gs.ServerOptions, opts...)
+	}
+}
+
+// WithUnaryInterceptors adds unary interceptors to the plugin server. They
+// are chained after the interceptors of the SDK, e.g. the validation, in the
+// order they are given.
+func WithUnaryInterceptors(interceptors ...grpc.UnaryServerInterceptor) ServerOption {
+	return func(gs *ServerConfiguration) {
+		gs.UnaryInterceptors = append(gs.UnaryInterceptors, interceptors...)
+	}
+}
+
+// WithStreamInterceptors adds stream interceptors to the plugin server. They
+// are chained after the interceptors of the SDK, e.g. the validation, in the
+// order they are given.
+func WithStreamInterceptors(interceptors ...grpc.StreamServerInterceptor) ServerOption {
+	return func(gs *ServerConfiguration) {
+		gs.StreamInterceptors = append(gs.StreamInterceptors, interceptors...)
+	}
+}
+
+// WithExitAfterPanics makes the plugin exit after the given number of panics
+// of its handlers, so that the host restarts it.
+func WithExitAfterPanics(n int) ServerOption {
+	return func(gs *ServerConfiguration) {
+		gs.ExitAfterPanics = n
 	}
 }
 
@@ -65,3 +127,29 @@ func WithTestConfig(config *goplugin.ServeTestConfig) ServerOption {
 		gs.TestConfig = config
 	}
 }
+
+// WithTLSConfig sets the TLS configuration used by a remote plugin server.
+func WithTLSConfig(config *tls.Config) ServerOption {
+	return func(gs *ServerConfiguration) {
+		gs.TLSConfig = config
+	}
+}
+
+// WithInsecureUnixSocket makes a remote plugin server configured without TLS
+// serve plaintext over a Unix socket. Hosts must opt in as well.
+func WithInsecureUnixSocket() ServerOption {
+	return func(gs *ServerConfiguration) {
+		gs.InsecureUnixSocket = true
+	}
+}
+
+// WithMutualTLS makes a remote plugin server authenticate with the given
+// certificate and key, and require hosts to present a certificate signed by
+// the given CA.
+func WithMutualTLS(certFile, keyFile, caFile string) ServerOption {
+	return func(gs *ServerConfiguration) {
+		gs.TLSCertFile = certFile
+		gs.TLSKeyFile = keyFile
+		gs.TLSCAFile = caFile
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"path"
+
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// builtInFilename is the name of the file holding the NewBuiltIn function of
+// a plugin package.
+const builtInFilename = "builtin_ext_plugin.pb.go"
+
+// goPackages groups the generated files by Go package, in generation order.
+type goPackages struct {
+	paths   []protogen.GoImportPath
+	byPaths map[protogen.GoImportPath][]*protogen.File
+}
+
+func (p *goPackages) add(file *protogen.File) {
+	if p.byPaths == nil {
+		p.byPaths = map[protogen.GoImportPath][]*protogen.File{}
+	}
+	if _, ok := p.byPaths[file.GoImportPath]; !ok {
+		p.paths = append(p.paths, file.GoImportPath)
+	}
+	p.byPaths[file.GoImportPath] = append(p.byPaths[file.GoImportPath], file)
+}
+
+func (p *goPackages) files() [][]*protogen.File {
+	files := make([][]*protogen.File, 0, len(p.paths))
+	for _, importPath := range p.paths {
+		files = append(files, p.byPaths[importPath])
+	}
+	return files
+}
+
+// generateBuiltInFile generates the NewBuiltIn function of the Go package of
+// the given files, registering an implementation of the plugin services of
+// the package as a built-in plugin. The files must all be generated at once
+// for the function to detect every plugin service of the package.
+func generateBuiltInFile(gen *protogen.Plugin, files []*protogen.File) *protogen.GeneratedFile {
+	first := files[0]
+	filename := path.Join(path.Dir(first.GeneratedFilenamePrefix), builtInFilename)
+	g := gen.NewGeneratedFile(filename, first.GoImportPath)
+	g.P("// Code generated by protoc-gen-go-extension. DO NOT EDIT.")
+	g.P()
+	g.P("package ", first.GoPackageName)
+	g.P()
+	g.P("// NewBuiltIn registers impl as the built-in plugin of the given name with")
+	g.P("// api.RegisterBuiltIn. The plugin serves every plugin service of the package")
+	g.P("// impl implements, the first one being the plugin type, and the config")
+	g.P("// service if impl implements configv1.ConfigServer.")
+	g.P("func NewBuiltIn(name string, impl any) error {")
+	g.P("var servers []", g.QualifiedGoIdent(pluginsdkPackage.Ident("PluginServer")))
+	for _, file := range files {
+		for _, service := range file.Services {
+			g.P("if server, ok := impl.(", service.GoName, "Server); ok {")
+			g.P("servers = append(servers, ", service.GoName, "PluginServer(server))")
+			g.P("}")
+		}
+	}
+	g.P("if len(servers) == 0 {")
+	g.P("return ", g.QualifiedGoIdent(fmtPackage.Ident("Errorf")), "(\"built-in plugin %q does not implement any plugin service of package ", first.GoPackageName, "\", name)")
+	g.P("}")
+	g.P("var services []", g.QualifiedGoIdent(pluginsdkPackage.Ident("ServiceServer")))
+	g.P("for _, server := range servers[1:] {")
+	g.P("services = append(services, server)")
+	g.P("}")
+	g.P("if config, ok := impl.(", g.QualifiedGoIdent(configPackage.Ident("ConfigServer")), "); ok {")
+	g.P("services = append(services, ", g.QualifiedGoIdent(configPackage.Ident("ConfigServiceServer")), "(config))")
+	g.P("}")
+	g.P(g.QualifiedGoIdent(pluginsdkPackage.Ident("RegisterBuiltIn")), "(name, servers[0], services...)")
+	g.P("return nil")
+	g.P("}")
+	return g
+}
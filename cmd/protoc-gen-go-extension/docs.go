@@ -0,0 +1,402 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	"text/template"
+
+	"buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/openkcm/plugin-sdk/internal/consts"
+)
+
+// docsPage is the reference documentation of a plugin type.
+type docsPage struct {
+	Type            string
+	Source          string
+	Package         string
+	ProtocolVersion int
+	ConfigService   string
+	Services        []docsService
+	Messages        []docsMessage
+	Enums           []docsEnum
+}
+
+type docsService struct {
+	Name        string
+	Comment     string
+	Deprecated  bool
+	Constraints string
+	Methods     []docsMethod
+}
+
+type docsMethod struct {
+	Name            string
+	Comment         string
+	Deprecated      bool
+	Request         docsType
+	Response        docsType
+	ClientStreaming bool
+	ServerStreaming bool
+}
+
+type docsMessage struct {
+	Name       string
+	Anchor     string
+	Comment    string
+	Deprecated bool
+	Fields     []docsField
+}
+
+type docsField struct {
+	Name        string
+	Number      int
+	Label       string
+	Type        docsType
+	Comment     string
+	Deprecated  bool
+	Constraints string
+}
+
+type docsEnum struct {
+	Name       string
+	Anchor     string
+	Comment    string
+	Deprecated bool
+	Values     []docsEnumValue
+}
+
+type docsEnumValue struct {
+	Name       string
+	Number     int
+	Comment    string
+	Deprecated bool
+}
+
+// docsType is the type of a field or the request or response of a RPC. The
+// anchor is only set for the types documented on the same page.
+type docsType struct {
+	Name   string
+	Anchor string
+}
+
+// generateDocsFile generates the reference documentation of the plugin type
+// defined by the given file, in the given format ("markdown" or "html").
+func generateDocsFile(gen *protogen.Plugin, file *protogen.File, format string) (*protogen.GeneratedFile, error) {
+	if len(file.Services) == 0 {
+		return nil, nil
+	}
+
+	page := newDocsPage(file)
+	var buf bytes.Buffer
+	var err error
+	var filename string
+	switch format {
+	case "markdown":
+		filename = file.GeneratedFilenamePrefix + ".md"
+		err = markdownTemplate.Execute(&buf, page)
+	case "html":
+		filename = file.GeneratedFilenamePrefix + ".html"
+		err = htmlTemplate.Execute(&buf, page)
+	default:
+		return nil, fmt.Errorf(`invalid format %q: expecting either "markdown" or "html"`, format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to render the documentation of %s: %w", file.Desc.Path(), err)
+	}
+
+	g := gen.NewGeneratedFile(filename, file.GoImportPath)
+	if _, err := g.Write(buf.Bytes()); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+func newDocsPage(file *protogen.File) docsPage {
+	page := docsPage{
+		// The type of a plugin is the name of its service, see generateServiceBridges.
+		Type:            file.Services[0].GoName,
+		Source:          file.Desc.Path(),
+		Package:         string(file.Desc.Package()),
+		ProtocolVersion: consts.ProtocolVersion,
+		ConfigService:   configServiceName,
+	}
+	for _, service := range file.Services {
+		page.Services = append(page.Services, newDocsService(file, service))
+	}
+	var addMessages func([]*protogen.Message)
+	addMessages = func(messages []*protogen.Message) {
+		for _, message := range messages {
+			if message.Desc.IsMapEntry() {
+				continue
+			}
+			page.Messages = append(page.Messages, newDocsMessage(file, message))
+			for _, enum := range message.Enums {
+				page.Enums = append(page.Enums, newDocsEnum(enum))
+			}
+			addMessages(message.Messages)
+		}
+	}
+	addMessages(file.Messages)
+	for _, enum := range file.Enums {
+		page.Enums = append(page.Enums, newDocsEnum(enum))
+	}
+	return page
+}
+
+func newDocsService(file *protogen.File, service *protogen.Service) docsService {
+	doc := docsService{
+		Name:       string(service.Desc.FullName()),
+		Comment:    comment(service.Comments.Leading),
+		Deprecated: isDeprecated(service.Desc),
+	}
+	if minPlugins, maxPlugins, ok := pluginConstraints(service.Desc); ok {
+		doc.Constraints = fmt.Sprintf("min %d, max %d", minPlugins, maxPlugins)
+	}
+	for _, method := range service.Methods {
+		doc.Methods = append(doc.Methods, docsMethod{
+			Name:            string(method.Desc.Name()),
+			Comment:         comment(method.Comments.Leading),
+			Deprecated:      isDeprecated(method.Desc),
+			Request:         newDocsType(file, method.Input.Desc),
+			Response:        newDocsType(file, method.Output.Desc),
+			ClientStreaming: method.Desc.IsStreamingClient(),
+			ServerStreaming: method.Desc.IsStreamingServer(),
+		})
+	}
+	return doc
+}
+
+func newDocsMessage(file *protogen.File, message *protogen.Message) docsMessage {
+	doc := docsMessage{
+		Name:       relativeName(message.Desc),
+		Anchor:     anchor(message.Desc),
+		Comment:    comment(message.Comments.Leading),
+		Deprecated: isDeprecated(message.Desc),
+	}
+	for _, field := range message.Fields {
+		doc.Fields = append(doc.Fields, docsField{
+			Name:        string(field.Desc.Name()),
+			Number:      int(field.Desc.Number()),
+			Label:       fieldLabel(field.Desc),
+			Type:        fieldType(file, field.Desc),
+			Comment:     comment(field.Comments.Leading),
+			Deprecated:  isDeprecated(field.Desc),
+			Constraints: fieldConstraints(field.Desc),
+		})
+	}
+	return doc
+}
+
+func newDocsEnum(enum *protogen.Enum) docsEnum {
+	doc := docsEnum{
+		Name:       relativeName(enum.Desc),
+		Anchor:     anchor(enum.Desc),
+		Comment:    comment(enum.Comments.Leading),
+		Deprecated: isDeprecated(enum.Desc),
+	}
+	for _, value := range enum.Values {
+		doc.Values = append(doc.Values, docsEnumValue{
+			Name:       string(value.Desc.Name()),
+			Number:     int(value.Desc.Number()),
+			Comment:    comment(value.Comments.Leading),
+			Deprecated: isDeprecated(value.Desc),
+		})
+	}
+	return doc
+}
+
+func newDocsType(file *protogen.File, desc protoreflect.Descriptor) docsType {
+	if desc.ParentFile().Path() != file.Desc.Path() {
+		return docsType{Name: string(desc.FullName())}
+	}
+	return docsType{Name: relativeName(desc), Anchor: anchor(desc)}
+}
+
+func fieldType(file *protogen.File, field protoreflect.FieldDescriptor) docsType {
+	switch {
+	case field.IsMap():
+		key, value := fieldType(file, field.MapKey()), fieldType(file, field.MapValue())
+		return docsType{Name: "map<" + key.Name + ", " + value.Name + ">", Anchor: value.Anchor}
+	case field.Message() != nil:
+		return newDocsType(file, field.Message())
+	case field.Enum() != nil:
+		return newDocsType(file, field.Enum())
+	default:
+		return docsType{Name: field.Kind().String()}
+	}
+}
+
+func fieldLabel(field protoreflect.FieldDescriptor) string {
+	switch {
+	case field.IsMap():
+		return ""
+	case field.IsList():
+		return "repeated"
+	case field.HasOptionalKeyword():
+		return "optional"
+	case field.ContainingOneof() != nil:
+		return "oneof " + string(field.ContainingOneof().Name())
+	}
+	return ""
+}
+
+// fieldConstraints returns the protovalidate rules of the field in the
+// text format, e.g. "required:true string:{min_len:3}".
+func fieldConstraints(field protoreflect.FieldDescriptor) string {
+	rules, ok := proto.GetExtension(field.Options(), validate.E_Field).(*validate.FieldRules)
+	if !ok || rules == nil {
+		return ""
+	}
+	return prototext.MarshalOptions{}.Format(rules)
+}
+
+func isDeprecated(desc protoreflect.Descriptor) bool {
+	options, ok := desc.Options().(interface{ GetDeprecated() bool })
+	return ok && options.GetDeprecated()
+}
+
+// relativeName returns the name of the descriptor relative to its package,
+// e.g. "Outer.Inner" for nested messages.
+func relativeName(desc protoreflect.Descriptor) string {
+	return strings.TrimPrefix(string(desc.FullName()), string(desc.ParentFile().Package())+".")
+}
+
+func anchor(desc protoreflect.Descriptor) string {
+	return strings.ToLower(strings.ReplaceAll(string(desc.FullName()), ".", "-"))
+}
+
+func comment(comments protogen.Comments) string {
+	return strings.TrimSpace(string(comments))
+}
+
+var docsFuncs = template.FuncMap{
+	// cell formats the text as the content of a Markdown table cell.
+	"cell": func(s string) string {
+		return strings.ReplaceAll(strings.ReplaceAll(strings.TrimSpace(s), "|", `\|`), "\n", "<br>")
+	},
+	"streaming": func(streaming bool) string {
+		if streaming {
+			return "stream "
+		}
+		return ""
+	},
+}
+
+var markdownTemplate = template.Must(template.New("markdown").Funcs(docsFuncs).Parse(`<!-- Code generated by protoc-gen-go-extension. DO NOT EDIT. -->
+
+# {{.Type}} plugin
+
+Reference of the ` + "`{{.Type}}`" + ` plugin type, generated from ` + "`{{.Source}}`" + ` (package ` + "`{{.Package}}`" + `).
+
+## Handshake
+
+| Property | Value |
+|---|---|
+| Plugin type | ` + "`{{.Type}}`" + ` |
+| Magic cookie key | ` + "`{{.Type}}`" + ` |
+| Magic cookie value | ` + "`{{.Type}}`" + ` |
+| Protocol version | {{.ProtocolVersion}} |
+| Config service | Optional, plugins implementing ` + "`{{.ConfigService}}`" + ` are configured by the host |
+
+## Services
+{{range .Services}}
+### {{.Name}}
+{{if .Deprecated}}
+**Deprecated.**
+{{end}}{{with .Comment}}
+{{.}}
+{{end}}{{with .Constraints}}
+Plugins required by hosts: {{.}}.
+{{end}}
+| RPC | Request | Response | Description |
+|---|---|---|---|
+{{range .Methods}}| ` + "`{{.Name}}`" + `{{if .Deprecated}} (deprecated){{end}} | {{streaming .ClientStreaming}}{{template "type" .Request}} | {{streaming .ServerStreaming}}{{template "type" .Response}} | {{cell .Comment}} |
+{{end}}{{end}}
+## Messages
+{{range .Messages}}
+### <a id="{{.Anchor}}"></a>{{.Name}}
+{{if .Deprecated}}
+**Deprecated.**
+{{end}}{{with .Comment}}
+{{.}}
+{{end}}{{if .Fields}}
+| Field | Number | Type | Constraints | Description |
+|---|---|---|---|---|
+{{range .Fields}}| ` + "`{{.Name}}`" + `{{if .Deprecated}} (deprecated){{end}} | {{.Number}} | {{with .Label}}{{.}} {{end}}{{template "type" .Type}} | {{with .Constraints}}` + "`{{cell .}}`" + `{{end}} | {{cell .Comment}} |
+{{end}}{{end}}{{end}}{{if .Enums}}
+## Enums
+{{range .Enums}}
+### <a id="{{.Anchor}}"></a>{{.Name}}
+{{if .Deprecated}}
+**Deprecated.**
+{{end}}{{with .Comment}}
+{{.}}
+{{end}}
+| Value | Number | Description |
+|---|---|---|
+{{range .Values}}| ` + "`{{.Name}}`" + `{{if .Deprecated}} (deprecated){{end}} | {{.Number}} | {{cell .Comment}} |
+{{end}}{{end}}{{end}}
+{{- define "type"}}{{if .Anchor}}[` + "`{{.Name}}`" + `](#{{.Anchor}}){{else}}` + "`{{.Name}}`" + `{{end}}{{end}}`))
+
+var htmlTemplate = htmltemplate.Must(htmltemplate.New("html").Funcs(htmltemplate.FuncMap{"streaming": docsFuncs["streaming"]}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="generator" content="protoc-gen-go-extension">
+<title>{{.Type}} plugin</title>
+</head>
+<body>
+<h1>{{.Type}} plugin</h1>
+<p>Reference of the <code>{{.Type}}</code> plugin type, generated from <code>{{.Source}}</code> (package <code>{{.Package}}</code>).</p>
+
+<h2>Handshake</h2>
+<table>
+<tr><th>Property</th><th>Value</th></tr>
+<tr><td>Plugin type</td><td><code>{{.Type}}</code></td></tr>
+<tr><td>Magic cookie key</td><td><code>{{.Type}}</code></td></tr>
+<tr><td>Magic cookie value</td><td><code>{{.Type}}</code></td></tr>
+<tr><td>Protocol version</td><td>{{.ProtocolVersion}}</td></tr>
+<tr><td>Config service</td><td>Optional, plugins implementing <code>{{.ConfigService}}</code> are configured by the host</td></tr>
+</table>
+
+<h2>Services</h2>
+{{range .Services}}
+<h3>{{.Name}}</h3>
+{{if .Deprecated}}<p><strong>Deprecated.</strong></p>{{end}}
+{{with .Comment}}<p>{{.}}</p>{{end}}
+{{with .Constraints}}<p>Plugins required by hosts: {{.}}.</p>{{end}}
+<table>
+<tr><th>RPC</th><th>Request</th><th>Response</th><th>Description</th></tr>
+{{range .Methods}}<tr><td><code>{{.Name}}</code>{{if .Deprecated}} (deprecated){{end}}</td><td>{{streaming .ClientStreaming}}{{template "type" .Request}}</td><td>{{streaming .ServerStreaming}}{{template "type" .Response}}</td><td>{{.Comment}}</td></tr>
+{{end}}</table>
+{{end}}
+<h2>Messages</h2>
+{{range .Messages}}
+<h3 id="{{.Anchor}}">{{.Name}}</h3>
+{{if .Deprecated}}<p><strong>Deprecated.</strong></p>{{end}}
+{{with .Comment}}<p>{{.}}</p>{{end}}
+{{if .Fields}}<table>
+<tr><th>Field</th><th>Number</th><th>Type</th><th>Constraints</th><th>Description</th></tr>
+{{range .Fields}}<tr><td><code>{{.Name}}</code>{{if .Deprecated}} (deprecated){{end}}</td><td>{{.Number}}</td><td>{{with .Label}}{{.}} {{end}}{{template "type" .Type}}</td><td>{{with .Constraints}}<code>{{.}}</code>{{end}}</td><td>{{.Comment}}</td></tr>
+{{end}}</table>{{end}}
+{{end}}
+{{if .Enums}}<h2>Enums</h2>
+{{range .Enums}}
+<h3 id="{{.Anchor}}">{{.Name}}</h3>
+{{if .Deprecated}}<p><strong>Deprecated.</strong></p>{{end}}
+{{with .Comment}}<p>{{.}}</p>{{end}}
+<table>
+<tr><th>Value</th><th>Number</th><th>Description</th></tr>
+{{range .Values}}<tr><td><code>{{.Name}}</code>{{if .Deprecated}} (deprecated){{end}}</td><td>{{.Number}}</td><td>{{.Comment}}</td></tr>
+{{end}}</table>
+{{end}}{{end}}
+</body>
+</html>
+{{define "type"}}{{if .Anchor}}<a href="#{{.Anchor}}"><code>{{.Name}}</code></a>{{else}}<code>{{.Name}}</code>{{end}}{{end}}`))
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"go/token"
+	"go/types"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// maxPlainResults is the maximum number of response fields the idiomatic
+// facade methods return as plain results. Larger responses are returned as
+// the response message.
+const maxPlainResults = 3
+
+// reservedNames are the names used by the idiomatic facade methods which the
+// parameters and results must not shadow.
+var reservedNames = map[string]bool{"ctx": true, "f": true, "resp": true, "err": true}
+
+// idiomaticMethodName returns the name of the idiomatic facade method of the
+// RPC, or false if the RPC has none: streaming RPCs, requests with oneofs,
+// RPCs without a facade method and names clashing with another method of the
+// facade are left out.
+func idiomaticMethodName(service *protogen.Service, method *protogen.Method) (string, bool) {
+	if method.Desc.IsStreamingClient() || method.Desc.IsStreamingServer() {
+		return "", false
+	}
+	for _, oneof := range method.Input.Oneofs {
+		if !oneof.Desc.IsSynthetic() {
+			return "", false
+		}
+	}
+	name := "Call" + method.GoName
+	if facadeMethods[name] || facadeMethods[method.GoName] {
+		return "", false
+	}
+	for _, other := range service.Methods {
+		if other.GoName == name {
+			return "", false
+		}
+	}
+	return name, true
+}
+
+// generateIdiomaticFacadeMethod generates the facade method calling the given
+// unary RPC with the fields of its request as parameters. The fields of the
+// response are returned as plain results, unless it has more than
+// maxPlainResults fields.
+func generateIdiomaticFacadeMethod(g *protogen.GeneratedFile, service *protogen.Service, method *protogen.Method) {
+	name, ok := idiomaticMethodName(service, method)
+	if !ok {
+		return
+	}
+
+	facadeType := service.GoName + "Facade"
+	ctx := g.QualifiedGoIdent(contextPackage.Ident("Context"))
+	in := g.QualifiedGoIdent(method.Input.GoIdent)
+	out := g.QualifiedGoIdent(method.Output.GoIdent)
+
+	used := map[string]bool{}
+	params := []string{"ctx " + ctx}
+	fields := make([]string, 0, len(method.Input.Fields))
+	for _, field := range method.Input.Fields {
+		param := paramName(field, used)
+		params = append(params, param+" "+fieldGoType(g, field, true))
+		fields = append(fields, field.GoName+": "+param)
+	}
+	request := "&" + in + "{" + strings.Join(fields, ", ") + "}"
+
+	var results, values []string
+	if len(method.Output.Fields) <= maxPlainResults {
+		for _, field := range method.Output.Fields {
+			results = append(results, paramName(field, used)+" "+fieldGoType(g, field, false))
+			values = append(values, "resp.Get"+field.GoName+"()")
+		}
+		if len(results) > 0 {
+			results = append(results, "err error")
+		}
+	} else {
+		results = []string{"*" + out, "error"}
+		values = []string{"resp"}
+	}
+
+	g.P()
+	g.P("// ", name, " calls ", method.Desc.Name(), " on the plugin with the fields of the")
+	g.P("// request, prefixing errors with the plugin name.")
+	switch {
+	case len(results) == 0:
+		g.P("func (f *", facadeType, ") ", name, "(", strings.Join(params, ", "), ") error {")
+		g.P("_, err := f.", method.GoName, "(ctx, ", request, ")")
+		g.P("return err")
+	default:
+		g.P("func (f *", facadeType, ") ", name, "(", strings.Join(params, ", "), ") (", strings.Join(results, ", "), ") {")
+		g.P("resp, err := f.", method.GoName, "(ctx, ", request, ")")
+		g.P("return ", strings.Join(values, ", "), ", err")
+	}
+	g.P("}")
+}
+
+// paramName returns the name of the parameter or result holding the field,
+// escaped if it is a Go keyword, a predeclared identifier, a name the
+// generated method uses or one of the used names, to which it is added.
+func paramName(field *protogen.Field, used map[string]bool) string {
+	r, size := utf8.DecodeRuneInString(field.GoName)
+	name := string(unicode.ToLower(r)) + field.GoName[size:]
+	for token.IsKeyword(name) || types.Universe.Lookup(name) != nil || reservedNames[name] || used[name] {
+		name += "_"
+	}
+	used[name] = true
+	return name
+}
+
+// fieldGoType returns the Go type of the field. Scalar fields with explicit
+// presence are pointers if withPresence is set, as in the message struct;
+// otherwise the type is the one returned by the getter of the field.
+func fieldGoType(g *protogen.GeneratedFile, field *protogen.Field, withPresence bool) string {
+	if field.Desc.IsMap() {
+		key := fieldGoType(g, field.Message.Fields[0], false)
+		value := fieldGoType(g, field.Message.Fields[1], false)
+		return "map[" + key + "]" + value
+	}
+
+	var goType string
+	switch field.Desc.Kind() {
+	case protoreflect.BoolKind:
+		goType = "bool"
+	case protoreflect.EnumKind:
+		goType = g.QualifiedGoIdent(field.Enum.GoIdent)
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		goType = "int32"
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		goType = "uint32"
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		goType = "int64"
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		goType = "uint64"
+	case protoreflect.FloatKind:
+		goType = "float32"
+	case protoreflect.DoubleKind:
+		goType = "float64"
+	case protoreflect.StringKind:
+		goType = "string"
+	case protoreflect.BytesKind:
+		goType = "[]byte"
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		goType = "*" + g.QualifiedGoIdent(field.Message.GoIdent)
+	}
+
+	switch {
+	case field.Desc.IsList():
+		return "[]" + goType
+	case withPresence && field.Desc.HasPresence() && field.Desc.Message() == nil &&
+		field.Desc.Kind() != protoreflect.BytesKind:
+		return "*" + goType
+	}
+	return goType
+}
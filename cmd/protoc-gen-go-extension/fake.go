@@ -0,0 +1,176 @@
+package main
+
+import (
+	"path"
+
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+const syncPackage = protogen.GoImportPath("sync")
+
+// generateFakeFile generates the fakes of the plugin services of the given
+// file into the "<package>fake" package next to it, e.g. testv1fake for
+// testv1. The fakes can be loaded as built-in plugins to test hosts.
+func generateFakeFile(gen *protogen.Plugin, file *protogen.File) (*protogen.GeneratedFile, error) {
+	if len(file.Services) == 0 {
+		return nil, nil
+	}
+
+	packageName := string(file.GoPackageName) + "fake"
+	prefix := file.GeneratedFilenamePrefix
+	filename := path.Join(path.Dir(prefix), packageName, path.Base(prefix)+"_fake.pb.go")
+
+	g := gen.NewGeneratedFile(filename, file.GoImportPath+"/"+protogen.GoImportPath(packageName))
+	g.P("// Code generated by protoc-gen-go-extension. DO NOT EDIT.")
+	g.P()
+	g.P("// Package ", packageName, " provides fakes of the plugins of package ", file.GoPackageName, ".")
+	g.P("package ", packageName)
+	for _, service := range file.Services {
+		generateFake(g, file, service)
+	}
+	return g, nil
+}
+
+// generateFake generates the fake of the given plugin service. Each call of
+// a unary method is recorded and answered by the next scripted response, if
+// any, or else by the function of the method, if set, or else with an empty
+// response. Streaming methods are only answered by their function.
+func generateFake(g *protogen.GeneratedFile, file *protogen.File, service *protogen.Service) {
+	fakeType := service.GoName
+	unimplemented := g.QualifiedGoIdent(file.GoImportPath.Ident("Unimplemented" + service.GoName + "Server"))
+	ctx := g.QualifiedGoIdent(contextPackage.Ident("Context"))
+
+	g.P()
+	g.P("// ", fakeType, " is a fake ", service.GoName, " plugin. The calls of its unary")
+	g.P("// methods are recorded and answered by the next response scripted with the")
+	g.P("// Return methods, or else by the function of the method, if set, or else")
+	g.P("// with an empty response. Streaming methods are only answered by their")
+	g.P("// function.")
+	g.P("type ", fakeType, " struct {")
+	g.P(unimplemented)
+	g.P()
+	for _, method := range service.Methods {
+		g.P("// ", method.GoName, "Func answers the calls of ", method.GoName, ", if set.")
+		g.P(method.GoName, "Func func(", fakeFuncParams(g, ctx, method), ") ", fakeFuncResults(g, method))
+	}
+	g.P()
+	g.P("mu ", g.QualifiedGoIdent(syncPackage.Ident("Mutex")))
+	for _, method := range service.Methods {
+		if isStreaming(method) {
+			continue
+		}
+		in := g.QualifiedGoIdent(method.Input.GoIdent)
+		out := g.QualifiedGoIdent(method.Output.GoIdent)
+		name := unexport(method.GoName)
+		g.P(name, "Calls []*", in)
+		g.P(name, "Results []func() (*", out, ", error)")
+	}
+	g.P("}")
+
+	g.P()
+	g.P("// PluginServer returns the plugin server of the fake, e.g. to load it as a")
+	g.P("// built-in plugin with catalog.MakeBuiltIn.")
+	g.P("func (f *", fakeType, ") PluginServer() ", g.QualifiedGoIdent(pluginsdkPackage.Ident("PluginServer")), " {")
+	g.P("return ", g.QualifiedGoIdent(file.GoImportPath.Ident(service.GoName+"PluginServer")), "(f)")
+	g.P("}")
+
+	for _, method := range service.Methods {
+		if isStreaming(method) {
+			generateFakeStreamingMethod(g, ctx, fakeType, method)
+		} else {
+			generateFakeUnaryMethod(g, ctx, fakeType, method)
+		}
+	}
+}
+
+func generateFakeUnaryMethod(g *protogen.GeneratedFile, ctx, fakeType string, method *protogen.Method) {
+	in := g.QualifiedGoIdent(method.Input.GoIdent)
+	out := g.QualifiedGoIdent(method.Output.GoIdent)
+	name := unexport(method.GoName)
+
+	g.P()
+	g.P("func (f *", fakeType, ") ", method.GoName, "(ctx ", ctx, ", req *", in, ") (*", out, ", error) {")
+	g.P("f.mu.Lock()")
+	g.P("f.", name, "Calls = append(f.", name, "Calls, req)")
+	g.P("var result func() (*", out, ", error)")
+	g.P("if len(f.", name, "Results) > 0 {")
+	g.P("result, f.", name, "Results = f.", name, "Results[0], f.", name, "Results[1:]")
+	g.P("}")
+	g.P("fn := f.", method.GoName, "Func")
+	g.P("f.mu.Unlock()")
+	g.P()
+	g.P("switch {")
+	g.P("case result != nil:")
+	g.P("return result()")
+	g.P("case fn != nil:")
+	g.P("return fn(ctx, req)")
+	g.P("}")
+	g.P("return &", out, "{}, nil")
+	g.P("}")
+
+	g.P()
+	g.P("// Return", method.GoName, " scripts the response of the next call of ", method.GoName, " not")
+	g.P("// answered by a previously scripted response.")
+	g.P("func (f *", fakeType, ") Return", method.GoName, "(resp *", out, ", err error) {")
+	g.P("f.mu.Lock()")
+	g.P("defer f.mu.Unlock()")
+	g.P("f.", name, "Results = append(f.", name, "Results, func() (*", out, ", error) { return resp, err })")
+	g.P("}")
+
+	g.P()
+	g.P("// ", method.GoName, "Calls returns the requests of the calls of ", method.GoName, ".")
+	g.P("func (f *", fakeType, ") ", method.GoName, "Calls() []*", in, " {")
+	g.P("f.mu.Lock()")
+	g.P("defer f.mu.Unlock()")
+	g.P("return append([]*", in, "(nil), f.", name, "Calls...)")
+	g.P("}")
+}
+
+func generateFakeStreamingMethod(g *protogen.GeneratedFile, ctx, fakeType string, method *protogen.Method) {
+	g.P()
+	g.P("func (f *", fakeType, ") ", method.GoName, "(", fakeFuncParams(g, ctx, method), ") error {")
+	g.P("if f.", method.GoName, "Func == nil {")
+	g.P("return f.Unimplemented", method.Parent.GoName, "Server.", method.GoName, "(", fakeFuncArgs(method), ")")
+	g.P("}")
+	g.P("return f.", method.GoName, "Func(", fakeFuncArgs(method), ")")
+	g.P("}")
+}
+
+// fakeFuncParams returns the parameters of the server method of the given
+// RPC, named as passed by fakeFuncArgs.
+func fakeFuncParams(g *protogen.GeneratedFile, ctx string, method *protogen.Method) string {
+	in := g.QualifiedGoIdent(method.Input.GoIdent)
+	out := g.QualifiedGoIdent(method.Output.GoIdent)
+	switch {
+	case method.Desc.IsStreamingClient() && method.Desc.IsStreamingServer():
+		return "stream " + g.QualifiedGoIdent(grpcPackage.Ident("BidiStreamingServer")) + "[" + in + ", " + out + "]"
+	case method.Desc.IsStreamingClient():
+		return "stream " + g.QualifiedGoIdent(grpcPackage.Ident("ClientStreamingServer")) + "[" + in + ", " + out + "]"
+	case method.Desc.IsStreamingServer():
+		return "req *" + in + ", stream " + g.QualifiedGoIdent(grpcPackage.Ident("ServerStreamingServer")) + "[" + out + "]"
+	default:
+		return "ctx " + ctx + ", req *" + in
+	}
+}
+
+func fakeFuncArgs(method *protogen.Method) string {
+	switch {
+	case method.Desc.IsStreamingClient():
+		return "stream"
+	case method.Desc.IsStreamingServer():
+		return "req, stream"
+	default:
+		return "ctx, req"
+	}
+}
+
+func fakeFuncResults(g *protogen.GeneratedFile, method *protogen.Method) string {
+	if isStreaming(method) {
+		return "error"
+	}
+	return "(*" + g.QualifiedGoIdent(method.Output.GoIdent) + ", error)"
+}
+
+func isStreaming(method *protogen.Method) bool {
+	return method.Desc.IsStreamingClient() || method.Desc.IsStreamingServer()
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"flag"
 	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
 
@@ -12,26 +13,49 @@ import (
 
 const (
 	pluginsdkPackage = protogen.GoImportPath("github.com/openkcm/plugin-sdk/api")
+	facadePackage    = protogen.GoImportPath("github.com/openkcm/plugin-sdk/pkg/plugin")
+	contextPackage   = protogen.GoImportPath("context")
+	fmtPackage       = protogen.GoImportPath("fmt")
+	configPackage    = protogen.GoImportPath("github.com/openkcm/plugin-sdk/proto/service/common/config/v1")
 	grpcPackage      = protogen.GoImportPath("google.golang.org/grpc")
+
+	// configServiceName is the fully qualified name of the config service
+	// every plugin implements.
+	configServiceName = "service.common.config.v1.Config"
 )
 
+// packageVersionPattern matches the version of a proto package, e.g. the
+// "v1" of "plugin.test.v1".
+var packageVersionPattern = regexp.MustCompile(`(?:^|\.)v(\d+)$`)
+
+// facadeMethods are the methods of the generated facades which the methods
+// wrapping the plugin calls must not shadow. Calls of RPCs with these names
+// are made through the embedded plugin client instead.
+var facadeMethods = map[string]bool{
+	"InitInfo": true, "InitLog": true, "LogCall": true, "WrapErr": true, "Error": true, "Errorf": true,
+	"Name": true, "Type": true, "Tags": true, "Build": true, "Version": true, "SDKVersion": true, "Capabilities": true,
+	"GRPCServiceName": true, "InitClient": true, "IsInitialized": true,
+}
+
 var (
 	flags     flag.FlagSet
-	kind      = flags.String("kind", "plugin", `generation kind (either "plugin" or "service")`)
+	kind      = flags.String("kind", "plugin", `generation kind (either "plugin", "service", "fake" or "docs")`)
 	submodule = flags.String("submodule", "", `package location`)
+	format    = flags.String("format", "markdown", `documentation format (either "markdown" or "html")`)
 )
 
 func main() {
 	protogen.Options{ParamFunc: flags.Set}.Run(func(gen *protogen.Plugin) error {
 		isPlugin := false
 		switch *kind {
-		case "service":
+		case "service", "fake", "docs":
 		case "plugin":
 			isPlugin = true
 		default:
-			return fmt.Errorf(`invalid kind %q: expecting either "plugin" or "service"`, *kind)
+			return fmt.Errorf(`invalid kind %q: expecting either "plugin", "service", "fake" or "docs"`, *kind)
 		}
 		gen.SupportedFeatures = uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL)
+		var packages goPackages
 		for _, f := range gen.Files {
 			if !f.Generate {
 				continue
@@ -39,16 +63,35 @@ func main() {
 			if !strings.Contains(f.GoImportPath.String(), *submodule) {
 				continue
 			}
+			switch *kind {
+			case "fake":
+				if _, err := generateFakeFile(gen, f); err != nil {
+					return err
+				}
+				continue
+			case "docs":
+				if _, err := generateDocsFile(gen, f, *format); err != nil {
+					return err
+				}
+				continue
+			}
 			if _, err := generateFile(gen, f, isPlugin); err != nil {
 				return err
 			}
+			if isPlugin && len(f.Services) > 0 {
+				packages.add(f)
+			}
+		}
+		for _, files := range packages.files() {
+			generateBuiltInFile(gen, files)
 		}
 		return nil
 	})
 }
 
 func generateFile(gen *protogen.Plugin, file *protogen.File, isPlugin bool) (*protogen.GeneratedFile, error) {
-	if len(file.Services) == 0 {
+	sensitive := sensitiveMessages(file)
+	if len(file.Services) == 0 && len(sensitive) == 0 {
 		return nil, nil
 	}
 
@@ -71,7 +114,11 @@ func generateFile(gen *protogen.Plugin, file *protogen.File, isPlugin bool) (*pr
 		if err != nil {
 			return nil, err
 		}
+		if isPlugin {
+			generatePluginRepo(g, service)
+		}
 	}
+	generateRedaction(g, sensitive)
 	return g, nil
 }
 
@@ -153,6 +200,126 @@ func generateServiceBridges(g *protogen.GeneratedFile, serviceName, serviceFullN
 	return nil
 }
 
+// generatePluginRepo generates the plugin repository, version and facade
+// hosts use to consume the plugin service. The constraints of the repository
+// are read from the (openkcm.options.v1.constraints) option of the service and the
+// version is deprecated along with the service.
+func generatePluginRepo(g *protogen.GeneratedFile, service *protogen.Service) {
+	serviceName := service.GoName
+	repoType := serviceName + "Repo"
+	versionType := serviceName + "Version"
+	facadeType := serviceName + "Facade"
+	pluginClientType := serviceName + "PluginClient"
+
+	minPlugins, maxPlugins, _ := pluginConstraints(service.Desc)
+
+	g.P()
+	g.P("// ", repoType, " is the repository of the ", serviceName, " plugins loaded by")
+	g.P("// the catalog. It is registered for the plugin Type.")
+	g.P("type ", repoType, " struct {")
+	g.P("facades []*", facadeType)
+	g.P("}")
+	g.P()
+	g.P("var _ ", g.QualifiedGoIdent(pluginsdkPackage.Ident("PluginRepo")), " = (*", repoType, ")(nil)")
+	g.P()
+	g.P("func (r *", repoType, ") Binder() any {")
+	g.P("return func(f *", facadeType, ") {")
+	g.P("r.facades = append(r.facades, f)")
+	g.P("}")
+	g.P("}")
+	g.P()
+	g.P("func (r *", repoType, ") Versions() []", g.QualifiedGoIdent(pluginsdkPackage.Ident("Version")), " {")
+	g.P("return []", g.QualifiedGoIdent(pluginsdkPackage.Ident("Version")), "{", versionType, "{}}")
+	g.P("}")
+	g.P()
+	g.P("func (r *", repoType, ") Constraints() ", g.QualifiedGoIdent(pluginsdkPackage.Ident("Constraints")), " {")
+	g.P("return ", g.QualifiedGoIdent(pluginsdkPackage.Ident("Constraints")), "{Min: ", minPlugins, ", Max: ", maxPlugins, "}")
+	g.P("}")
+	g.P()
+	g.P("func (r *", repoType, ") Clear() {")
+	g.P("r.facades = nil")
+	g.P("}")
+	g.P()
+	g.P("// Facades returns the facades of the loaded plugins.")
+	g.P("func (r *", repoType, ") Facades() []*", facadeType, " {")
+	g.P("return r.facades")
+	g.P("}")
+
+	g.P()
+	g.P("// ", versionType, " is the version of the ", serviceName, " plugin facade.")
+	g.P("type ", versionType, " struct{}")
+	g.P()
+	g.P("func (", versionType, ") New() ", g.QualifiedGoIdent(pluginsdkPackage.Ident("Facade")), " { return new(", facadeType, ") }")
+	g.P("func (", versionType, ") Deprecated() bool { return ", isDeprecated(service.Desc), " }")
+
+	g.P()
+	g.P("// ", facadeType, " is the facade of a loaded ", serviceName, " plugin.")
+	g.P("type ", facadeType, " struct {")
+	g.P(g.QualifiedGoIdent(facadePackage.Ident("Facade")))
+	g.P(pluginClientType)
+	g.P("}")
+	g.P()
+	g.P("func (f *", facadeType, ") Version() uint {")
+	g.P("return ", packageVersion(string(service.Desc.ParentFile().Package())))
+	g.P("}")
+	for _, method := range service.Methods {
+		generateFacadeMethod(g, service, method)
+		generateIdiomaticFacadeMethod(g, service, method)
+	}
+}
+
+// generateFacadeMethod generates the facade method calling the given RPC on
+// the plugin. Errors are prefixed with the plugin name and the calls logged
+// at debug level. The methods drop the call options of the gRPC client,
+// whose methods remain available through the embedded plugin client.
+func generateFacadeMethod(g *protogen.GeneratedFile, service *protogen.Service, method *protogen.Method) {
+	if facadeMethods[method.GoName] {
+		return
+	}
+
+	facadeType := service.GoName + "Facade"
+	clientField := service.GoName + "PluginClient"
+	fullMethodName := service.GoName + "_" + method.GoName + "_FullMethodName"
+	ctx := g.QualifiedGoIdent(contextPackage.Ident("Context"))
+	in := g.QualifiedGoIdent(method.Input.GoIdent)
+	out := g.QualifiedGoIdent(method.Output.GoIdent)
+
+	var params, args, result, req string
+	switch {
+	case method.Desc.IsStreamingClient() && method.Desc.IsStreamingServer():
+		params, args, req = "ctx "+ctx, "ctx", "nil"
+		result = g.QualifiedGoIdent(grpcPackage.Ident("BidiStreamingClient")) + "[" + in + ", " + out + "]"
+	case method.Desc.IsStreamingClient():
+		params, args, req = "ctx "+ctx, "ctx", "nil"
+		result = g.QualifiedGoIdent(grpcPackage.Ident("ClientStreamingClient")) + "[" + in + ", " + out + "]"
+	case method.Desc.IsStreamingServer():
+		params, args, req = "ctx "+ctx+", req *"+in, "ctx, req", "req"
+		result = g.QualifiedGoIdent(grpcPackage.Ident("ServerStreamingClient")) + "[" + out + "]"
+	default:
+		params, args, req = "ctx "+ctx+", req *"+in, "ctx, req", "req"
+		result = "*" + out
+	}
+
+	g.P()
+	g.P("// ", method.GoName, " calls ", method.Desc.Name(), " on the plugin, prefixing errors with the plugin name.")
+	g.P("func (f *", facadeType, ") ", method.GoName, "(", params, ") (", result, ", error) {")
+	g.P("f.LogCall(ctx, ", fullMethodName, ", ", req, ")")
+	g.P("resp, err := f.", clientField, ".", method.GoName, "(", args, ")")
+	g.P("return resp, f.WrapErr(err)")
+	g.P("}")
+}
+
+// packageVersion returns the version of the given proto package, or 1 if the
+// package is not versioned.
+func packageVersion(pkg string) uint64 {
+	if m := packageVersionPattern.FindStringSubmatch(pkg); m != nil {
+		if version, err := strconv.ParseUint(m[1], 10, 32); err == nil {
+			return version
+		}
+	}
+	return 1
+}
+
 func unexport(s string) string {
 	if len(s) < 1 {
 		return ""
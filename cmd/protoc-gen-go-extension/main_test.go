@@ -1,15 +1,27 @@
 package main
 
 import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
 	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/encoding/protowire"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
 	"google.golang.org/protobuf/reflect/protoreflect"
 	"google.golang.org/protobuf/types/descriptorpb"
 	"google.golang.org/protobuf/types/pluginpb"
+
+	optionsv1 "github.com/openkcm/plugin-sdk/proto/openkcm/options/v1"
 )
 
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
 func TestGenerateFile(t *testing.T) {
 	// create test cases
 	tests := []struct {
@@ -181,6 +193,479 @@ func TestGenerateServiceBridges(t *testing.T) {
 	}
 }
 
+func TestGeneratePluginRepo(t *testing.T) {
+	// Arrange
+	options := &descriptorpb.ServiceOptions{Deprecated: proto.Bool(true)}
+	proto.SetExtension(options, optionsv1.E_Constraints, &optionsv1.PluginConstraints{Min: 1, Max: 1})
+	gen, err := protogen.Options{}.New(&pluginpb.CodeGeneratorRequest{
+		FileToGenerate: []string{"testdata/foo/v2/foo.proto"},
+		ProtoFile: []*descriptorpb.FileDescriptorProto{
+			{
+				Name:    proto.String("testdata/foo/v2/foo.proto"),
+				Syntax:  proto.String(protoreflect.Proto3.String()),
+				Package: proto.String("testdata.foo.v2"),
+				Options: &descriptorpb.FileOptions{
+					GoPackage: proto.String("github.com/openkcm/foo/v2;foov2"),
+				},
+				MessageType: []*descriptorpb.DescriptorProto{
+					{Name: proto.String("Spell")},
+				},
+				Service: []*descriptorpb.ServiceDescriptorProto{
+					{
+						Name:    proto.String("DoMagic"),
+						Options: options,
+						Method: []*descriptorpb.MethodDescriptorProto{
+							{Name: proto.String("Cast"), InputType: proto.String(".testdata.foo.v2.Spell"), OutputType: proto.String(".testdata.foo.v2.Spell")},
+							{Name: proto.String("Chant"), InputType: proto.String(".testdata.foo.v2.Spell"), OutputType: proto.String(".testdata.foo.v2.Spell"), ServerStreaming: proto.Bool(true)},
+							{Name: proto.String("Duel"), InputType: proto.String(".testdata.foo.v2.Spell"), OutputType: proto.String(".testdata.foo.v2.Spell"), ClientStreaming: proto.Bool(true), ServerStreaming: proto.Bool(true)},
+							{Name: proto.String("Version"), InputType: proto.String(".testdata.foo.v2.Spell"), OutputType: proto.String(".testdata.foo.v2.Spell")},
+						},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Act
+	g, err := generateFile(gen, gen.Files[0], true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, err := g.Content()
+
+	// Assert
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"type DoMagicRepo struct",
+		"return api.Constraints{Min: 1, Max: 1}",
+		"func (DoMagicVersion) Deprecated() bool { return true }",
+		"type DoMagicFacade struct",
+		"return 2",
+		"func (f *DoMagicFacade) Cast(ctx context.Context, req *Spell) (*Spell, error) {",
+		"func (f *DoMagicFacade) Chant(ctx context.Context, req *Spell) (grpc.ServerStreamingClient[Spell], error) {",
+		"func (f *DoMagicFacade) Duel(ctx context.Context) (grpc.BidiStreamingClient[Spell, Spell], error) {",
+		"return resp, f.WrapErr(err)",
+	} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("generated code does not contain %q:\n%s", want, content)
+		}
+	}
+	if strings.Contains(string(content), "func (f *DoMagicFacade) Version(ctx") {
+		t.Errorf("generated code shadows the facade version:\n%s", content)
+	}
+}
+
+func TestGenerateFacadeGolden(t *testing.T) {
+	// Arrange
+	optional := descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum()
+	gen, err := protogen.Options{}.New(&pluginpb.CodeGeneratorRequest{
+		FileToGenerate: []string{"testdata/foo/v2/foo.proto"},
+		ProtoFile: []*descriptorpb.FileDescriptorProto{
+			{
+				Name:    proto.String("testdata/foo/v2/foo.proto"),
+				Syntax:  proto.String(protoreflect.Proto3.String()),
+				Package: proto.String("testdata.foo.v2"),
+				Options: &descriptorpb.FileOptions{
+					GoPackage: proto.String("github.com/openkcm/foo/v2;foov2"),
+				},
+				MessageType: []*descriptorpb.DescriptorProto{
+					{
+						Name: proto.String("Spell"),
+						Field: []*descriptorpb.FieldDescriptorProto{
+							{Name: proto.String("word"), Number: proto.Int32(1), Type: descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(), Label: optional},
+							{Name: proto.String("power"), Number: proto.Int32(2), Type: descriptorpb.FieldDescriptorProto_TYPE_INT32.Enum(), Label: optional, OneofIndex: proto.Int32(0), Proto3Optional: proto.Bool(true)},
+							{Name: proto.String("schools"), Number: proto.Int32(3), Type: descriptorpb.FieldDescriptorProto_TYPE_ENUM.Enum(), TypeName: proto.String(".testdata.foo.v2.School"), Label: descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()},
+							{Name: proto.String("runes"), Number: proto.Int32(4), Type: descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(), TypeName: proto.String(".testdata.foo.v2.Spell.RunesEntry"), Label: descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()},
+							{Name: proto.String("type"), Number: proto.Int32(5), Type: descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(), Label: optional},
+						},
+						NestedType: []*descriptorpb.DescriptorProto{
+							{
+								Name: proto.String("RunesEntry"),
+								Field: []*descriptorpb.FieldDescriptorProto{
+									{Name: proto.String("key"), Number: proto.Int32(1), Type: descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(), Label: optional},
+									{Name: proto.String("value"), Number: proto.Int32(2), Type: descriptorpb.FieldDescriptorProto_TYPE_BYTES.Enum(), Label: optional},
+								},
+								Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+							},
+						},
+						OneofDecl: []*descriptorpb.OneofDescriptorProto{{Name: proto.String("_power")}},
+					},
+					{
+						Name: proto.String("Outcome"),
+						Field: []*descriptorpb.FieldDescriptorProto{
+							{Name: proto.String("success"), Number: proto.Int32(1), Type: descriptorpb.FieldDescriptorProto_TYPE_BOOL.Enum(), Label: optional},
+							{Name: proto.String("word"), Number: proto.Int32(2), Type: descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(), Label: optional},
+						},
+					},
+					{
+						Name: proto.String("Book"),
+						Field: []*descriptorpb.FieldDescriptorProto{
+							{Name: proto.String("title"), Number: proto.Int32(1), Type: descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(), Label: optional},
+							{Name: proto.String("author"), Number: proto.Int32(2), Type: descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(), Label: optional},
+							{Name: proto.String("pages"), Number: proto.Int32(3), Type: descriptorpb.FieldDescriptorProto_TYPE_UINT32.Enum(), Label: optional},
+							{Name: proto.String("spells"), Number: proto.Int32(4), Type: descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(), TypeName: proto.String(".testdata.foo.v2.Spell"), Label: descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()},
+						},
+					},
+					{Name: proto.String("Empty")},
+				},
+				EnumType: []*descriptorpb.EnumDescriptorProto{
+					{
+						Name:  proto.String("School"),
+						Value: []*descriptorpb.EnumValueDescriptorProto{{Name: proto.String("SCHOOL_UNSPECIFIED"), Number: proto.Int32(0)}},
+					},
+				},
+				Service: []*descriptorpb.ServiceDescriptorProto{
+					{
+						Name: proto.String("DoMagic"),
+						Method: []*descriptorpb.MethodDescriptorProto{
+							{Name: proto.String("Cast"), InputType: proto.String(".testdata.foo.v2.Spell"), OutputType: proto.String(".testdata.foo.v2.Outcome")},
+							{Name: proto.String("Dispel"), InputType: proto.String(".testdata.foo.v2.Spell"), OutputType: proto.String(".testdata.foo.v2.Empty")},
+							{Name: proto.String("Read"), InputType: proto.String(".testdata.foo.v2.Empty"), OutputType: proto.String(".testdata.foo.v2.Book")},
+							{Name: proto.String("Chant"), InputType: proto.String(".testdata.foo.v2.Spell"), OutputType: proto.String(".testdata.foo.v2.Outcome"), ServerStreaming: proto.Bool(true)},
+							{Name: proto.String("Version"), InputType: proto.String(".testdata.foo.v2.Spell"), OutputType: proto.String(".testdata.foo.v2.Outcome")},
+						},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	golden := filepath.Join("testdata", "foo_ext_plugin.pb.go.golden")
+
+	// Act
+	g, err := generateFile(gen, gen.Files[0], true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, err := g.Content()
+
+	// Assert
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *update {
+		if err := os.WriteFile(golden, content, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(content, want) {
+		t.Errorf("generated code does not match %s, run the test with -update to update it:\n%s", golden, content)
+	}
+}
+
+func TestGenerateBuiltInFile(t *testing.T) {
+	// Arrange
+	file := func(name, service string) *descriptorpb.FileDescriptorProto {
+		return &descriptorpb.FileDescriptorProto{
+			Name:    proto.String("testdata/foo/v2/" + name),
+			Syntax:  proto.String(protoreflect.Proto3.String()),
+			Package: proto.String("testdata.foo.v2"),
+			Options: &descriptorpb.FileOptions{
+				GoPackage: proto.String("github.com/openkcm/foo/v2;foov2"),
+			},
+			Service: []*descriptorpb.ServiceDescriptorProto{{Name: proto.String(service)}},
+		}
+	}
+	gen, err := protogen.Options{}.New(&pluginpb.CodeGeneratorRequest{
+		FileToGenerate: []string{"testdata/foo/v2/foo.proto", "testdata/foo/v2/bar.proto"},
+		ProtoFile:      []*descriptorpb.FileDescriptorProto{file("foo.proto", "DoMagic"), file("bar.proto", "DoTricks")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var packages goPackages
+	for _, f := range gen.Files {
+		packages.add(f)
+	}
+
+	// Act
+	files := packages.files()
+	for _, f := range files {
+		generateBuiltInFile(gen, f)
+	}
+	resp := gen.Response()
+
+	// Assert
+	if len(files) != 1 || len(files[0]) != 2 {
+		t.Fatalf("unexpected packages %v", files)
+	}
+	if resp.Error != nil || len(resp.GetFile()) != 1 {
+		t.Fatalf("unexpected response %v", resp)
+	}
+	if want := "github.com/openkcm/foo/v2/builtin_ext_plugin.pb.go"; resp.GetFile()[0].GetName() != want {
+		t.Errorf("generated file %q, want %q", resp.GetFile()[0].GetName(), want)
+	}
+	content := resp.GetFile()[0].GetContent()
+	for _, want := range []string{
+		"func NewBuiltIn(name string, impl any) error {",
+		"if server, ok := impl.(DoMagicServer); ok {",
+		"servers = append(servers, DoTricksPluginServer(server))",
+		"if config, ok := impl.(v1.ConfigServer); ok {",
+		"api.RegisterBuiltIn(name, servers[0], services...)",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("generated code does not contain %q:\n%s", want, content)
+		}
+	}
+}
+
+func TestGenerateFakeFile(t *testing.T) {
+	// Arrange
+	gen, err := protogen.Options{}.New(&pluginpb.CodeGeneratorRequest{
+		FileToGenerate: []string{"testdata/foo/v2/foo.proto"},
+		ProtoFile: []*descriptorpb.FileDescriptorProto{
+			{
+				Name:    proto.String("testdata/foo/v2/foo.proto"),
+				Syntax:  proto.String(protoreflect.Proto3.String()),
+				Package: proto.String("testdata.foo.v2"),
+				Options: &descriptorpb.FileOptions{
+					GoPackage: proto.String("github.com/openkcm/foo/v2;foov2"),
+				},
+				MessageType: []*descriptorpb.DescriptorProto{
+					{Name: proto.String("Spell")},
+				},
+				Service: []*descriptorpb.ServiceDescriptorProto{
+					{
+						Name: proto.String("DoMagic"),
+						Method: []*descriptorpb.MethodDescriptorProto{
+							{Name: proto.String("Cast"), InputType: proto.String(".testdata.foo.v2.Spell"), OutputType: proto.String(".testdata.foo.v2.Spell")},
+							{Name: proto.String("Chant"), InputType: proto.String(".testdata.foo.v2.Spell"), OutputType: proto.String(".testdata.foo.v2.Spell"), ServerStreaming: proto.Bool(true)},
+						},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Act
+	_, err = generateFakeFile(gen, gen.Files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp := gen.Response()
+
+	// Assert
+	if resp.Error != nil || len(resp.GetFile()) != 1 {
+		t.Fatalf("unexpected response %v", resp)
+	}
+	file := resp.GetFile()[0]
+	if want := "github.com/openkcm/foo/v2/foov2fake/foo_fake.pb.go"; file.GetName() != want {
+		t.Errorf("generated file %q, want %q", file.GetName(), want)
+	}
+	for _, want := range []string{
+		"package foov2fake",
+		"type DoMagic struct",
+		"CastFunc func(ctx context.Context, req *v2.Spell) (*v2.Spell, error)",
+		"ChantFunc func(req *v2.Spell, stream grpc.ServerStreamingServer[v2.Spell]) error",
+		"func (f *DoMagic) ReturnCast(resp *v2.Spell, err error) {",
+		"func (f *DoMagic) CastCalls() []*v2.Spell {",
+		"return f.UnimplementedDoMagicServer.Chant(req, stream)",
+	} {
+		if !strings.Contains(file.GetContent(), want) {
+			t.Errorf("generated code does not contain %q:\n%s", want, file.GetContent())
+		}
+	}
+}
+
+func TestGenerateRedaction(t *testing.T) {
+	// Arrange
+	// The option is sent by protoc as an unknown field of the options,
+	// looked up in the imported options file.
+	sensitive := &descriptorpb.FieldOptions{}
+	sensitive.ProtoReflect().SetUnknown(protowire.AppendVarint(
+		protowire.AppendTag(nil, optionsv1.E_Sensitive.TypeDescriptor().Number(), protowire.VarintType), 1))
+	gen, err := protogen.Options{}.New(&pluginpb.CodeGeneratorRequest{
+		FileToGenerate: []string{"testdata/foo/v2/foo.proto"},
+		ProtoFile: []*descriptorpb.FileDescriptorProto{
+			protodesc.ToFileDescriptorProto(descriptorpb.File_google_protobuf_descriptor_proto),
+			protodesc.ToFileDescriptorProto(optionsv1.File_openkcm_options_v1_options_proto),
+			{
+				Name:       proto.String("testdata/foo/v2/foo.proto"),
+				Syntax:     proto.String(protoreflect.Proto3.String()),
+				Package:    proto.String("testdata.foo.v2"),
+				Dependency: []string{"openkcm/options/v1/options.proto"},
+				Options: &descriptorpb.FileOptions{
+					GoPackage: proto.String("github.com/openkcm/foo/v2;foov2"),
+				},
+				MessageType: []*descriptorpb.DescriptorProto{
+					{
+						Name: proto.String("Secret"),
+						Field: []*descriptorpb.FieldDescriptorProto{
+							{Name: proto.String("value"), Number: proto.Int32(1), Type: descriptorpb.FieldDescriptorProto_TYPE_BYTES.Enum(), Label: descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(), Options: sensitive},
+						},
+					},
+					{
+						Name: proto.String("Spell"),
+						Field: []*descriptorpb.FieldDescriptorProto{
+							{Name: proto.String("secret"), Number: proto.Int32(1), Type: descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(), TypeName: proto.String(".testdata.foo.v2.Secret"), Label: descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum()},
+						},
+					},
+					{
+						Name: proto.String("Charm"),
+						Field: []*descriptorpb.FieldDescriptorProto{
+							{Name: proto.String("name"), Number: proto.Int32(1), Type: descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(), Label: descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum()},
+						},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Act
+	_, err = generateFile(gen, gen.FilesByPath["testdata/foo/v2/foo.proto"], true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp := gen.Response()
+
+	// Assert
+	if resp.Error != nil || len(resp.GetFile()) != 1 {
+		t.Fatalf("unexpected response %v", resp)
+	}
+	content := resp.GetFile()[0].GetContent()
+	for _, want := range []string{
+		"func (x *Secret) Redacted() *Secret {",
+		"func (x *Secret) LogValue() slog.Value {",
+		"func (x *Spell) Redacted() *Spell {",
+		"return redact.Message(x)",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("generated code does not contain %q:\n%s", want, content)
+		}
+	}
+	if strings.Contains(content, "func (x *Charm)") {
+		t.Errorf("generated code redacts a message without sensitive fields:\n%s", content)
+	}
+}
+
+func TestGenerateDocsFile(t *testing.T) {
+	// Arrange
+	required := &descriptorpb.FieldOptions{}
+	proto.SetExtension(required, validate.E_Field, &validate.FieldRules{Required: proto.Bool(true)})
+	request := &pluginpb.CodeGeneratorRequest{
+		FileToGenerate: []string{"testdata/foo/v2/foo.proto"},
+		ProtoFile: []*descriptorpb.FileDescriptorProto{
+			{
+				Name:    proto.String("testdata/foo/v2/foo.proto"),
+				Syntax:  proto.String(protoreflect.Proto3.String()),
+				Package: proto.String("testdata.foo.v2"),
+				Options: &descriptorpb.FileOptions{
+					GoPackage: proto.String("github.com/openkcm/foo/v2;foov2"),
+				},
+				MessageType: []*descriptorpb.DescriptorProto{
+					{
+						Name: proto.String("Spell"),
+						Field: []*descriptorpb.FieldDescriptorProto{
+							{Name: proto.String("word"), Number: proto.Int32(1), Type: descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(), Label: descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(), Options: required},
+							{Name: proto.String("school"), Number: proto.Int32(2), Type: descriptorpb.FieldDescriptorProto_TYPE_ENUM.Enum(), TypeName: proto.String(".testdata.foo.v2.School"), Label: descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()},
+						},
+					},
+				},
+				EnumType: []*descriptorpb.EnumDescriptorProto{
+					{
+						Name:  proto.String("School"),
+						Value: []*descriptorpb.EnumValueDescriptorProto{{Name: proto.String("SCHOOL_UNSPECIFIED"), Number: proto.Int32(0)}},
+					},
+				},
+				Service: []*descriptorpb.ServiceDescriptorProto{
+					{
+						Name:    proto.String("DoMagic"),
+						Options: &descriptorpb.ServiceOptions{Deprecated: proto.Bool(true)},
+						Method: []*descriptorpb.MethodDescriptorProto{
+							{Name: proto.String("Cast"), InputType: proto.String(".testdata.foo.v2.Spell"), OutputType: proto.String(".testdata.foo.v2.Spell")},
+						},
+					},
+				},
+			},
+		},
+	}
+	tests := []struct {
+		format   string
+		filename string
+		want     []string
+	}{
+		{
+			format:   "markdown",
+			filename: "github.com/openkcm/foo/v2/foo.md",
+			want: []string{
+				"# DoMagic plugin",
+				"| Magic cookie key | `DoMagic` |",
+				"**Deprecated.**",
+				"| `Cast` | [`Spell`](#testdata-foo-v2-spell) | [`Spell`](#testdata-foo-v2-spell) |",
+				"| `school` | 2 | repeated [`School`](#testdata-foo-v2-school) |",
+				"| `SCHOOL_UNSPECIFIED` | 0 |",
+				"required:true",
+			},
+		},
+		{
+			format:   "html",
+			filename: "github.com/openkcm/foo/v2/foo.html",
+			want: []string{
+				"<h1>DoMagic plugin</h1>",
+				`<h3 id="testdata-foo-v2-spell">Spell</h3>`,
+				"required:true",
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.format, func(t *testing.T) {
+			gen, err := protogen.Options{}.New(request)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Act
+			_, err = generateDocsFile(gen, gen.Files[0], tc.format)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp := gen.Response()
+
+			// Assert
+			if resp.Error != nil || len(resp.GetFile()) != 1 || resp.GetFile()[0].GetName() != tc.filename {
+				t.Fatalf("unexpected response %v", resp)
+			}
+			for _, want := range tc.want {
+				if !strings.Contains(resp.GetFile()[0].GetContent(), want) {
+					t.Errorf("generated documentation does not contain %q:\n%s", want, resp.GetFile()[0].GetContent())
+				}
+			}
+		})
+	}
+}
+
+func TestPackageVersion(t *testing.T) {
+	tests := map[string]uint64{
+		"plugin.test.v1":  1,
+		"plugin.test.v12": 12,
+		"v3":              3,
+		"plugin.test":     1,
+		"plugin.testv2":   1,
+	}
+	for pkg, want := range tests {
+		if got := packageVersion(pkg); got != want {
+			t.Errorf("packageVersion(%q) = %v, want %v", pkg, got, want)
+		}
+	}
+}
+
 func TestUnexport(t *testing.T) {
 	// create test cases
 	tests := []struct {
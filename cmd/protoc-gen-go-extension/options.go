@@ -0,0 +1,99 @@
+package main
+
+import (
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// The options of the SDK are looked up by full name in the files imported by
+// the generated protos rather than through their Go package, so that the
+// generator builds before the Go code of the protos is generated.
+const (
+	constraintsOption protoreflect.FullName = "openkcm.options.v1.constraints"
+	sensitiveOption   protoreflect.FullName = "openkcm.options.v1.sensitive"
+)
+
+// isSensitive reports whether the field is marked with the
+// (openkcm.options.v1.sensitive) option.
+func isSensitive(field protoreflect.FieldDescriptor) bool {
+	value, ok := getOption(field, sensitiveOption)
+	return ok && value.Bool()
+}
+
+// pluginConstraints returns the minimum and maximum number of plugins set by
+// the (openkcm.options.v1.constraints) option of the service, if set.
+func pluginConstraints(service protoreflect.ServiceDescriptor) (minimum, maximum uint64, ok bool) {
+	value, ok := getOption(service, constraintsOption)
+	if !ok {
+		return 0, 0, false
+	}
+	constraints := value.Message()
+	field := func(name protoreflect.Name) uint64 {
+		if fd := constraints.Descriptor().Fields().ByName(name); fd != nil {
+			return constraints.Get(fd).Uint()
+		}
+		return 0
+	}
+	return field("min"), field("max"), true
+}
+
+// getOption returns the value of the option extension of the descriptor, if
+// set.
+func getOption(desc protoreflect.Descriptor, name protoreflect.FullName) (protoreflect.Value, bool) {
+	xt := findExtensionType(desc.ParentFile(), name)
+	if xt == nil {
+		return protoreflect.Value{}, false
+	}
+
+	// The options are parsed again with the extension known, since it is
+	// kept as unknown fields when its Go package is not linked in.
+	options := desc.Options()
+	data, err := proto.Marshal(options)
+	if err != nil {
+		return protoreflect.Value{}, false
+	}
+	resolver := new(protoregistry.Types)
+	if err := resolver.RegisterExtension(xt); err != nil {
+		return protoreflect.Value{}, false
+	}
+	parsed := options.ProtoReflect().Type().New()
+	if err := (proto.UnmarshalOptions{Resolver: resolver}).Unmarshal(data, parsed.Interface()); err != nil {
+		return protoreflect.Value{}, false
+	}
+	if !parsed.Has(xt.TypeDescriptor()) {
+		return protoreflect.Value{}, false
+	}
+	return parsed.Get(xt.TypeDescriptor()), true
+}
+
+// findExtensionType returns the type of the extension, looked up in the file
+// and the files it imports, directly or not, or else in the registered types.
+func findExtensionType(file protoreflect.FileDescriptor, name protoreflect.FullName) protoreflect.ExtensionType {
+	if xd := findExtension(file, name, map[string]bool{}); xd != nil {
+		return dynamicpb.NewExtensionType(xd)
+	}
+	xt, _ := protoregistry.GlobalTypes.FindExtensionByName(name)
+	return xt
+}
+
+func findExtension(file protoreflect.FileDescriptor, name protoreflect.FullName, visited map[string]bool) protoreflect.ExtensionDescriptor {
+	if file == nil || visited[file.Path()] {
+		return nil
+	}
+	visited[file.Path()] = true
+
+	if file.Package() == name.Parent() {
+		if xd := file.Extensions().ByName(name.Name()); xd != nil {
+			return xd
+		}
+	}
+	imports := file.Imports()
+	for i := range imports.Len() {
+		if xd := findExtension(imports.Get(i).FileDescriptor, name, visited); xd != nil {
+			return xd
+		}
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	redactPackage = protogen.GoImportPath("github.com/openkcm/plugin-sdk/pkg/redact")
+	slogPackage   = protogen.GoImportPath("log/slog")
+)
+
+// sensitiveMessages returns the messages of the file, including the nested
+// ones, holding fields marked with the (openkcm.options.v1.sensitive) option, directly
+// or in nested messages.
+func sensitiveMessages(file *protogen.File) []*protogen.Message {
+	var messages []*protogen.Message
+	var add func([]*protogen.Message)
+	add = func(candidates []*protogen.Message) {
+		for _, message := range candidates {
+			if !message.Desc.IsMapEntry() && holdsSensitiveFields(message.Desc, map[protoreflect.FullName]bool{}) {
+				messages = append(messages, message)
+			}
+			add(message.Messages)
+		}
+	}
+	add(file.Messages)
+	return messages
+}
+
+func holdsSensitiveFields(message protoreflect.MessageDescriptor, visited map[protoreflect.FullName]bool) bool {
+	if visited[message.FullName()] {
+		return false
+	}
+	visited[message.FullName()] = true
+
+	fields := message.Fields()
+	for i := range fields.Len() {
+		field := fields.Get(i)
+		if isSensitive(field) {
+			return true
+		}
+		if field.IsMap() {
+			field = field.MapValue()
+		}
+		if field.Message() != nil && holdsSensitiveFields(field.Message(), visited) {
+			return true
+		}
+	}
+	return false
+}
+
+// generateRedaction generates the methods redacting the sensitive fields of
+// the given messages, also when they are logged with slog.
+func generateRedaction(g *protogen.GeneratedFile, messages []*protogen.Message) {
+	for _, message := range messages {
+		name := message.GoIdent.GoName
+		g.P()
+		g.P("// Redacted returns a copy of the message with its sensitive fields redacted.")
+		g.P("func (x *", name, ") Redacted() *", name, " {")
+		g.P("return ", g.QualifiedGoIdent(redactPackage.Ident("Message")), "(x)")
+		g.P("}")
+		g.P()
+		g.P("// LogValue implements slog.LogValuer, logging the message redacted.")
+		g.P("func (x *", name, ") LogValue() ", g.QualifiedGoIdent(slogPackage.Ident("Value")), " {")
+		g.P("return ", g.QualifiedGoIdent(redactPackage.Ident("LogValue")), "(x)")
+		g.P("}")
+	}
+}
@@ -1,27 +1,68 @@
 package bootstrap
 
 import (
+	"os"
+
 	goplugin "github.com/hashicorp/go-plugin"
 
 	"github.com/openkcm/plugin-sdk/api"
+	"github.com/openkcm/plugin-sdk/internal/consts"
 )
 
+// ProtocolVersion is the version of the plugin protocol spoken by this SDK.
+// It is bumped with every major version of the SDK that breaks the protocol
+// and negotiated in the go-plugin handshake, so that a host can keep loading
+// plugins built against earlier major versions.
+const ProtocolVersion = consts.ProtocolVersion
+
+// MagicCookieKey is the key of the magic cookie the host passes to the plugins
+// it launches, the value being the plugin type. The cookie is not a security
+// measure: it only tells a plugin binary it is launched by a host rather than
+// run directly.
+//
+// Hosts and plugins built with earlier versions of the SDK use the plugin type
+// as both the key and the value of the cookie. The host passes the legacy
+// cookie along with this one and the plugin accepts either, so that plugins
+// and hosts of both generations keep loading each other.
+const MagicCookieKey = "OPENKCM_PLUGIN_TYPE"
+
 // ServerHandshakeConfig returns the handshake configuration for the given
 // server implementation.
 func ServerHandshakeConfig(pluginServer api.PluginServer) goplugin.HandshakeConfig {
-	return goplugin.HandshakeConfig{
-		ProtocolVersion:  1,
-		MagicCookieKey:   pluginServer.Type(),
-		MagicCookieValue: pluginServer.Type(),
-	}
+	return HandshakeConfig(pluginServer.Type())
 }
 
 // ClientHandshakeConfig returns the handshake configuration for the given
 // client implementation.
 func ClientHandshakeConfig(pluginClient api.PluginClient) goplugin.HandshakeConfig {
+	return HandshakeConfig(pluginClient.Type())
+}
+
+// HandshakeConfig returns the handshake configuration for plugins of the
+// given type.
+func HandshakeConfig(pluginType string) goplugin.HandshakeConfig {
 	return goplugin.HandshakeConfig{
-		ProtocolVersion:  1,
-		MagicCookieKey:   pluginClient.Type(),
-		MagicCookieValue: pluginClient.Type(),
+		ProtocolVersion:  ProtocolVersion,
+		MagicCookieKey:   MagicCookieKey,
+		MagicCookieValue: pluginType,
+	}
+}
+
+// LegacyMagicCookie returns the environment variable of the magic cookie
+// expected by plugins of the given type built with earlier versions of the
+// SDK.
+func LegacyMagicCookie(pluginType string) string {
+	return pluginType + "=" + pluginType
+}
+
+// acceptLegacyMagicCookie sets the magic cookie of the plugin type when the
+// plugin is launched by a host built with an earlier version of the SDK,
+// which only passes the legacy cookie.
+func acceptLegacyMagicCookie(pluginType string) {
+	if _, ok := os.LookupEnv(MagicCookieKey); ok {
+		return
+	}
+	if os.Getenv(pluginType) == pluginType {
+		_ = os.Setenv(MagicCookieKey, pluginType)
 	}
 }
@@ -1,6 +1,7 @@
 package bootstrap
 
 import (
+	"os"
 	"testing"
 
 	"google.golang.org/grpc"
@@ -34,8 +35,8 @@ func TestServerHandshakeConfig(t *testing.T) {
 	if got.ProtocolVersion != 1 {
 		t.Errorf("Expected ProtocolVersion to be 1, but got %d", got.ProtocolVersion)
 	}
-	if got.MagicCookieKey != "test" {
-		t.Errorf("Expected MagicCookieKey to be 'test', but got %s", got.MagicCookieKey)
+	if got.MagicCookieKey != MagicCookieKey {
+		t.Errorf("Expected MagicCookieKey to be %q, but got %s", MagicCookieKey, got.MagicCookieKey)
 	}
 	if got.MagicCookieValue != "test" {
 		t.Errorf("Expected MagicCookieValue to be 'test', but got %s", got.MagicCookieValue)
@@ -53,10 +54,40 @@ func TestClientHandshakeConfig(t *testing.T) {
 	if got.ProtocolVersion != 1 {
 		t.Errorf("Expected ProtocolVersion to be 1, but got %d", got.ProtocolVersion)
 	}
-	if got.MagicCookieKey != "test" {
-		t.Errorf("Expected MagicCookieKey to be 'test', but got %s", got.MagicCookieKey)
+	if got.MagicCookieKey != MagicCookieKey {
+		t.Errorf("Expected MagicCookieKey to be %q, but got %s", MagicCookieKey, got.MagicCookieKey)
 	}
 	if got.MagicCookieValue != "test" {
 		t.Errorf("Expected MagicCookieValue to be 'test', but got %s", got.MagicCookieValue)
 	}
 }
+
+func TestAcceptLegacyMagicCookie(t *testing.T) {
+	tests := []struct {
+		name   string
+		env    map[string]string
+		cookie string
+	}{
+		{name: "legacy cookie", env: map[string]string{"test": "test"}, cookie: "test"},
+		{name: "cookie", env: map[string]string{MagicCookieKey: "other", "test": "test"}, cookie: "other"},
+		{name: "no cookie", env: map[string]string{"test": "other"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			t.Setenv(MagicCookieKey, "")
+			os.Unsetenv(MagicCookieKey)
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			// Act
+			acceptLegacyMagicCookie("test")
+
+			// Assert
+			if got := os.Getenv(MagicCookieKey); got != tt.cookie {
+				t.Errorf("Expected cookie %q, but got %q", tt.cookie, got)
+			}
+		})
+	}
+}
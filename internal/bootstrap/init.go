@@ -11,18 +11,21 @@ import (
 )
 
 // Init initializes the plugin and advertises the given host service names to
-// the plugin for brokering. The list of service names implemented by the
-// plugin are returned. This function is only intended to be used internally.
-func Init(ctx context.Context, conn grpc.ClientConnInterface, hostServiceNames []string) (pluginServiceNames []string, err error) {
+// the plugin for brokering, either over the go-plugin broker or over broker
+// streams. The response holds the service names implemented by the plugin and
+// the capabilities it advertises. This function is only intended to be used
+// internally.
+func Init(ctx context.Context, conn grpc.ClientConnInterface, hostServiceNames []string, brokerStreams bool) (*initv1.InitResponse, error) {
 	client := initv1.NewBootstrapClient(conn)
 	resp, err := client.Init(ctx, &initv1.InitRequest{
 		HostServiceNames: hostServiceNames,
+		BrokerStreams:    brokerStreams,
 	})
 	switch status.Code(err) {
 	case codes.Unimplemented:
-		return []string{}, nil
+		return &initv1.InitResponse{PluginServiceNames: []string{}}, nil
 	case codes.OK:
-		return resp.PluginServiceNames, nil
+		return resp, nil
 	}
 	return nil, err
 }
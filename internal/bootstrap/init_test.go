@@ -97,7 +97,7 @@ func TestInit(t *testing.T) {
 			defer conn.Close()
 
 			// Act
-			_, err = Init(ctx, conn, []string{})
+			_, err = Init(ctx, conn, []string{}, false)
 
 			// Assert
 			if tc.wantError && err != nil { // expected error and got it
@@ -0,0 +1,67 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"runtime/debug"
+	"sync/atomic"
+
+	"github.com/hashicorp/go-hclog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// panicExitCode is the exit code of a plugin exiting after too many panics.
+const panicExitCode = 2
+
+// panicRecovery recovers from panics of the handlers of a plugin server and
+// converts them to internal errors, so that a single faulty call does not
+// crash the plugin.
+type panicRecovery struct {
+	logger hclog.Logger
+
+	// exitAfter is the number of panics after which the plugin exits, so
+	// that the host restarts it, or zero to never exit.
+	exitAfter int64
+	exit      func(code int)
+
+	panics atomic.Int64
+}
+
+func newPanicRecovery(logger hclog.Logger, exitAfter int) *panicRecovery {
+	return &panicRecovery{
+		logger:    logger,
+		exitAfter: int64(exitAfter),
+		exit:      os.Exit,
+	}
+}
+
+func (p *panicRecovery) UnaryServerInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (_ any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = p.recovered(info.FullMethod, r)
+		}
+	}()
+	return handler(ctx, req)
+}
+
+func (p *panicRecovery) StreamServerInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = p.recovered(info.FullMethod, r)
+		}
+	}()
+	return handler(srv, ss)
+}
+
+func (p *panicRecovery) recovered(method string, r any) error {
+	panics := p.panics.Add(1)
+	p.logger.Error("Plugin panicked", "method", method, "cause", fmt.Sprint(r), "panics", panics, "stack", string(debug.Stack()))
+	if p.exitAfter > 0 && panics >= p.exitAfter {
+		p.logger.Error("Plugin exiting after too many panics", "panics", panics)
+		p.exit(panicExitCode)
+	}
+	return status.Errorf(codes.Internal, "%s", r)
+}
@@ -0,0 +1,55 @@
+package bootstrap
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestPanicRecovery(t *testing.T) {
+	// Arrange
+	var exitCodes []int
+	recovery := newPanicRecovery(hclog.NewNullLogger(), 2)
+	recovery.exit = func(code int) { exitCodes = append(exitCodes, code) }
+
+	// Act
+	_, unaryErr := recovery.UnaryServerInterceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/test/Unary"},
+		func(context.Context, any) (any, error) {
+			panic("unary failure")
+		})
+	streamErr := recovery.StreamServerInterceptor(nil, nil, &grpc.StreamServerInfo{FullMethod: "/test/Stream"},
+		func(any, grpc.ServerStream) error {
+			panic("stream failure")
+		})
+
+	// Assert
+	assert.Equal(t, codes.Internal, status.Code(unaryErr))
+	assert.Contains(t, unaryErr.Error(), "unary failure")
+	assert.Equal(t, codes.Internal, status.Code(streamErr))
+	assert.Contains(t, streamErr.Error(), "stream failure")
+	assert.Equal(t, int64(2), recovery.panics.Load())
+	assert.Equal(t, []int{panicExitCode}, exitCodes, "exits once the limit is reached")
+}
+
+func TestPanicRecovery_NeverExitsByDefault(t *testing.T) {
+	// Arrange
+	recovery := newPanicRecovery(hclog.NewNullLogger(), 0)
+	recovery.exit = func(int) { t.Fatal("unexpected exit") }
+
+	// Act
+	for range 3 {
+		_, err := recovery.UnaryServerInterceptor(context.Background(), nil, &grpc.UnaryServerInfo{},
+			func(context.Context, any) (any, error) {
+				panic("failure")
+			})
+
+		// Assert
+		assert.Equal(t, codes.Internal, status.Code(err))
+	}
+	assert.Equal(t, int64(3), recovery.panics.Load())
+}
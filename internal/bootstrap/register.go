@@ -17,6 +17,15 @@ type HostDialer interface {
 	DialHost(ctx context.Context) (grpc.ClientConnInterface, error)
 }
 
+// BrokerStreamsDialer is implemented by dialers that can also reach the host
+// over broker streams opened by the host. The host asks for it when it cannot
+// use the go-plugin broker. This interface is only intended to be used
+// internally.
+type BrokerStreamsDialer interface {
+	HostDialer
+	DialHostOverStreams(ctx context.Context) (grpc.ClientConnInterface, error)
+}
+
 // register given servers with the gRPC server. The given dialer and logger will
 // be used when the plugins are initialized.
 func Register(s *grpc.Server, servers []api.ServiceServer, logger hclog.Logger, dialer HostDialer) {
@@ -45,6 +54,16 @@ type initService struct {
 }
 
 func (s *initService) Init(ctx context.Context, req *initv1.InitRequest) (*initv1.InitResponse, error) {
+	dialHost := s.dialer.DialHost
+	if dialer, ok := s.dialer.(BrokerStreamsDialer); ok && req.BrokerStreams {
+		dialHost = dialer.DialHostOverStreams
+	}
+
+	resp := &initv1.InitResponse{
+		PluginServiceNames: s.names,
+		SdkVersion:         SDKVersion(),
+	}
+
 	initted := map[any]struct{}{}
 	for _, impl := range s.impls {
 		// Wire up the logger and host service broker. Since the same
@@ -59,8 +78,12 @@ func (s *initService) Init(ctx context.Context, req *initv1.InitRequest) (*initv
 			impl.SetLogger(s.logger)
 		}
 
+		if impl, ok := impl.(api.HasCapabilities); ok {
+			addCapabilities(resp, impl.Capabilities())
+		}
+
 		if impl, ok := impl.(api.NeedsHostServices); ok {
-			conn, err := s.dialer.DialHost(ctx)
+			conn, err := dialHost(ctx)
 			if err != nil {
 				return nil, err
 			}
@@ -72,9 +95,24 @@ func (s *initService) Init(ctx context.Context, req *initv1.InitRequest) (*initv
 		}
 	}
 
-	return &initv1.InitResponse{
-		PluginServiceNames: s.names,
-	}, nil
+	return resp, nil
+}
+
+// addCapabilities merges the capabilities advertised by an implementation
+// into the response. The first version and build information advertised win.
+func addCapabilities(resp *initv1.InitResponse, capabilities api.Capabilities) {
+	if resp.PluginVersion == "" {
+		resp.PluginVersion = capabilities.Version
+	}
+	if resp.BuildInfo == "" {
+		resp.BuildInfo = capabilities.BuildInfo
+	}
+	for key, value := range capabilities.Metadata {
+		if resp.Capabilities == nil {
+			resp.Capabilities = make(map[string]string)
+		}
+		resp.Capabilities[key] = value
+	}
 }
 
 func (s *initService) Deinit(ctx context.Context, req *initv1.DeinitRequest) (*initv1.DeinitResponse, error) {
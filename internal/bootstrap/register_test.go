@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc"
 
 	"github.com/openkcm/plugin-sdk/api"
@@ -89,6 +90,80 @@ func TestInitServiceInit(t *testing.T) {
 	}
 }
 
+type hasCapabilitiesMock struct {
+	capabilities api.Capabilities
+}
+
+func (hcm *hasCapabilitiesMock) Capabilities() api.Capabilities {
+	return hcm.capabilities
+}
+
+func TestInitServiceInitCapabilities(t *testing.T) {
+	// Arrange
+	svc := &initService{
+		logger: hclog.Default(),
+		dialer: &hostDialerMock{},
+		names:  []string{"plugin"},
+		impls: []any{
+			&needsHostServiceMock{},
+			&hasCapabilitiesMock{capabilities: api.Capabilities{
+				Version:   "1.2.3",
+				BuildInfo: "build",
+				Metadata:  map[string]string{"algorithms": "AES256"},
+			}},
+			&hasCapabilitiesMock{capabilities: api.Capabilities{
+				Version:  "2.0.0",
+				Metadata: map[string]string{"feature": "enabled"},
+			}},
+		},
+	}
+
+	// Act
+	resp, err := svc.Init(context.Background(), &initv1.InitRequest{})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"plugin"}, resp.GetPluginServiceNames())
+	assert.Equal(t, SDKVersion(), resp.GetSdkVersion())
+	assert.Equal(t, "1.2.3", resp.GetPluginVersion())
+	assert.Equal(t, "build", resp.GetBuildInfo())
+	assert.Equal(t, map[string]string{"algorithms": "AES256", "feature": "enabled"}, resp.GetCapabilities())
+}
+
+type streamsDialerMock struct {
+	hostDialerMock
+
+	overStreams bool
+}
+
+func (sdm *streamsDialerMock) DialHostOverStreams(ctx context.Context) (grpc.ClientConnInterface, error) {
+	sdm.overStreams = true
+	return nil, nil
+}
+
+func TestInitServiceInitBrokerStreams(t *testing.T) {
+	for _, brokerStreams := range []bool{false, true} {
+		// Arrange
+		dialer := &streamsDialerMock{}
+		svc := &initService{
+			logger: hclog.Default(),
+			dialer: dialer,
+			impls:  []any{&needsHostServiceMock{}},
+		}
+
+		// Act
+		_, err := svc.Init(context.Background(), &initv1.InitRequest{BrokerStreams: brokerStreams})
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if dialer.overStreams != brokerStreams {
+			t.Errorf("expected dialing over broker streams to be %v, got %v", brokerStreams, dialer.overStreams)
+		}
+	}
+}
+
 func TestIinitServiceDeinit(t *testing.T) {
 	// Arrange
 	mock := &needsHostServiceMock{}
@@ -0,0 +1,361 @@
+package bootstrap
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"github.com/openkcm/plugin-sdk/api"
+	pluginoption "github.com/openkcm/plugin-sdk/api/plugin-option"
+	brokerv1 "github.com/openkcm/plugin-sdk/internal/proto/service/broker/v1"
+)
+
+// frameSize is the maximum number of bytes sent in a single broker frame.
+const frameSize = 32 * 1024
+
+// ServeRemote serves the plugin on the given address until the process is
+// interrupted or terminated. Unlike Serve, the plugin is not launched by the
+// host but is already running and connected to by the host. The address is
+// either unix:///path/to/socket or tcp://host:port. Plugins must be
+// configured with mutual TLS, unless served over a Unix socket with
+// InsecureUnixSocket set.
+func ServeRemote(address string, opts ...pluginoption.ServerOption) error {
+	cfg, err := newServerConfiguration(opts)
+	if err != nil {
+		return err
+	}
+
+	network, addr, err := ParseAddress(address)
+	if err != nil {
+		return err
+	}
+
+	tlsConfig, err := serverTLSConfig(cfg)
+	if err != nil {
+		return err
+	}
+
+	var serverOpts []grpc.ServerOption
+	switch {
+	case tlsConfig != nil:
+		serverOpts = append(serverOpts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	case network == "unix" && cfg.InsecureUnixSocket:
+		cfg.Logger.Warn("Serving remote plugin without TLS", "address", address)
+	default:
+		return errors.New("remote plugins require mutual TLS, unless served over a Unix socket with InsecureUnixSocket")
+	}
+
+	if network == "unix" {
+		if err := removeStaleSocket(addr); err != nil {
+			return err
+		}
+	}
+	listener, err := net.Listen(network, addr)
+	if err != nil {
+		return err
+	}
+
+	server := customGRPCServer(cfg.ServerOptions)(serverOpts)
+	dialer := newRemoteDialer()
+	defer dialer.Close()
+	Register(server, append([]api.ServiceServer{cfg.PluginServer}, cfg.ServiceServers...), cfg.Logger, dialer)
+	brokerv1.RegisterBrokerServer(server, dialer)
+
+	ctx := context.Background()
+	if cfg.TestConfig != nil && cfg.TestConfig.Context != nil {
+		ctx = cfg.TestConfig.Context
+	}
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		// The broker streams are only ended by the host, so they are torn
+		// down before waiting for the calls in flight.
+		_ = dialer.Close()
+		server.GracefulStop()
+	}()
+
+	cfg.Logger.Info("Serving remote plugin", "address", address)
+	return server.Serve(listener)
+}
+
+// serverTLSConfig returns the mutual TLS configuration of the remote plugin
+// server, if any. A given configuration must set the CAs the certificates of
+// the hosts are verified against, which are required on a clone of it.
+func serverTLSConfig(cfg *pluginoption.ServerConfiguration) (*tls.Config, error) {
+	if cfg.TLSConfig == nil {
+		if cfg.TLSCertFile == "" {
+			return nil, nil
+		}
+		return MutualTLSConfig(cfg.TLSCertFile, cfg.TLSKeyFile, cfg.TLSCAFile, true)
+	}
+	if cfg.TLSConfig.ClientCAs == nil {
+		return nil, errors.New("TLS configuration of remote plugin lacks the client CAs required for mutual TLS")
+	}
+	tlsConfig := cfg.TLSConfig.Clone()
+	tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	return tlsConfig, nil
+}
+
+// removeStaleSocket removes the socket left behind by a previous instance of
+// the plugin, so that the address can be listened on again. A socket still
+// listened on is left in place and reported as in use.
+func removeStaleSocket(path string) error {
+	if info, err := os.Stat(path); err != nil || info.Mode()&os.ModeSocket == 0 {
+		return nil
+	}
+	conn, err := net.Dial("unix", path)
+	if err == nil {
+		_ = conn.Close()
+		return fmt.Errorf("address unix://%s already in use", path)
+	}
+	if errors.Is(err, syscall.ECONNREFUSED) {
+		return os.Remove(path)
+	}
+	return nil
+}
+
+// ParseAddress splits the address of a remote plugin into the network and the
+// address to dial or listen on. This function is only intended to be used
+// internally.
+func ParseAddress(address string) (network, addr string, err error) {
+	switch {
+	case strings.HasPrefix(address, "unix://"):
+		network, addr = "unix", strings.TrimPrefix(address, "unix://")
+	case strings.HasPrefix(address, "tcp://"):
+		network, addr = "tcp", strings.TrimPrefix(address, "tcp://")
+	default:
+		return "", "", fmt.Errorf("unsupported remote plugin address %q: expected unix:// or tcp://", address)
+	}
+	if addr == "" {
+		return "", "", fmt.Errorf("remote plugin address %q is missing a path or host", address)
+	}
+	return network, addr, nil
+}
+
+// MutualTLSConfig loads a TLS configuration that authenticates with the given
+// certificate and key, and requires the peer to present a certificate signed
+// by the given CA. This function is only intended to be used internally.
+func MutualTLSConfig(certFile, keyFile, caFile string, server bool) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load certificate: %w", err)
+	}
+
+	ca, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, fmt.Errorf("no certificates found in CA file %q", caFile)
+	}
+
+	config := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if server {
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+		config.ClientCAs = pool
+	} else {
+		config.RootCAs = pool
+	}
+	return config, nil
+}
+
+// FrameStream is a stream of broker frames, one of the ends of the broker
+// Connect stream.
+type FrameStream interface {
+	Send(*brokerv1.Frame) error
+	Recv() (*brokerv1.Frame, error)
+}
+
+// HostFrames returns the frames of the host end of the broker Connect
+// stream. This function is only intended to be used internally.
+func HostFrames(stream brokerv1.Broker_ConnectClient) FrameStream {
+	return hostFrames{stream}
+}
+
+type hostFrames struct {
+	stream brokerv1.Broker_ConnectClient
+}
+
+func (s hostFrames) Send(frame *brokerv1.Frame) error {
+	return s.stream.Send(&brokerv1.ConnectRequest{Frame: frame})
+}
+
+func (s hostFrames) Recv() (*brokerv1.Frame, error) {
+	resp, err := s.stream.Recv()
+	return resp.GetFrame(), err
+}
+
+// pluginFrames are the frames of the plugin end of the broker Connect
+// stream.
+type pluginFrames struct {
+	stream brokerv1.Broker_ConnectServer
+}
+
+func (s pluginFrames) Send(frame *brokerv1.Frame) error {
+	return s.stream.Send(&brokerv1.ConnectResponse{Frame: frame})
+}
+
+func (s pluginFrames) Recv() (*brokerv1.Frame, error) {
+	req, err := s.stream.Recv()
+	return req.GetFrame(), err
+}
+
+// Tunnel copies the bytes read from conn to the stream and the frames received
+// on the stream to conn until either fails. The connection is closed when
+// Tunnel returns. This function is only intended to be used internally.
+func Tunnel(conn net.Conn, stream FrameStream) error {
+	defer conn.Close()
+
+	errCh := make(chan error, 2)
+	go func() {
+		for {
+			frame, err := stream.Recv()
+			if err != nil {
+				errCh <- err
+				return
+			}
+			if _, err := conn.Write(frame.GetData()); err != nil {
+				errCh <- err
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		buf := make([]byte, frameSize)
+		for {
+			n, err := conn.Read(buf)
+			if n > 0 {
+				if err := stream.Send(&brokerv1.Frame{Data: append([]byte(nil), buf[:n]...)}); err != nil {
+					errCh <- err
+					return
+				}
+			}
+			if err != nil {
+				errCh <- err
+				return
+			}
+		}
+	})
+
+	err := <-errCh
+	// Closing the connection stops the sender. The receiver stops when the
+	// stream ends, which is up to the caller.
+	_ = conn.Close()
+	wg.Wait()
+	return err
+}
+
+// remoteDialer dials the host over the broker streams opened by the host.
+type remoteDialer struct {
+	brokerv1.UnimplementedBrokerServer
+
+	tunnels   chan net.Conn
+	closed    chan struct{}
+	closeOnce sync.Once
+
+	mu   sync.Mutex
+	conn *grpc.ClientConn
+}
+
+func newRemoteDialer() *remoteDialer {
+	return &remoteDialer{
+		tunnels: make(chan net.Conn),
+		closed:  make(chan struct{}),
+	}
+}
+
+func (d *remoteDialer) DialHost(context.Context) (grpc.ClientConnInterface, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.conn != nil {
+		return d.conn, nil
+	}
+
+	// The stream is already protected by the transport security of the
+	// plugin server.
+	conn, err := grpc.NewClient(
+		"passthrough:host",
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(d.dial),
+	)
+	if err != nil {
+		return nil, err
+	}
+	d.conn = conn
+	return conn, nil
+}
+
+// dial waits for the host to open a broker stream.
+func (d *remoteDialer) dial(ctx context.Context, _ string) (net.Conn, error) {
+	select {
+	case conn := <-d.tunnels:
+		return conn, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-d.closed:
+		return nil, net.ErrClosed
+	}
+}
+
+// Connect implements brokerv1.BrokerServer. The stream is handed to the next
+// dial of the host connection and served until either end goes away.
+func (d *remoteDialer) Connect(stream brokerv1.Broker_ConnectServer) error {
+	client, server := net.Pipe()
+	select {
+	case d.tunnels <- client:
+	case <-stream.Context().Done():
+		_ = server.Close()
+		return nil
+	case <-d.closed:
+		_ = server.Close()
+		return nil
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-d.closed:
+			_ = server.Close()
+		case <-done:
+		}
+	}()
+
+	err := Tunnel(server, pluginFrames{stream})
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrClosedPipe) || status.Code(err) == codes.Canceled {
+		return nil
+	}
+	return err
+}
+
+func (d *remoteDialer) Close() error {
+	d.closeOnce.Do(func() { close(d.closed) })
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.conn != nil {
+		return d.conn.Close()
+	}
+	return nil
+}
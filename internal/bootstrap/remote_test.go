@@ -0,0 +1,119 @@
+package bootstrap
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pluginerrors "github.com/openkcm/plugin-sdk/api/plugin-errors"
+	pluginoption "github.com/openkcm/plugin-sdk/api/plugin-option"
+)
+
+func TestParseAddress(t *testing.T) {
+	tests := []struct {
+		address string
+		network string
+		addr    string
+		wantErr bool
+	}{
+		{address: "unix:///run/kcm/keystore.sock", network: "unix", addr: "/run/kcm/keystore.sock"},
+		{address: "tcp://localhost:7000", network: "tcp", addr: "localhost:7000"},
+		{address: "tcp://", wantErr: true},
+		{address: "http://localhost:7000", wantErr: true},
+		{address: "/run/kcm/keystore.sock", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			network, addr, err := ParseAddress(tt.address)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.network, network)
+			assert.Equal(t, tt.addr, addr)
+		})
+	}
+}
+
+func TestServeRemoteRequiresPluginServer(t *testing.T) {
+	err := ServeRemote("unix:///tmp/plugin.sock")
+	assert.ErrorIs(t, err, pluginerrors.ErrServerRequired)
+}
+
+func TestServeRemoteRequiresTLSOverTCP(t *testing.T) {
+	err := ServeRemote("tcp://127.0.0.1:0", pluginoption.WithPluginServer(&pluginMock{typ: "test"}))
+	assert.Error(t, err)
+}
+
+func TestServeRemoteRequiresTLSOverUnixSocket(t *testing.T) {
+	// Act
+	err := ServeRemote("unix://"+filepath.Join(t.TempDir(), "plugin.sock"), pluginoption.WithPluginServer(&pluginMock{typ: "test"}))
+
+	// Assert
+	assert.ErrorContains(t, err, "require mutual TLS")
+}
+
+func TestServerTLSConfig(t *testing.T) {
+	t.Run("requires client CAs", func(t *testing.T) {
+		// Act
+		_, err := serverTLSConfig(&pluginoption.ServerConfiguration{TLSConfig: &tls.Config{}})
+
+		// Assert
+		assert.ErrorContains(t, err, "client CAs")
+	})
+
+	t.Run("requires client certificates", func(t *testing.T) {
+		// Arrange
+		given := &tls.Config{ClientCAs: x509.NewCertPool(), ClientAuth: tls.RequestClientCert}
+
+		// Act
+		config, err := serverTLSConfig(&pluginoption.ServerConfiguration{TLSConfig: given})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, tls.RequireAndVerifyClientCert, config.ClientAuth)
+		assert.Equal(t, tls.RequestClientCert, given.ClientAuth)
+	})
+}
+
+func TestRemoveStaleSocket(t *testing.T) {
+	t.Run("stale socket", func(t *testing.T) {
+		// Arrange
+		path := filepath.Join(t.TempDir(), "plugin.sock")
+		listener, err := net.Listen("unix", path)
+		require.NoError(t, err)
+		listener.(*net.UnixListener).SetUnlinkOnClose(false)
+		require.NoError(t, listener.Close())
+
+		// Act
+		err = removeStaleSocket(path)
+
+		// Assert
+		require.NoError(t, err)
+		_, err = os.Stat(path)
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("socket in use", func(t *testing.T) {
+		// Arrange
+		path := filepath.Join(t.TempDir(), "plugin.sock")
+		listener, err := net.Listen("unix", path)
+		require.NoError(t, err)
+		defer listener.Close()
+
+		// Act
+		err = removeStaleSocket(path)
+
+		// Assert
+		assert.ErrorContains(t, err, "already in use")
+		_, err = os.Stat(path)
+		assert.NoError(t, err)
+	})
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"os/signal"
+	"syscall"
 
 	"buf.build/go/protovalidate"
 	"github.com/hashicorp/go-hclog"
@@ -18,51 +20,91 @@ import (
 	pluginerrors "github.com/openkcm/plugin-sdk/api/plugin-errors"
 	pluginoption "github.com/openkcm/plugin-sdk/api/plugin-option"
 	"github.com/openkcm/plugin-sdk/internal/consts"
+	brokerv1 "github.com/openkcm/plugin-sdk/internal/proto/service/broker/v1"
 )
 
 // Serve serves the plugin with the given loggers and plugin/service servers and an optional test configuration.
 func Serve(
 	opts ...pluginoption.ServerOption,
 ) error {
+	cfg, err := newServerConfiguration(opts)
+	if err != nil {
+		return err
+	}
+
+	// The plugin may outlive the host it was launched by when the host
+	// reattaches to it after a restart. Writing to the standard streams
+	// inherited from the host must then not terminate the plugin.
+	signal.Ignore(syscall.SIGPIPE)
+
+	acceptLegacyMagicCookie(cfg.PluginServer.Type())
+	goplugin.Serve(&goplugin.ServeConfig{
+		HandshakeConfig: ServerHandshakeConfig(cfg.PluginServer),
+		VersionedPlugins: map[int]goplugin.PluginSet{
+			ProtocolVersion: {
+				cfg.PluginServer.Type(): newHCPlugin(cfg.Logger, cfg.PluginServer, cfg.ServiceServers),
+			},
+		},
+		Logger:     cfg.Logger,
+		GRPCServer: customGRPCServer(cfg.ServerOptions),
+		Test:       cfg.TestConfig,
+	})
+
+	return nil
+}
+
+// newServerConfiguration applies the options and the defaults shared by all
+// the ways of serving a plugin.
+func newServerConfiguration(opts []pluginoption.ServerOption) (*pluginoption.ServerConfiguration, error) {
 	cfg := &pluginoption.ServerConfiguration{}
 	for _, opt := range opts {
 		opt(cfg)
 	}
 
 	if cfg.PluginServer == nil {
-		return pluginerrors.ErrServerRequired
+		return nil, pluginerrors.ErrServerRequired
 	}
 
 	if cfg.Logger == nil {
 		cfg.Logger = NewLogger()
 	}
 
-	if cfg.ValidateInput || cfg.ValidateOutput {
-		if len(cfg.ServerOptions) == 0 {
-			cfg.ServerOptions = make([]grpc.ServerOption, 0)
-		}
+	// Panics are recovered from before any interceptor chained by the
+	// configuration runs, so that the panics of interceptors are too. The
+	// chain is installed before the server options of the configuration,
+	// only an interceptor set with grpc.UnaryInterceptor or
+	// grpc.StreamInterceptor runs before it.
+	recovery := newPanicRecovery(cfg.Logger, cfg.ExitAfterPanics)
+	unary, stream := ServerInterceptors(cfg)
+	cfg.ServerOptions = append([]grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(append([]grpc.UnaryServerInterceptor{recovery.UnaryServerInterceptor}, unary...)...),
+		grpc.ChainStreamInterceptor(append([]grpc.StreamServerInterceptor{recovery.StreamServerInterceptor}, stream...)...),
+	}, cfg.ServerOptions...)
+
+	return cfg, nil
+}
 
+// ServerInterceptors returns the interceptors of the configuration: the
+// interceptors of the SDK first, followed by the ones added with
+// pluginoption.WithUnaryInterceptors and WithStreamInterceptors. The
+// interceptors chained with pluginoption.SetServerOption run after them, an
+// interceptor set with grpc.UnaryInterceptor or grpc.StreamInterceptor runs
+// before all of them. This function is only intended to be used internally.
+func ServerInterceptors(cfg *pluginoption.ServerConfiguration) ([]grpc.UnaryServerInterceptor, []grpc.StreamServerInterceptor) {
+	var unary []grpc.UnaryServerInterceptor
+	var stream []grpc.StreamServerInterceptor
+	if cfg.ValidateInput || cfg.ValidateOutput {
 		validator, err := protovalidate.New()
 		if err != nil {
 			slog.Warn("failed to initialize plugin proto validator", "error", err)
 		} else {
-			cfg.ServerOptions = append(cfg.ServerOptions, grpc.UnaryInterceptor(
-				ValidationUnaryInterceptor(validator, cfg.ValidateInput, cfg.ValidateOutput),
-			))
+			unary = append(unary, ValidationUnaryInterceptor(validator, cfg.ValidateInput, cfg.ValidateOutput))
+			stream = append(stream, ValidationStreamInterceptor(validator, cfg.ValidateInput, cfg.ValidateOutput))
 		}
 	}
-
-	goplugin.Serve(&goplugin.ServeConfig{
-		HandshakeConfig: ServerHandshakeConfig(cfg.PluginServer),
-		Plugins: map[string]goplugin.Plugin{
-			cfg.PluginServer.Type(): newHCPlugin(cfg.Logger, cfg.PluginServer, cfg.ServiceServers),
-		},
-		Logger:     cfg.Logger,
-		GRPCServer: customGRPCServer(cfg.ServerOptions),
-		Test:       cfg.TestConfig,
-	})
-
-	return nil
+	unary = append(unary, cfg.UnaryInterceptors...)
+	stream = append(stream, cfg.StreamInterceptors...)
+	return unary, stream
 }
 
 type hcServer struct {
@@ -79,7 +121,9 @@ func newHCPlugin(logger hclog.Logger, pluginServer api.PluginServer, serviceServ
 }
 
 func (p *hcServer) GRPCServer(broker *goplugin.GRPCBroker, server *grpc.Server) (err error) {
-	Register(server, p.servers, p.logger, &hcDialer{broker: broker})
+	streams := newRemoteDialer()
+	Register(server, p.servers, p.logger, &hcDialer{broker: broker, streams: streams})
+	brokerv1.RegisterBrokerServer(server, streams)
 	return nil
 }
 
@@ -88,8 +132,9 @@ func (p *hcServer) GRPCClient(context.Context, *goplugin.GRPCBroker, *grpc.Clien
 }
 
 type hcDialer struct {
-	broker *goplugin.GRPCBroker
-	conn   grpc.ClientConnInterface
+	broker  *goplugin.GRPCBroker
+	streams *remoteDialer
+	conn    grpc.ClientConnInterface
 }
 
 func (d *hcDialer) DialHost(ctx context.Context) (grpc.ClientConnInterface, error) {
@@ -105,6 +150,10 @@ func (d *hcDialer) DialHost(ctx context.Context) (grpc.ClientConnInterface, erro
 	return conn, nil
 }
 
+func (d *hcDialer) DialHostOverStreams(ctx context.Context) (grpc.ClientConnInterface, error) {
+	return d.streams.DialHost(ctx)
+}
+
 func customGRPCServer(
 	base []grpc.ServerOption,
 ) func([]grpc.ServerOption) *grpc.Server {
@@ -150,3 +199,57 @@ func ValidationUnaryInterceptor(v protovalidate.Validator, request, response boo
 		return resp, nil
 	}
 }
+
+// ValidationStreamInterceptor validates every message received and sent
+// over a stream, like ValidationUnaryInterceptor does for unary calls.
+func ValidationStreamInterceptor(v protovalidate.Validator, request, response bool) grpc.StreamServerInterceptor {
+	return func(
+		srv any,
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		return handler(srv, &validatingServerStream{
+			ServerStream: ss,
+			validator:    v,
+			request:      request,
+			response:     response,
+		})
+	}
+}
+
+type validatingServerStream struct {
+	grpc.ServerStream
+
+	validator         protovalidate.Validator
+	request, response bool
+}
+
+func (s *validatingServerStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if msg, ok := m.(proto.Message); s.request && ok {
+		if err := s.validator.Validate(msg); err != nil {
+			return status.Errorf(
+				codes.InvalidArgument,
+				"request validation failed: %v",
+				err,
+			)
+		}
+	}
+	return nil
+}
+
+func (s *validatingServerStream) SendMsg(m any) error {
+	if msg, ok := m.(proto.Message); s.response && ok {
+		if err := s.validator.Validate(msg); err != nil {
+			return status.Errorf(
+				codes.Internal,
+				"response validation failed: %v",
+				err,
+			)
+		}
+	}
+	return s.ServerStream.SendMsg(m)
+}
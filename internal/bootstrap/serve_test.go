@@ -3,6 +3,7 @@ package bootstrap
 import (
 	"context"
 	"errors"
+	"net"
 	"testing"
 
 	"buf.build/go/protovalidate"
@@ -10,7 +11,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
 	"google.golang.org/protobuf/proto"
 
 	goplugin "github.com/hashicorp/go-plugin"
@@ -184,3 +187,120 @@ func TestValidationUnaryInterceptor_ResponseValidationFails(t *testing.T) {
 	assert.True(t, ok, "expected gRPC status error")
 	assert.Equal(t, codes.Internal, st.Code())
 }
+
+type serverStreamMock struct {
+	grpc.ServerStream
+
+	sent []any
+}
+
+func (s *serverStreamMock) RecvMsg(m any) error { return nil }
+func (s *serverStreamMock) SendMsg(m any) error {
+	s.sent = append(s.sent, m)
+	return nil
+}
+
+func TestValidationStreamInterceptor(t *testing.T) {
+	tests := []struct {
+		name              string
+		request, response bool
+		wantRecvCode      codes.Code
+		wantSendCode      codes.Code
+	}{
+		{name: "no validation", wantRecvCode: codes.OK, wantSendCode: codes.OK},
+		{name: "request validation", request: true, wantRecvCode: codes.InvalidArgument, wantSendCode: codes.OK},
+		{name: "response validation", response: true, wantRecvCode: codes.OK, wantSendCode: codes.Internal},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			v := &mockValidator{err: errors.New("invalid")}
+			interceptor := ValidationStreamInterceptor(v, tc.request, tc.response)
+			ss := &serverStreamMock{}
+
+			// Act
+			var recvErr, sendErr error
+			err := interceptor(nil, ss, &grpc.StreamServerInfo{}, func(srv any, stream grpc.ServerStream) error {
+				recvErr = stream.RecvMsg(&initv1.InitRequest{})
+				sendErr = stream.SendMsg(&initv1.InitResponse{})
+				return nil
+			})
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantRecvCode, status.Code(recvErr))
+			assert.Equal(t, tc.wantSendCode, status.Code(sendErr))
+			if tc.wantSendCode == codes.OK {
+				assert.Len(t, ss.sent, 1)
+			} else {
+				assert.Empty(t, ss.sent)
+			}
+		})
+	}
+}
+
+func TestServerInterceptors(t *testing.T) {
+	// Arrange
+	var calls []string
+	record := func(name string) grpc.UnaryServerInterceptor {
+		return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			calls = append(calls, name)
+			return handler(ctx, req)
+		}
+	}
+	cfg, err := newServerConfiguration([]pluginoption.ServerOption{
+		pluginoption.WithPluginServer(&pluginMock{typ: "test"}),
+		pluginoption.EnableInputValidation(),
+		pluginoption.SetServerOption(grpc.MaxRecvMsgSize(1024)),
+		pluginoption.SetServerOption(grpc.MaxSendMsgSize(1024)),
+		pluginoption.WithUnaryInterceptors(record("first")),
+		pluginoption.WithUnaryInterceptors(record("second")),
+		pluginoption.WithStreamInterceptors(func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			return handler(srv, ss)
+		}),
+	})
+	assert.NoError(t, err)
+
+	// Act
+	unary, stream := ServerInterceptors(cfg)
+	for _, interceptor := range unary[1:] {
+		_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{}, func(context.Context, any) (any, error) {
+			return nil, nil
+		})
+		assert.NoError(t, err)
+	}
+
+	// Assert
+	assert.Len(t, unary, 3, "validation followed by the user interceptors")
+	assert.Len(t, stream, 2, "validation followed by the user interceptors")
+	assert.Equal(t, []string{"first", "second"}, calls)
+	assert.Len(t, cfg.ServerOptions, 4, "server options are appended")
+}
+
+func TestServerConfiguration_RecoversChainedInterceptorPanics(t *testing.T) {
+	// Arrange
+	cfg, err := newServerConfiguration([]pluginoption.ServerOption{
+		pluginoption.WithPluginServer(&pluginMock{typ: "test"}),
+		pluginoption.SetServerOption(grpc.ChainUnaryInterceptor(
+			func(context.Context, any, *grpc.UnaryServerInfo, grpc.UnaryHandler) (any, error) {
+				panic("interceptor failure")
+			})),
+	})
+	assert.NoError(t, err)
+	lis := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer(cfg.ServerOptions...)
+	initv1.RegisterBootstrapServer(server, &bootstrapServerMockOK{})
+	go func() { _ = server.Serve(lis) }()
+	defer server.Stop()
+	conn, err := grpc.NewClient("passthrough://bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	// Act
+	_, err = initv1.NewBootstrapClient(conn).Deinit(context.Background(), &initv1.DeinitRequest{})
+
+	// Assert
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
@@ -0,0 +1,28 @@
+package bootstrap
+
+import (
+	"runtime/debug"
+	"sync"
+)
+
+// sdkModulePath is the module path of the plugin SDK.
+const sdkModulePath = "github.com/openkcm/plugin-sdk"
+
+// SDKVersion returns the version of the plugin SDK the running binary was
+// built with, as recorded in its build information. It is empty if the
+// version is unknown. This function is only intended to be used internally.
+var SDKVersion = sync.OnceValue(func() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+	if info.Main.Path == sdkModulePath {
+		return info.Main.Version
+	}
+	for _, dep := range info.Deps {
+		if dep.Path == sdkModulePath {
+			return dep.Version
+		}
+	}
+	return ""
+})
@@ -2,4 +2,9 @@ package consts
 
 const (
 	HostServiceProviderID = 1
+
+	// ProtocolVersion is the version of the plugin protocol spoken by this
+	// SDK, see bootstrap.ProtocolVersion. It is defined here for the code
+	// generator, which must not depend on generated code.
+	ProtocolVersion = 1
 )
@@ -0,0 +1,225 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v7.35.1
+// source: service/broker/v1/broker.proto
+
+package brokerv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ConnectRequest carries the bytes sent by the host.
+type ConnectRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Frame         *Frame                 `protobuf:"bytes,1,opt,name=frame,proto3" json:"frame,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConnectRequest) Reset() {
+	*x = ConnectRequest{}
+	mi := &file_service_broker_v1_broker_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConnectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConnectRequest) ProtoMessage() {}
+
+func (x *ConnectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_service_broker_v1_broker_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConnectRequest.ProtoReflect.Descriptor instead.
+func (*ConnectRequest) Descriptor() ([]byte, []int) {
+	return file_service_broker_v1_broker_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ConnectRequest) GetFrame() *Frame {
+	if x != nil {
+		return x.Frame
+	}
+	return nil
+}
+
+// ConnectResponse carries the bytes sent by the plugin.
+type ConnectResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Frame         *Frame                 `protobuf:"bytes,1,opt,name=frame,proto3" json:"frame,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConnectResponse) Reset() {
+	*x = ConnectResponse{}
+	mi := &file_service_broker_v1_broker_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConnectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConnectResponse) ProtoMessage() {}
+
+func (x *ConnectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_service_broker_v1_broker_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConnectResponse.ProtoReflect.Descriptor instead.
+func (*ConnectResponse) Descriptor() ([]byte, []int) {
+	return file_service_broker_v1_broker_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ConnectResponse) GetFrame() *Frame {
+	if x != nil {
+		return x.Frame
+	}
+	return nil
+}
+
+// Frame is a chunk of the bytes sent over the brokered connection.
+type Frame struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Frame) Reset() {
+	*x = Frame{}
+	mi := &file_service_broker_v1_broker_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Frame) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Frame) ProtoMessage() {}
+
+func (x *Frame) ProtoReflect() protoreflect.Message {
+	mi := &file_service_broker_v1_broker_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Frame.ProtoReflect.Descriptor instead.
+func (*Frame) Descriptor() ([]byte, []int) {
+	return file_service_broker_v1_broker_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Frame) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+var File_service_broker_v1_broker_proto protoreflect.FileDescriptor
+
+const file_service_broker_v1_broker_proto_rawDesc = "" +
+	"\n" +
+	"\x1eservice/broker/v1/broker.proto\x12\x11service.broker.v1\"@\n" +
+	"\x0eConnectRequest\x12.\n" +
+	"\x05frame\x18\x01 \x01(\v2\x18.service.broker.v1.FrameR\x05frame\"A\n" +
+	"\x0fConnectResponse\x12.\n" +
+	"\x05frame\x18\x01 \x01(\v2\x18.service.broker.v1.FrameR\x05frame\"\x1b\n" +
+	"\x05Frame\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data2^\n" +
+	"\x06Broker\x12T\n" +
+	"\aConnect\x12!.service.broker.v1.ConnectRequest\x1a\".service.broker.v1.ConnectResponse(\x010\x01BIZGgithub.com/openkcm/plugin-sdk/internal/proto/service/broker/v1;brokerv1b\x06proto3"
+
+var (
+	file_service_broker_v1_broker_proto_rawDescOnce sync.Once
+	file_service_broker_v1_broker_proto_rawDescData []byte
+)
+
+func file_service_broker_v1_broker_proto_rawDescGZIP() []byte {
+	file_service_broker_v1_broker_proto_rawDescOnce.Do(func() {
+		file_service_broker_v1_broker_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_service_broker_v1_broker_proto_rawDesc), len(file_service_broker_v1_broker_proto_rawDesc)))
+	})
+	return file_service_broker_v1_broker_proto_rawDescData
+}
+
+var file_service_broker_v1_broker_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_service_broker_v1_broker_proto_goTypes = []any{
+	(*ConnectRequest)(nil),  // 0: service.broker.v1.ConnectRequest
+	(*ConnectResponse)(nil), // 1: service.broker.v1.ConnectResponse
+	(*Frame)(nil),           // 2: service.broker.v1.Frame
+}
+var file_service_broker_v1_broker_proto_depIdxs = []int32{
+	2, // 0: service.broker.v1.ConnectRequest.frame:type_name -> service.broker.v1.Frame
+	2, // 1: service.broker.v1.ConnectResponse.frame:type_name -> service.broker.v1.Frame
+	0, // 2: service.broker.v1.Broker.Connect:input_type -> service.broker.v1.ConnectRequest
+	1, // 3: service.broker.v1.Broker.Connect:output_type -> service.broker.v1.ConnectResponse
+	3, // [3:4] is the sub-list for method output_type
+	2, // [2:3] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_service_broker_v1_broker_proto_init() }
+func file_service_broker_v1_broker_proto_init() {
+	if File_service_broker_v1_broker_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_service_broker_v1_broker_proto_rawDesc), len(file_service_broker_v1_broker_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_service_broker_v1_broker_proto_goTypes,
+		DependencyIndexes: file_service_broker_v1_broker_proto_depIdxs,
+		MessageInfos:      file_service_broker_v1_broker_proto_msgTypes,
+	}.Build()
+	File_service_broker_v1_broker_proto = out.File
+	file_service_broker_v1_broker_proto_goTypes = nil
+	file_service_broker_v1_broker_proto_depIdxs = nil
+}
@@ -0,0 +1,46 @@
+// Code generated by protoc-gen-go-extension. DO NOT EDIT.
+
+package brokerv1
+
+import (
+	api "github.com/openkcm/plugin-sdk/api"
+	grpc "google.golang.org/grpc"
+)
+
+const (
+	GRPCServiceFullName = "service.broker.v1.Broker"
+)
+
+func BrokerServiceServer(server BrokerServer) api.ServiceServer {
+	return brokerServiceServer{BrokerServer: server}
+}
+
+type brokerServiceServer struct {
+	BrokerServer
+}
+
+func (s brokerServiceServer) GRPCServiceName() string {
+	return GRPCServiceFullName
+}
+
+func (s brokerServiceServer) RegisterServer(server *grpc.Server) any {
+	RegisterBrokerServer(server, s.BrokerServer)
+	return s.BrokerServer
+}
+
+type BrokerServiceClient struct {
+	BrokerClient
+}
+
+func (c *BrokerServiceClient) IsInitialized() bool {
+	return c.BrokerClient != nil
+}
+
+func (c *BrokerServiceClient) GRPCServiceName() string {
+	return GRPCServiceFullName
+}
+
+func (c *BrokerServiceClient) InitClient(conn grpc.ClientConnInterface) any {
+	c.BrokerClient = NewBrokerClient(conn)
+	return c.BrokerClient
+}
@@ -27,8 +27,12 @@ type InitRequest struct {
 	// List of all the names of gRPC services implemented by the host.
 	// These names are the fully qualified gRPC service name.
 	HostServiceNames []string `protobuf:"bytes,1,rep,name=host_service_names,json=hostServiceNames,proto3" json:"host_service_names,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+	// Whether the host serves the host services over broker streams opened to
+	// the plugin (see service.broker.v1.Broker) instead of the go-plugin
+	// broker, e.g. because the host may reattach to the running plugin.
+	BrokerStreams bool `protobuf:"varint,2,opt,name=broker_streams,json=brokerStreams,proto3" json:"broker_streams,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *InitRequest) Reset() {
@@ -68,14 +72,30 @@ func (x *InitRequest) GetHostServiceNames() []string {
 	return nil
 }
 
+func (x *InitRequest) GetBrokerStreams() bool {
+	if x != nil {
+		return x.BrokerStreams
+	}
+	return false
+}
+
 // Init response parameters
 type InitResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// List of all the names of gRPC services implemented by the service. These
 	// names are the fully qualified gRPC service name.
 	PluginServiceNames []string `protobuf:"bytes,1,rep,name=plugin_service_names,json=pluginServiceNames,proto3" json:"plugin_service_names,omitempty"`
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+	// Version of the plugin SDK the plugin was built with.
+	SdkVersion string `protobuf:"bytes,2,opt,name=sdk_version,json=sdkVersion,proto3" json:"sdk_version,omitempty"`
+	// Semantic version of the plugin.
+	PluginVersion string `protobuf:"bytes,3,opt,name=plugin_version,json=pluginVersion,proto3" json:"plugin_version,omitempty"`
+	// Build information of the plugin.
+	BuildInfo string `protobuf:"bytes,4,opt,name=build_info,json=buildInfo,proto3" json:"build_info,omitempty"`
+	// Capabilities advertised by the plugin, e.g. the supported key algorithms
+	// or feature flags.
+	Capabilities  map[string]string `protobuf:"bytes,5,rep,name=capabilities,proto3" json:"capabilities,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *InitResponse) Reset() {
@@ -115,6 +135,34 @@ func (x *InitResponse) GetPluginServiceNames() []string {
 	return nil
 }
 
+func (x *InitResponse) GetSdkVersion() string {
+	if x != nil {
+		return x.SdkVersion
+	}
+	return ""
+}
+
+func (x *InitResponse) GetPluginVersion() string {
+	if x != nil {
+		return x.PluginVersion
+	}
+	return ""
+}
+
+func (x *InitResponse) GetBuildInfo() string {
+	if x != nil {
+		return x.BuildInfo
+	}
+	return ""
+}
+
+func (x *InitResponse) GetCapabilities() map[string]string {
+	if x != nil {
+		return x.Capabilities
+	}
+	return nil
+}
+
 // Deinit request parameters
 type DeinitRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -193,11 +241,21 @@ var File_service_init_v1_init_proto protoreflect.FileDescriptor
 
 const file_service_init_v1_init_proto_rawDesc = "" +
 	"\n" +
-	"\x1aservice/init/v1/init.proto\x12\x0fservice.init.v1\";\n" +
+	"\x1aservice/init/v1/init.proto\x12\x0fservice.init.v1\"b\n" +
 	"\vInitRequest\x12,\n" +
-	"\x12host_service_names\x18\x01 \x03(\tR\x10hostServiceNames\"@\n" +
+	"\x12host_service_names\x18\x01 \x03(\tR\x10hostServiceNames\x12%\n" +
+	"\x0ebroker_streams\x18\x02 \x01(\bR\rbrokerStreams\"\xbd\x02\n" +
 	"\fInitResponse\x120\n" +
-	"\x14plugin_service_names\x18\x01 \x03(\tR\x12pluginServiceNames\"\x0f\n" +
+	"\x14plugin_service_names\x18\x01 \x03(\tR\x12pluginServiceNames\x12\x1f\n" +
+	"\vsdk_version\x18\x02 \x01(\tR\n" +
+	"sdkVersion\x12%\n" +
+	"\x0eplugin_version\x18\x03 \x01(\tR\rpluginVersion\x12\x1d\n" +
+	"\n" +
+	"build_info\x18\x04 \x01(\tR\tbuildInfo\x12S\n" +
+	"\fcapabilities\x18\x05 \x03(\v2/.service.init.v1.InitResponse.CapabilitiesEntryR\fcapabilities\x1a?\n" +
+	"\x11CapabilitiesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x0f\n" +
 	"\rDeinitRequest\"\x10\n" +
 	"\x0eDeinitResponse2\x9b\x01\n" +
 	"\tBootstrap\x12C\n" +
@@ -216,23 +274,25 @@ func file_service_init_v1_init_proto_rawDescGZIP() []byte {
 	return file_service_init_v1_init_proto_rawDescData
 }
 
-var file_service_init_v1_init_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_service_init_v1_init_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
 var file_service_init_v1_init_proto_goTypes = []any{
 	(*InitRequest)(nil),    // 0: service.init.v1.InitRequest
 	(*InitResponse)(nil),   // 1: service.init.v1.InitResponse
 	(*DeinitRequest)(nil),  // 2: service.init.v1.DeinitRequest
 	(*DeinitResponse)(nil), // 3: service.init.v1.DeinitResponse
+	nil,                    // 4: service.init.v1.InitResponse.CapabilitiesEntry
 }
 var file_service_init_v1_init_proto_depIdxs = []int32{
-	0, // 0: service.init.v1.Bootstrap.Init:input_type -> service.init.v1.InitRequest
-	2, // 1: service.init.v1.Bootstrap.Deinit:input_type -> service.init.v1.DeinitRequest
-	1, // 2: service.init.v1.Bootstrap.Init:output_type -> service.init.v1.InitResponse
-	3, // 3: service.init.v1.Bootstrap.Deinit:output_type -> service.init.v1.DeinitResponse
-	2, // [2:4] is the sub-list for method output_type
-	0, // [0:2] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	4, // 0: service.init.v1.InitResponse.capabilities:type_name -> service.init.v1.InitResponse.CapabilitiesEntry
+	0, // 1: service.init.v1.Bootstrap.Init:input_type -> service.init.v1.InitRequest
+	2, // 2: service.init.v1.Bootstrap.Deinit:input_type -> service.init.v1.DeinitRequest
+	1, // 3: service.init.v1.Bootstrap.Init:output_type -> service.init.v1.InitResponse
+	3, // 4: service.init.v1.Bootstrap.Deinit:output_type -> service.init.v1.DeinitResponse
+	3, // [3:5] is the sub-list for method output_type
+	1, // [1:3] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
 }
 
 func init() { file_service_init_v1_init_proto_init() }
@@ -246,7 +306,7 @@ func file_service_init_v1_init_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_service_init_v1_init_proto_rawDesc), len(file_service_init_v1_init_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   5,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -0,0 +1,318 @@
+// Package semver implements the parsing and comparison of semantic versions
+// and the constraints plugins are required to satisfy.
+package semver
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version is a semantic version, see https://semver.org.
+type Version struct {
+	Major, Minor, Patch uint64
+
+	// Prerelease is the pre-release version, e.g. "rc.1".
+	Prerelease string
+
+	// Build is the build metadata, which is ignored when comparing versions.
+	Build string
+
+	// parts is the number of version numbers given, which is less than three
+	// for partial versions, e.g. "1.2", used in constraints.
+	parts int
+}
+
+// Parse parses a semantic version, optionally prefixed with "v". The minor
+// and patch versions may be omitted, in which case they are zero.
+func Parse(s string) (Version, error) {
+	v, err := parse(s)
+	if err != nil {
+		return Version{}, err
+	}
+	v.parts = 3
+	return v, nil
+}
+
+func parse(s string) (Version, error) {
+	var v Version
+	rest := strings.TrimPrefix(strings.TrimSpace(s), "v")
+	if i := strings.IndexByte(rest, '+'); i >= 0 {
+		rest, v.Build = rest[:i], rest[i+1:]
+		if v.Build == "" {
+			return Version{}, fmt.Errorf("invalid version %q: empty build metadata", s)
+		}
+	}
+	if i := strings.IndexByte(rest, '-'); i >= 0 {
+		rest, v.Prerelease = rest[:i], rest[i+1:]
+		if v.Prerelease == "" {
+			return Version{}, fmt.Errorf("invalid version %q: empty pre-release version", s)
+		}
+	}
+
+	numbers := strings.Split(rest, ".")
+	if len(numbers) > 3 {
+		return Version{}, fmt.Errorf("invalid version %q: too many version numbers", s)
+	}
+	for i, number := range numbers {
+		n, err := strconv.ParseUint(number, 10, 64)
+		if err != nil {
+			return Version{}, fmt.Errorf("invalid version %q: %q is not a version number", s, number)
+		}
+		switch i {
+		case 0:
+			v.Major = n
+		case 1:
+			v.Minor = n
+		case 2:
+			v.Patch = n
+		}
+	}
+	if len(numbers) < 3 && (v.Prerelease != "" || v.Build != "") {
+		return Version{}, fmt.Errorf("invalid version %q: pre-release versions must be complete", s)
+	}
+	v.parts = len(numbers)
+	return v, nil
+}
+
+// Compare returns -1, 0 or +1 depending on whether v is lower than, equal to
+// or greater than other. The build metadata is ignored.
+func (v Version) Compare(other Version) int {
+	if c := cmp.Compare(v.Major, other.Major); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(v.Minor, other.Minor); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(v.Patch, other.Patch); c != 0 {
+		return c
+	}
+	return comparePrerelease(v.Prerelease, other.Prerelease)
+}
+
+// comparePrerelease compares pre-release versions. A version without
+// pre-release version has a higher precedence than one with.
+func comparePrerelease(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return 1
+	case b == "":
+		return -1
+	}
+
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		an, aErr := strconv.ParseUint(as[i], 10, 64)
+		bn, bErr := strconv.ParseUint(bs[i], 10, 64)
+		switch {
+		case aErr == nil && bErr == nil:
+			if an != bn {
+				return cmp.Compare(an, bn)
+			}
+		case aErr == nil:
+			// Numeric identifiers have a lower precedence.
+			return -1
+		case bErr == nil:
+			return 1
+		default:
+			if c := strings.Compare(as[i], bs[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	return cmp.Compare(uint64(len(as)), uint64(len(bs)))
+}
+
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Prerelease != "" {
+		s += "-" + v.Prerelease
+	}
+	if v.Build != "" {
+		s += "+" + v.Build
+	}
+	return s
+}
+
+// Constraint is a set of alternatives, separated by "||", of comparisons that
+// must all hold, separated by commas, e.g. ">=1.2, <2 || >=3". The supported
+// operators are =, !=, >, >=, <, <=, ~ (same minor version) and ^ (same
+// major version, or minor version for 0.x versions). A version without
+// operator must be equal; partial versions match any version they prefix.
+type Constraint struct {
+	source       string
+	alternatives [][]comparison
+}
+
+type comparison struct {
+	op      string
+	version Version
+}
+
+// ParseConstraint parses a version constraint.
+func ParseConstraint(s string) (Constraint, error) {
+	c := Constraint{source: strings.TrimSpace(s)}
+	if c.source == "" {
+		return Constraint{}, errors.New("empty version constraint")
+	}
+	for _, alternative := range strings.Split(c.source, "||") {
+		var comparisons []comparison
+		for _, part := range strings.Split(alternative, ",") {
+			comp, err := parseComparison(strings.TrimSpace(part))
+			if err != nil {
+				return Constraint{}, fmt.Errorf("invalid version constraint %q: %w", s, err)
+			}
+			comparisons = append(comparisons, comp)
+		}
+		c.alternatives = append(c.alternatives, comparisons)
+	}
+	return c, nil
+}
+
+func parseComparison(s string) (comparison, error) {
+	if s == "" {
+		return comparison{}, errors.New("empty comparison")
+	}
+	var op string
+	for _, candidate := range []string{">=", "<=", "!=", ">", "<", "=", "~", "^"} {
+		if strings.HasPrefix(s, candidate) {
+			op = candidate
+			break
+		}
+	}
+	version, err := parse(strings.TrimSpace(s[len(op):]))
+	if err != nil {
+		return comparison{}, err
+	}
+	if op == "" {
+		op = "="
+	}
+	return comparison{op: op, version: version}, nil
+}
+
+// Check reports whether the version satisfies the constraint.
+func (c Constraint) Check(v Version) bool {
+	for _, alternative := range c.alternatives {
+		satisfied := true
+		for _, comp := range alternative {
+			if !comp.check(v) {
+				satisfied = false
+				break
+			}
+		}
+		if satisfied {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsMajor reports whether some release of the major version satisfies
+// the constraint. The releases checked are the first one of the major version
+// and those at and right above the bounds of the comparisons, which is where
+// the ranges matched by a constraint start.
+func (c Constraint) AllowsMajor(major uint64) bool {
+	candidates := []Version{{Major: major}}
+	for _, alternative := range c.alternatives {
+		for _, comp := range alternative {
+			lower, upper := comp.bounds()
+			bounds := []Version{lower}
+			if upper != nil {
+				bounds = append(bounds, *upper)
+			}
+			for _, bound := range bounds {
+				if bound.Major != major {
+					continue
+				}
+				bound.Prerelease = ""
+				candidates = append(candidates, bound, Version{Major: major, Minor: bound.Minor, Patch: bound.Patch + 1})
+			}
+		}
+	}
+	for _, v := range candidates {
+		if c.Check(v) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c comparison) check(v Version) bool {
+	lower, upper := c.bounds()
+	switch c.op {
+	case "=":
+		if upper == nil {
+			return v.Compare(lower) == 0
+		}
+		return v.Compare(lower) >= 0 && v.Compare(*upper) < 0
+	case "!=":
+		if upper == nil {
+			return v.Compare(lower) != 0
+		}
+		return v.Compare(lower) < 0 || v.Compare(*upper) >= 0
+	case ">":
+		if upper != nil {
+			return v.Compare(*upper) >= 0
+		}
+		return v.Compare(lower) > 0
+	case ">=":
+		return v.Compare(lower) >= 0
+	case "<":
+		if c.version.parts < 3 {
+			// Partial versions also exclude their pre-releases.
+			lower.Prerelease = "0"
+		}
+		return v.Compare(lower) < 0
+	case "<=":
+		if upper != nil {
+			return v.Compare(*upper) < 0
+		}
+		return v.Compare(lower) <= 0
+	case "~", "^":
+		return v.Compare(lower) >= 0 && v.Compare(*upper) < 0
+	}
+	return false
+}
+
+// bounds returns the lowest version matched by the version of the comparison
+// and the version right above the highest one, if any. Complete versions only
+// match themselves, except for the ~ and ^ operators.
+func (c comparison) bounds() (Version, *Version) {
+	lower := c.version
+	lower.Build = ""
+	var upper *Version
+	switch {
+	case c.op == "~":
+		if lower.parts < 2 {
+			upper = &Version{Major: lower.Major + 1}
+		} else {
+			upper = &Version{Major: lower.Major, Minor: lower.Minor + 1}
+		}
+	case c.op == "^":
+		switch {
+		case lower.Major > 0 || lower.parts < 2:
+			upper = &Version{Major: lower.Major + 1}
+		case lower.Minor > 0 || lower.parts < 3:
+			upper = &Version{Minor: lower.Minor + 1}
+		default:
+			upper = &Version{Patch: lower.Patch + 1}
+		}
+	case lower.parts == 1:
+		upper = &Version{Major: lower.Major + 1}
+	case lower.parts == 2:
+		upper = &Version{Major: lower.Major, Minor: lower.Minor + 1}
+	}
+	if upper != nil {
+		// The versions right above the range are its lowest pre-releases.
+		upper.Prerelease = "0"
+	}
+	return lower, upper
+}
+
+func (c Constraint) String() string {
+	return c.source
+}
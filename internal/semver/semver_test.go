@@ -0,0 +1,139 @@
+package semver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{input: "1.2.3", want: "1.2.3"},
+		{input: "v1.2.3", want: "1.2.3"},
+		{input: "1.2", want: "1.2.0"},
+		{input: "1.2.3-rc.1+build.5", want: "1.2.3-rc.1+build.5"},
+		{input: "1.2.3.4", wantErr: true},
+		{input: "1.x", wantErr: true},
+		{input: "1.2-rc", wantErr: true},
+		{input: "1.2.3-", wantErr: true},
+		{input: "", wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			// Act
+			got, err := Parse(tc.input)
+
+			// Assert
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got.String())
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	// Ordered by increasing precedence, see https://semver.org/#spec-item-11.
+	ordered := []string{
+		"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta",
+		"1.0.0-beta.2", "1.0.0-beta.11", "1.0.0-rc.1", "1.0.0", "1.0.1", "1.1.0", "2.0.0",
+	}
+	for i := range ordered {
+		for j := range ordered {
+			a, err := Parse(ordered[i])
+			assert.NoError(t, err)
+			b, err := Parse(ordered[j])
+			assert.NoError(t, err)
+
+			want := 0
+			switch {
+			case i < j:
+				want = -1
+			case i > j:
+				want = 1
+			}
+			assert.Equal(t, want, a.Compare(b), "%s <=> %s", a, b)
+		}
+	}
+}
+
+func TestConstraint(t *testing.T) {
+	tests := []struct {
+		constraint string
+		matches    []string
+		rejects    []string
+	}{
+		{constraint: ">=1.2, <2", matches: []string{"1.2.0", "1.9.9"}, rejects: []string{"1.1.9", "2.0.0", "2.0.0-rc.1"}},
+		{constraint: "1.2.3", matches: []string{"1.2.3", "1.2.3+build"}, rejects: []string{"1.2.4", "1.2.2"}},
+		{constraint: "=1.2", matches: []string{"1.2.0", "1.2.9"}, rejects: []string{"1.3.0", "1.1.0"}},
+		{constraint: "!=1.2.3", matches: []string{"1.2.4"}, rejects: []string{"1.2.3"}},
+		{constraint: ">1.2", matches: []string{"1.3.0"}, rejects: []string{"1.2.9"}},
+		{constraint: "<=1.2", matches: []string{"1.2.9"}, rejects: []string{"1.3.0"}},
+		{constraint: "~1.2.3", matches: []string{"1.2.3", "1.2.9"}, rejects: []string{"1.3.0", "1.2.2"}},
+		{constraint: "^1.2.3", matches: []string{"1.2.3", "1.9.0"}, rejects: []string{"2.0.0", "1.2.2"}},
+		{constraint: "^0.2.3", matches: []string{"0.2.9"}, rejects: []string{"0.3.0"}},
+		{constraint: "^0.0.3", matches: []string{"0.0.3"}, rejects: []string{"0.0.4"}},
+		{constraint: "<1 || >=3", matches: []string{"0.9.0", "3.1.0"}, rejects: []string{"1.0.0", "2.9.9"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.constraint, func(t *testing.T) {
+			// Arrange
+			c, err := ParseConstraint(tc.constraint)
+			assert.NoError(t, err)
+
+			// Act & Assert
+			for _, s := range tc.matches {
+				v, err := Parse(s)
+				assert.NoError(t, err)
+				assert.True(t, c.Check(v), "%s should satisfy %s", s, c)
+			}
+			for _, s := range tc.rejects {
+				v, err := Parse(s)
+				assert.NoError(t, err)
+				assert.False(t, c.Check(v), "%s should not satisfy %s", s, c)
+			}
+		})
+	}
+}
+
+func TestConstraintAllowsMajor(t *testing.T) {
+	tests := []struct {
+		constraint string
+		allows     []uint64
+		rules      []uint64
+	}{
+		{constraint: ">=1.2, <2", allows: []uint64{1}, rules: []uint64{0, 2}},
+		{constraint: ">=1.2", allows: []uint64{1, 2, 5}, rules: []uint64{0}},
+		{constraint: ">1.2.3, <1.2.5", allows: []uint64{1}, rules: []uint64{2}},
+		{constraint: "^2.1", allows: []uint64{2}, rules: []uint64{1, 3}},
+		{constraint: "<1 || >=3", allows: []uint64{0, 3}, rules: []uint64{1, 2}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.constraint, func(t *testing.T) {
+			// Arrange
+			c, err := ParseConstraint(tc.constraint)
+			assert.NoError(t, err)
+
+			// Act & Assert
+			for _, major := range tc.allows {
+				assert.True(t, c.AllowsMajor(major), "%s should allow major version %d", c, major)
+			}
+			for _, major := range tc.rules {
+				assert.False(t, c.AllowsMajor(major), "%s should rule out major version %d", c, major)
+			}
+		})
+	}
+}
+
+func TestParseConstraintInvalid(t *testing.T) {
+	for _, constraint := range []string{"", ">=1.2,", ">=x", "1.2 || "} {
+		_, err := ParseConstraint(constraint)
+		assert.Error(t, err, constraint)
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"google.golang.org/grpc/credentials/insecure"
 
 	"github.com/openkcm/plugin-sdk/api"
+	pluginoption "github.com/openkcm/plugin-sdk/api/plugin-option"
 	"github.com/openkcm/plugin-sdk/internal/bootstrap"
 	"github.com/openkcm/plugin-sdk/internal/slog2hclog"
 )
@@ -30,6 +31,9 @@ type builtInPluginStruct struct {
 	services  []api.ServiceServer
 	buildInfo string
 	version   uint
+
+	unaryInterceptors  []grpc.UnaryServerInterceptor
+	streamInterceptors []grpc.StreamServerInterceptor
 }
 
 func (p *builtInPluginStruct) Name() string {
@@ -60,6 +64,16 @@ func (p *builtInPluginStruct) Version() uint {
 	return p.version
 }
 
+func (p *builtInPluginStruct) SDKVersion() string {
+	return bootstrap.SDKVersion()
+}
+
+// Capabilities are only advertised by the plugin servers when the built-in
+// plugin is loaded, see Plugin.Info.
+func (p *builtInPluginStruct) Capabilities() api.Capabilities {
+	return api.Capabilities{}
+}
+
 func (p *builtInPluginStruct) SetValue(value string) {
 	p.buildInfo = value
 }
@@ -75,6 +89,26 @@ func MakeBuiltIn(name string, pluginServer api.PluginServer, serviceServers ...a
 	}
 }
 
+// MakeBuiltInWithOptions makes a built-in plugin configured with the same
+// options as an external plugin served with plugin.Serve. The service
+// servers, the validation and the interceptors are applied, the options
+// specific to external plugins are ignored.
+func MakeBuiltInWithOptions(name string, pluginServer api.PluginServer, opts ...pluginoption.ServerOption) BuiltInPlugin {
+	cfg := &pluginoption.ServerConfiguration{PluginServer: pluginServer}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	unary, stream := bootstrap.ServerInterceptors(cfg)
+	return &builtInPluginStruct{
+		name:               name,
+		plugin:             pluginServer,
+		services:           cfg.ServiceServers,
+		version:            1,
+		unaryInterceptors:  unary,
+		streamInterceptors: stream,
+	}
+}
+
 func loadBuiltInPlugin(ctx context.Context, builtIn BuiltInPlugin, pluginConfig PluginConfig) (_ *pluginImpl, err error) {
 	dialer := &builtinDialer{
 		pluginName:   builtIn.Name(),
@@ -90,7 +124,12 @@ func loadBuiltInPlugin(ctx context.Context, builtIn BuiltInPlugin, pluginConfig
 	}()
 	closers = append(closers, dialer)
 
-	builtinServer, serverCloser := newBuiltInServer(pluginConfig.Logger)
+	var unary []grpc.UnaryServerInterceptor
+	var stream []grpc.StreamServerInterceptor
+	if b, ok := builtIn.(*builtInPluginStruct); ok {
+		unary, stream = b.unaryInterceptors, b.streamInterceptors
+	}
+	builtinServer, serverCloser := newBuiltInServer(pluginConfig.Logger, unary, stream)
 	closers = append(closers, serverCloser)
 
 	pluginServers := append([]api.ServiceServer{builtIn.Plugin()}, builtIn.Services()...)
@@ -116,15 +155,17 @@ func loadBuiltInPlugin(ctx context.Context, builtIn BuiltInPlugin, pluginConfig
 		version:   version,
 	}
 
-	p, err := newPlugin(ctx, builtinConn, info, pluginConfig.Logger, closers, pluginConfig.HostServices)
+	p, err := newPlugin(ctx, builtinConn, info, pluginConfig, closers)
 	return p, err
 }
 
-func newBuiltInServer(log *slog.Logger) (*grpc.Server, io.Closer) {
+// newBuiltInServer returns the server of a built-in plugin. The given
+// interceptors are chained after the interceptors of the catalog.
+func newBuiltInServer(log *slog.Logger, unary []grpc.UnaryServerInterceptor, stream []grpc.StreamServerInterceptor) (*grpc.Server, io.Closer) {
 	drain := &drainHandlers{}
 	return grpc.NewServer(
-		grpc.ChainStreamInterceptor(drain.StreamServerInterceptor, streamPanicInterceptor(log)),
-		grpc.ChainUnaryInterceptor(drain.UnaryServerInterceptor, unaryPanicInterceptor(log)),
+		grpc.ChainStreamInterceptor(append([]grpc.StreamServerInterceptor{drain.StreamServerInterceptor, streamPanicInterceptor(log)}, stream...)...),
+		grpc.ChainUnaryInterceptor(append([]grpc.UnaryServerInterceptor{drain.UnaryServerInterceptor, unaryPanicInterceptor(log)}, unary...)...),
 	), closerFunc(drain.Wait)
 }
 
@@ -139,7 +180,7 @@ func (d *builtinDialer) DialHost(context.Context) (grpc.ClientConnInterface, err
 	if d.conn != nil {
 		return d.conn, nil
 	}
-	server := newHostServer(d.log, d.pluginName)
+	server := newHostServer(d.log, d.pluginName, d.hostServices)
 	conn, err := startPipeServer(server, d.log)
 	if err != nil {
 		return nil, err
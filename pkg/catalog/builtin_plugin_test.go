@@ -5,11 +5,17 @@ import (
 	"errors"
 	"io"
 	"log/slog"
+	"slices"
 	"testing"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/openkcm/plugin-sdk/api"
+	pluginoption "github.com/openkcm/plugin-sdk/api/plugin-option"
+	testv1 "github.com/openkcm/plugin-sdk/proto/plugin/test/v1"
+	"github.com/openkcm/plugin-sdk/proto/plugin/test/v1/testv1fake"
 )
 
 //
@@ -128,6 +134,126 @@ func TestMakeBuiltIn(t *testing.T) {
 	})
 }
 
+func TestMakeBuiltInWithOptions(t *testing.T) {
+	t.Parallel()
+
+	var calls []string
+	record := func(name string) grpc.UnaryServerInterceptor {
+		return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			calls = append(calls, name)
+			return handler(ctx, req)
+		}
+	}
+	intercept := func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if info.FullMethod != testv1.TestService_Test_FullMethodName {
+			return handler(ctx, req)
+		}
+		return &testv1.TestResponse{Response: "intercepted"}, nil
+	}
+
+	repo := &testPluginRepo{versions: []api.Version{testFacadeVersion{version: 1}}}
+	cat, err := New(context.Background(), Config{
+		Logger:        discardLogger(),
+		PluginConfigs: []PluginConfig{{Name: "intercepted", Type: testv1.Type}},
+	}, testRepository{plugins: map[string]api.PluginRepo{testv1.Type: repo}},
+		MakeBuiltInWithOptions("intercepted", testv1.TestServicePluginServer(&blockingTestPlugin{}),
+			pluginoption.WithUnaryInterceptors(record("first")),
+			pluginoption.WithUnaryInterceptors(record("second"), intercept),
+		))
+	if err != nil {
+		t.Fatalf("New(): %v", err)
+	}
+	defer cat.Close()
+
+	calls = nil
+	resp, err := repo.facades[0].Test(context.Background(), &testv1.TestRequest{})
+	if err != nil {
+		t.Fatalf("Test(): %v", err)
+	}
+	if resp.GetResponse() != "intercepted" || !slices.Equal(calls, []string{"first", "second"}) {
+		t.Fatalf("expected interceptors to be chained in order, got %q and calls %q", resp.GetResponse(), calls)
+	}
+}
+
+func TestGeneratedPluginRepo(t *testing.T) {
+	t.Parallel()
+
+	server := &blockingTestPlugin{started: make(chan struct{}), release: make(chan struct{})}
+	close(server.release)
+	repo := &testv1.TestServiceRepo{}
+	cat, err := New(context.Background(), Config{
+		Logger:        discardLogger(),
+		PluginConfigs: []PluginConfig{{Name: "generated", Type: testv1.Type}},
+	}, testRepository{plugins: map[string]api.PluginRepo{testv1.Type: repo}},
+		MakeBuiltIn("generated", testv1.TestServicePluginServer(server)))
+	if err != nil {
+		t.Fatalf("New(): %v", err)
+	}
+	defer cat.Close()
+
+	facades := repo.Facades()
+	if len(facades) != 1 || facades[0].Name() != "generated" || facades[0].Version() != 1 {
+		t.Fatalf("unexpected facades %v", facades)
+	}
+	if _, err := facades[0].Test(context.Background(), &testv1.TestRequest{}); err != nil {
+		t.Fatalf("Test(): %v", err)
+	}
+}
+
+func TestGeneratedFacadeWrapsErrors(t *testing.T) {
+	t.Parallel()
+
+	repo := &testv1.TestServiceRepo{}
+	cat, err := New(context.Background(), Config{
+		Logger:        discardLogger(),
+		PluginConfigs: []PluginConfig{{Name: "unimplemented", Type: testv1.Type}},
+	}, testRepository{plugins: map[string]api.PluginRepo{testv1.Type: repo}},
+		MakeBuiltIn("unimplemented", testv1.TestServicePluginServer(testv1.UnimplementedTestServiceServer{})))
+	if err != nil {
+		t.Fatalf("New(): %v", err)
+	}
+	defer cat.Close()
+
+	_, err = repo.Facades()[0].Test(context.Background(), &testv1.TestRequest{})
+	if want := "testservice(unimplemented): method Test not implemented"; status.Convert(err).Message() != want {
+		t.Fatalf("expected error %q, got %v", want, err)
+	}
+}
+
+func TestGeneratedFake(t *testing.T) {
+	t.Parallel()
+
+	fake := &testv1fake.TestService{
+		TestFunc: func(_ context.Context, req *testv1.TestRequest) (*testv1.TestResponse, error) {
+			return &testv1.TestResponse{Response: req.GetRequest()}, nil
+		},
+	}
+	fake.ReturnTest(nil, status.Error(codes.Unavailable, "scripted"))
+
+	repo := &testv1.TestServiceRepo{}
+	cat, err := New(context.Background(), Config{
+		Logger:        discardLogger(),
+		PluginConfigs: []PluginConfig{{Name: "fake", Type: testv1.Type}},
+	}, testRepository{plugins: map[string]api.PluginRepo{testv1.Type: repo}},
+		MakeBuiltIn("fake", fake.PluginServer()))
+	if err != nil {
+		t.Fatalf("New(): %v", err)
+	}
+	defer cat.Close()
+
+	facade := repo.Facades()[0]
+	if _, err := facade.Test(context.Background(), &testv1.TestRequest{Request: "first"}); status.Code(err) != codes.Unavailable {
+		t.Fatalf("expected scripted error, got %v", err)
+	}
+	resp, err := facade.Test(context.Background(), &testv1.TestRequest{Request: "second"})
+	if err != nil || resp.GetResponse() != "second" {
+		t.Fatalf("expected response of the function, got %v, %v", resp, err)
+	}
+	if calls := fake.TestCalls(); len(calls) != 2 || calls[0].GetRequest() != "first" {
+		t.Fatalf("unexpected calls %v", calls)
+	}
+}
+
 func TestBuiltinDialer(t *testing.T) {
 	t.Parallel()
 
@@ -176,7 +302,7 @@ func TestNewBuiltInServer(t *testing.T) {
 
 	log := slog.New(slog.NewTextHandler(discardWriter{}, nil))
 
-	server, closer := newBuiltInServer(log)
+	server, closer := newBuiltInServer(log, nil, nil)
 	if server == nil {
 		t.Fatal("expected grpc.Server")
 	}
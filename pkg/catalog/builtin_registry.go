@@ -1,11 +1,18 @@
 package catalog
 
+import (
+	"sync"
+
+	"github.com/openkcm/plugin-sdk/api"
+)
+
 type BuiltInPluginRetriever interface {
 	Retrieve() []BuiltInPlugin
 }
 
 type BuiltInPluginRegistry interface {
 	BuiltInPluginRetriever
+	api.BuiltInRegistrar
 
 	Register(plugin BuiltInPlugin)
 }
@@ -24,8 +31,50 @@ func (r *buildInRegistry) Register(plugin BuiltInPlugin) {
 	r.plugins = append(r.plugins, plugin)
 }
 
+// RegisterBuiltIn registers the built-in plugin made with MakeBuiltIn from
+// the given servers.
+func (r *buildInRegistry) RegisterBuiltIn(name string, pluginServer api.PluginServer, serviceServers ...api.ServiceServer) {
+	r.Register(MakeBuiltIn(name, pluginServer, serviceServers...))
+}
+
 func (r *buildInRegistry) Retrieve() []BuiltInPlugin {
 	plugins := make([]BuiltInPlugin, 0, len(r.plugins))
 	plugins = append(plugins, r.plugins...)
 	return plugins
 }
+
+// DefaultBuiltInPluginRegistry returns the registry of the built-in plugins
+// registered with api.RegisterBuiltIn, which the NewBuiltIn functions
+// generated by protoc-gen-go-extension register into. The plugins registered
+// into it directly are retrieved after them.
+func DefaultBuiltInPluginRegistry() BuiltInPluginRegistry {
+	return defaultBuiltInPluginRegistry
+}
+
+var defaultBuiltInPluginRegistry = &defaultBuiltInRegistry{}
+
+type defaultBuiltInRegistry struct {
+	mu      sync.Mutex
+	plugins []BuiltInPlugin
+}
+
+func (r *defaultBuiltInRegistry) Register(plugin BuiltInPlugin) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.plugins = append(r.plugins, plugin)
+}
+
+func (r *defaultBuiltInRegistry) RegisterBuiltIn(name string, pluginServer api.PluginServer, serviceServers ...api.ServiceServer) {
+	api.RegisterBuiltIn(name, pluginServer, serviceServers...)
+}
+
+func (r *defaultBuiltInRegistry) Retrieve() []BuiltInPlugin {
+	builtIns := api.BuiltIns()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	plugins := make([]BuiltInPlugin, 0, len(builtIns)+len(r.plugins))
+	for _, builtIn := range builtIns {
+		plugins = append(plugins, MakeBuiltIn(builtIn.Name, builtIn.PluginServer, builtIn.ServiceServers...))
+	}
+	return append(plugins, r.plugins...)
+}
@@ -3,6 +3,9 @@ package catalog
 import (
 	"reflect"
 	"testing"
+
+	testv1 "github.com/openkcm/plugin-sdk/proto/plugin/test/v1"
+	configv1 "github.com/openkcm/plugin-sdk/proto/service/common/config/v1"
 )
 
 func TestBuiltInRegistry(t *testing.T) {
@@ -58,3 +61,39 @@ func TestBuiltInRegistry(t *testing.T) {
 		}
 	})
 }
+
+type configurableTestPlugin struct {
+	testv1.UnimplementedTestServiceServer
+	configv1.UnimplementedConfigServer
+}
+
+func TestGeneratedNewBuiltIn(t *testing.T) {
+	t.Parallel()
+
+	if err := testv1.NewBuiltIn("generated-configurable", &configurableTestPlugin{}); err != nil {
+		t.Fatalf("NewBuiltIn(): %v", err)
+	}
+	if err := testv1.NewBuiltIn("generated-plain", testv1.UnimplementedTestServiceServer{}); err != nil {
+		t.Fatalf("NewBuiltIn(): %v", err)
+	}
+	if err := testv1.NewBuiltIn("generated-invalid", &configv1.UnimplementedConfigServer{}); err == nil {
+		t.Fatalf("expected error for an implementation of another service")
+	}
+
+	plugins := map[string]BuiltInPlugin{}
+	for _, plugin := range DefaultBuiltInPluginRegistry().Retrieve() {
+		plugins[plugin.Name()] = plugin
+	}
+	if _, ok := plugins["generated-invalid"]; ok {
+		t.Fatalf("unexpected plugin %q", "generated-invalid")
+	}
+	configurable, ok := plugins["generated-configurable"]
+	if !ok || configurable.Type() != testv1.Type || len(configurable.Services()) != 1 ||
+		configurable.Services()[0].GRPCServiceName() != configv1.GRPCServiceFullName {
+		t.Fatalf("unexpected plugin %v", configurable)
+	}
+	plain, ok := plugins["generated-plain"]
+	if !ok || len(plain.Services()) != 0 {
+		t.Fatalf("unexpected plugin %v", plain)
+	}
+}
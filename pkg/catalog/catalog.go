@@ -2,17 +2,20 @@ package catalog
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
 	"strings"
 
 	"github.com/openkcm/plugin-sdk/api"
+	"github.com/openkcm/plugin-sdk/internal/semver"
 )
 
 type Catalog struct {
 	closers     io.Closer
 	configurers Reconfigurers
+	report      CompatibilityReport
 }
 
 func (c *Catalog) Close() error {
@@ -107,8 +110,17 @@ func New(ctx context.Context, config Config, repo api.Repository, builtIns ...Bu
 		config.Logger = slog.Default()
 	}
 
+	if config.KeepPluginsRunning && config.StateFile == "" {
+		return nil, errors.New("keeping plugins running requires a state file")
+	}
+	var reattach *reattachState
+	if config.StateFile != "" {
+		reattach = &reattachState{path: config.StateFile}
+	}
+
 	pluginCounts := make(map[string]int)
 	var reconfigurers Reconfigurers
+	var report CompatibilityReport
 
 	for _, pluginConfig := range config.PluginConfigs {
 		if pluginConfig.Disabled {
@@ -117,6 +129,22 @@ func New(ctx context.Context, config Config, repo api.Repository, builtIns ...Bu
 		}
 
 		pluginConfig.HostServices = config.HostServices
+		if pluginConfig.IsExternal() && !pluginConfig.IsRemote() {
+			switch {
+			case !pluginConfig.isOnDemand() && pluginConfig.Instances <= 1:
+				pluginConfig.reattach = reattach
+				pluginConfig.keepRunning = reattach != nil && config.KeepPluginsRunning
+			case reattach != nil:
+				config.Logger.Warn("Plugin pools and plugins started on demand are not reattached to; they are stopped with the catalog",
+					Name, pluginConfig.Name)
+			}
+		}
+
+		if pluginConfig.VersionConstraint != "" {
+			if _, err := semver.ParseConstraint(pluginConfig.VersionConstraint); err != nil {
+				return nil, fmt.Errorf("plugin %q: %w", pluginConfig.Name, err)
+			}
+		}
 
 		pluginRepo, ok := pluginRepos[pluginConfig.Type]
 		if !ok {
@@ -124,6 +152,10 @@ func New(ctx context.Context, config Config, repo api.Repository, builtIns ...Bu
 			return nil, fmt.Errorf("unsupported plugin type %q", pluginConfig.Type)
 		}
 
+		if pluginConfig.StartMode == StartModeLazy && len(pluginConfig.ServiceNames) == 0 {
+			pluginConfig.ServiceNames = assumedServiceNames(pluginRepo, pluginConfig)
+		}
+
 		plugin, err := loadPluginAs(ctx, config.Logger, pluginConfig, builtIns...)
 		if err != nil {
 			return nil, err
@@ -131,10 +163,14 @@ func New(ctx context.Context, config Config, repo api.Repository, builtIns ...Bu
 
 		closers = append(closers, pluginCloser{plugin: plugin, log: plugin.Logger()})
 
-		cfrer, err := plugin.bindRepos(pluginRepo, serviceRepos)
+		cfrer, compatibility, err := plugin.bindRepos(pluginRepo, serviceRepos)
+		report.Plugins = append(report.Plugins, compatibility)
 		if err != nil {
 			plugin.Logger().Error("Failed to bind plugin", "error", err)
-			return nil, fmt.Errorf("failed to bind plugin %q: %w", pluginConfig.Name, err)
+			return nil, &CompatibilityError{
+				Report: report,
+				Err:    fmt.Errorf("failed to bind plugin %q: %w", pluginConfig.Name, err),
+			}
 		}
 
 		externalYamlConfiguration := strings.TrimSpace(pluginConfig.YamlConfiguration)
@@ -174,9 +210,14 @@ func New(ctx context.Context, config Config, repo api.Repository, builtIns ...Bu
 		pluginCounts[pluginConfig.Type]++
 	}
 
+	if config.StrictCompatibility && len(report.Problems()) > 0 {
+		return nil, &CompatibilityError{Report: report}
+	}
+
 	impl := &Catalog{
 		closers:     closers,
 		configurers: reconfigurers,
+		report:      report,
 	}
 
 	// Make sure all plugin constraints are satisfied
@@ -194,6 +235,14 @@ func New(ctx context.Context, config Config, repo api.Repository, builtIns ...Bu
 }
 
 func loadPluginAs(ctx context.Context, logger *slog.Logger, pluginConfig PluginConfig, builtIns ...BuiltInPlugin) (*pluginImpl, error) {
+	if pluginConfig.IsRemote() {
+		plugin, err := loadPluginAsRemote(ctx, logger, pluginConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load remote plugin %s: %w", pluginConfig.Name, err)
+		}
+		return plugin, nil
+	}
+
 	if pluginConfig.IsExternal() {
 		plugin, err := loadPluginAsExternal(ctx, logger, pluginConfig)
 		if err != nil {
@@ -223,9 +272,32 @@ func loadPluginAsExternal(ctx context.Context, logger *slog.Logger, pluginConfig
 		Type, pluginConfig.Type,
 	)
 
+	switch {
+	case pluginConfig.isOnDemand():
+		return loadPluginOnDemand(ctx, pluginConfig)
+	case pluginConfig.Instances > 1:
+		return loadPluginPool(ctx, pluginConfig)
+	}
 	return loadPlugin(ctx, pluginConfig)
 }
 
+func loadPluginAsRemote(ctx context.Context, logger *slog.Logger, pluginConfig PluginConfig) (*pluginImpl, error) {
+	if pluginConfig.Name == "" {
+		return nil, fmt.Errorf("failed to load remote plugin, missing name")
+	}
+
+	if pluginConfig.Type == "" {
+		return nil, fmt.Errorf("failed to load remote plugin %s, missing type", pluginConfig.Name)
+	}
+
+	pluginConfig.Logger = logger.With(
+		Name, pluginConfig.Name,
+		Type, pluginConfig.Type,
+	)
+
+	return loadRemotePlugin(ctx, pluginConfig)
+}
+
 func loadPluginAsBuiltIn(ctx context.Context, logger *slog.Logger, pluginConfig PluginConfig, builtIns ...BuiltInPlugin) (*pluginImpl, error) {
 	if pluginConfig.Name == "" {
 		return nil, fmt.Errorf("failed to load builtin plugin, missing name")
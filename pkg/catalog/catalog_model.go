@@ -23,4 +23,28 @@ type Config struct {
 	// HostServices are the servers for host services provided by SPIRE to
 	// plugins.
 	HostServices []api.ServiceServer
+
+	// StateFile is the file the catalog persists the reattach configuration
+	// of the external plugins to. On startup, plugins still running with the
+	// same binary and configuration are reattached to instead of being
+	// launched. Plugin pools and plugins started on demand are not
+	// supported: they are always launched and stopped with the catalog,
+	// even with KeepPluginsRunning, which is logged as a warning. If empty,
+	// plugins are not reattached to. The file holds the
+	// mTLS keys the host connects to the plugins with and is only readable
+	// by its owner.
+	StateFile string
+
+	// KeepPluginsRunning leaves the external plugins recorded in the state
+	// file running when the catalog is closed, for the host to reattach to
+	// them after a restart. It requires StateFile and does not apply to
+	// plugin pools nor plugins started on demand.
+	KeepPluginsRunning bool
+
+	// StrictCompatibility fails loading with a CompatibilityError when a
+	// plugin is bound to a deprecated facade version or advertises services
+	// no facade is bound to. Otherwise, these are only logged and reported
+	// by Catalog.CompatibilityReport. Plugins that cannot be bound at all
+	// always fail loading with a CompatibilityError.
+	StrictCompatibility bool
 }
@@ -1,6 +1,7 @@
 package catalog
 
 import (
+	"context"
 	"io"
 	"time"
 
@@ -19,6 +20,26 @@ func (cs closerGroup) Close() error {
 	return errs.Err()
 }
 
+// shutdowner is implemented by closers that can honour the deadline of a
+// context when closing.
+type shutdowner interface {
+	shutdown(ctx context.Context) error
+}
+
+// shutdown closes all closers in the group in reverse order. The context is
+// passed to the closers that accept one.
+func (cs closerGroup) shutdown(ctx context.Context) error {
+	var errs errs.Group
+	for i := len(cs) - 1; i >= 0; i-- {
+		if s, ok := cs[i].(shutdowner); ok {
+			errs.Add(s.shutdown(ctx))
+			continue
+		}
+		errs.Add(cs[i].Close())
+	}
+	return errs.Err()
+}
+
 type closerFunc func()
 
 // groupCloserFuncs returns a closerGroup from the given functions.
@@ -14,3 +14,15 @@ func pluginCmd(name string, arg ...string) *exec.Cmd {
 	}
 	return cmd
 }
+
+// detachedPluginCmd returns the command of a plugin that outlives the host.
+// The plugin is not killed with the host and is moved to its own process
+// group so that signals sent to the group of the host do not reach it.
+func detachedPluginCmd(name string, arg ...string) *exec.Cmd {
+	cmd := exec.Command(name, arg...)
+
+	cmd.SysProcAttr = &unix.SysProcAttr{
+		Setpgid: true,
+	}
+	return cmd
+}
@@ -9,3 +9,7 @@ import (
 func pluginCmd(name string, arg ...string) *exec.Cmd {
 	return exec.Command(name, arg...)
 }
+
+func detachedPluginCmd(name string, arg ...string) *exec.Cmd {
+	return exec.Command(name, arg...)
+}
@@ -0,0 +1,124 @@
+package catalog
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/openkcm/plugin-sdk/api"
+)
+
+// CompatibilityReport describes how the loaded plugins are bound to the
+// facades of the host.
+type CompatibilityReport struct {
+	Plugins []PluginCompatibility
+}
+
+// PluginCompatibility describes how a plugin is bound to the facades of the
+// host.
+type PluginCompatibility struct {
+	// Name of the plugin
+	Name string
+
+	// Type of the plugin
+	Type string
+
+	// Bindings are the facades bound to the plugin, the plugin facade first.
+	Bindings []FacadeBinding
+
+	// UnboundServices are the fully qualified gRPC service names advertised
+	// by the plugin that no facade is bound to.
+	UnboundServices []string
+}
+
+// FacadeBinding describes a facade bound to a plugin.
+type FacadeBinding struct {
+	// Service is the fully qualified gRPC service name of the facade.
+	Service string
+
+	// Version is the version of the facade.
+	Version uint
+
+	// Deprecated reports whether the facade version is deprecated.
+	Deprecated bool
+
+	// ReplacementService and ReplacementVersion identify the facade version
+	// recommended instead of a deprecated one, if any.
+	ReplacementService string
+	ReplacementVersion uint
+}
+
+func newFacadeBinding(bound, latest api.Version) FacadeBinding {
+	facade := bound.New()
+	binding := FacadeBinding{
+		Service:    facade.GRPCServiceName(),
+		Version:    facade.Version(),
+		Deprecated: bound.Deprecated(),
+	}
+	if binding.Deprecated && !latest.Deprecated() {
+		replacement := latest.New()
+		binding.ReplacementService = replacement.GRPCServiceName()
+		binding.ReplacementVersion = replacement.Version()
+	}
+	return binding
+}
+
+// Problems describes the deprecated bindings and the unbound services of the
+// plugins, if any.
+func (r CompatibilityReport) Problems() []string {
+	var problems []string
+	for _, plugin := range r.Plugins {
+		for _, binding := range plugin.Bindings {
+			if !binding.Deprecated {
+				continue
+			}
+			problem := fmt.Sprintf("plugin %q is bound to deprecated service %s version %d", plugin.Name, binding.Service, binding.Version)
+			if binding.ReplacementService != "" {
+				problem += fmt.Sprintf(", use %s version %d instead", binding.ReplacementService, binding.ReplacementVersion)
+			}
+			problems = append(problems, problem)
+		}
+		for _, service := range plugin.UnboundServices {
+			problems = append(problems, fmt.Sprintf("plugin %q advertises unsupported service %s", plugin.Name, service))
+		}
+	}
+	return problems
+}
+
+// clone returns a deep copy of the report.
+func (r CompatibilityReport) clone() CompatibilityReport {
+	plugins := slices.Clone(r.Plugins)
+	for i := range plugins {
+		plugins[i].Bindings = slices.Clone(plugins[i].Bindings)
+		plugins[i].UnboundServices = slices.Clone(plugins[i].UnboundServices)
+	}
+	return CompatibilityReport{Plugins: plugins}
+}
+
+// CompatibilityError is returned by New when a plugin cannot be bound to the
+// facades of the host, or in strict compatibility mode when the
+// compatibility report has problems. The report covers the plugins loaded
+// so far, including the one that could not be bound.
+type CompatibilityError struct {
+	Report CompatibilityReport
+
+	// Err is the error binding a plugin failed with, if any.
+	Err error
+}
+
+func (e *CompatibilityError) Error() string {
+	if e.Err != nil {
+		return e.Err.Error()
+	}
+	return "plugins are not fully compatible: " + strings.Join(e.Report.Problems(), "; ")
+}
+
+func (e *CompatibilityError) Unwrap() error {
+	return e.Err
+}
+
+// CompatibilityReport returns how the loaded plugins are bound to the facades
+// of the host.
+func (c *Catalog) CompatibilityReport() CompatibilityReport {
+	return c.report.clone()
+}
@@ -0,0 +1,128 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/openkcm/plugin-sdk/api"
+	testv1 "github.com/openkcm/plugin-sdk/proto/plugin/test/v1"
+)
+
+// compatTestVersion is a facade version of the test plugin which can be
+// renamed and deprecated.
+type compatTestVersion struct {
+	version    uint
+	service    string
+	deprecated bool
+}
+
+func (v compatTestVersion) New() api.Facade {
+	return &compatTestFacade{testFacade: &testFacade{version: v.version}, service: v.service}
+}
+func (v compatTestVersion) Deprecated() bool { return v.deprecated }
+
+type compatTestFacade struct {
+	*testFacade
+
+	service string
+}
+
+func (f *compatTestFacade) GRPCServiceName() string {
+	if f.service != "" {
+		return f.service
+	}
+	return f.testFacade.GRPCServiceName()
+}
+
+type compatTestRepo struct {
+	versions []api.Version
+}
+
+func (r *compatTestRepo) Binder() any                  { return func(api.Facade) {} }
+func (r *compatTestRepo) Versions() []api.Version      { return r.versions }
+func (r *compatTestRepo) Constraints() api.Constraints { return api.ZeroOrMore() }
+func (r *compatTestRepo) Clear()                       {}
+
+func newCompatTestCatalog(strict bool) (*Catalog, error) {
+	repo := &compatTestRepo{versions: []api.Version{
+		compatTestVersion{version: 2, service: "plugin.test.v2.TestService"},
+		compatTestVersion{version: 1, deprecated: true},
+	}}
+	return New(context.Background(), Config{
+		Logger:              discardLogger(),
+		PluginConfigs:       []PluginConfig{{Name: "compat", Type: testv1.Type}},
+		StrictCompatibility: strict,
+	}, testRepository{plugins: map[string]api.PluginRepo{testv1.Type: repo}},
+		MakeBuiltIn("compat", testv1.TestServicePluginServer(&blockingTestPlugin{}), &fakePluginServiceServer{name: "plugin.extra.v1.Extra"}))
+}
+
+func TestCompatibilityReport(t *testing.T) {
+	t.Parallel()
+
+	cat, err := newCompatTestCatalog(false)
+	if err != nil {
+		t.Fatalf("New(): %v", err)
+	}
+	defer cat.Close()
+
+	report := cat.CompatibilityReport()
+	if len(report.Plugins) != 1 {
+		t.Fatalf("expected one plugin, got %+v", report.Plugins)
+	}
+	plugin := report.Plugins[0]
+	want := FacadeBinding{
+		Service:            testv1.GRPCServiceFullName,
+		Version:            1,
+		Deprecated:         true,
+		ReplacementService: "plugin.test.v2.TestService",
+		ReplacementVersion: 2,
+	}
+	if plugin.Name != "compat" || len(plugin.Bindings) != 1 || plugin.Bindings[0] != want {
+		t.Fatalf("unexpected bindings %+v", plugin)
+	}
+	if !slices.Equal(plugin.UnboundServices, []string{"plugin.extra.v1.Extra"}) {
+		t.Fatalf("unexpected unbound services %q", plugin.UnboundServices)
+	}
+	if problems := report.Problems(); len(problems) != 2 {
+		t.Fatalf("expected two problems, got %q", problems)
+	}
+
+	report.Plugins[0].Bindings[0].Version = 42
+	report.Plugins[0].UnboundServices[0] = "changed"
+	if again := cat.CompatibilityReport(); again.Plugins[0].Bindings[0].Version != 1 || again.Plugins[0].UnboundServices[0] != "plugin.extra.v1.Extra" {
+		t.Fatalf("expected the report of the catalog to be unchanged, got %+v", again.Plugins[0])
+	}
+}
+
+func TestCompatibilityErrorOnBindFailure(t *testing.T) {
+	t.Parallel()
+
+	repo := &compatTestRepo{versions: []api.Version{
+		compatTestVersion{version: 3, service: "plugin.test.v3.TestService"},
+	}}
+	_, err := New(context.Background(), Config{
+		Logger:        discardLogger(),
+		PluginConfigs: []PluginConfig{{Name: "compat", Type: testv1.Type, Version: 3}},
+	}, testRepository{plugins: map[string]api.PluginRepo{testv1.Type: repo}},
+		MakeBuiltIn("compat", testv1.TestServicePluginServer(&blockingTestPlugin{})))
+
+	var compatErr *CompatibilityError
+	if !errors.As(err, &compatErr) || compatErr.Err == nil {
+		t.Fatalf("expected compatibility error, got %v", err)
+	}
+	if len(compatErr.Report.Plugins) != 1 || compatErr.Report.Plugins[0].Name != "compat" {
+		t.Fatalf("expected the report of the unbound plugin, got %+v", compatErr.Report)
+	}
+}
+
+func TestStrictCompatibility(t *testing.T) {
+	t.Parallel()
+
+	_, err := newCompatTestCatalog(true)
+	var compatErr *CompatibilityError
+	if !errors.As(err, &compatErr) || len(compatErr.Report.Plugins) != 1 {
+		t.Fatalf("expected compatibility error, got %v", err)
+	}
+}
@@ -0,0 +1,306 @@
+package catalog
+
+import (
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/openkcm/plugin-sdk/api"
+)
+
+const compositeVirtualNodes = 100
+
+// BalancePolicy determines which plugin of a Composite serves a call.
+type BalancePolicy int
+
+const (
+	// RoundRobin spreads calls evenly across the plugins.
+	RoundRobin BalancePolicy = iota
+	// PriorityFailover sends calls to the first plugin, in the order given,
+	// and fails over to the next ones when it is unavailable, for idempotent
+	// methods only.
+	PriorityFailover
+	// ConsistentHash sends calls with the same request key to the same
+	// plugin. It requires a request key function (see WithRequestKey).
+	ConsistentHash
+)
+
+func (p BalancePolicy) String() string {
+	switch p {
+	case RoundRobin:
+		return "round-robin"
+	case PriorityFailover:
+		return "priority-failover"
+	case ConsistentHash:
+		return "consistent-hash"
+	default:
+		return "unknown"
+	}
+}
+
+// RequestKeyFunc returns the key used to select a plugin for the given
+// request when consistent hashing is used. For streams, req is nil.
+type RequestKeyFunc func(method string, req any) string
+
+type CompositeOption func(*Composite)
+
+// WithBalancePolicy sets the balance policy. Defaults to RoundRobin.
+func WithBalancePolicy(policy BalancePolicy) CompositeOption {
+	return func(c *Composite) {
+		c.policy = policy
+	}
+}
+
+// WithIdempotent marks the given method, by its full gRPC method name, as
+// safe to fail over or not, overriding the idempotency_level option of the
+// method definition.
+func WithIdempotent(method string, idempotent bool) CompositeOption {
+	return func(c *Composite) {
+		if c.idempotent == nil {
+			c.idempotent = make(map[string]bool)
+		}
+		c.idempotent[method] = idempotent
+	}
+}
+
+// WithRequestKey sets the function extracting the key from the requests
+// when the ConsistentHash policy is used.
+func WithRequestKey(fn RequestKeyFunc) CompositeOption {
+	return func(c *Composite) {
+		c.requestKey = fn
+	}
+}
+
+// ServedBy returns a call option that reports the information of the plugin
+// that served a call made through a Composite.
+func ServedBy(info *api.Info) grpc.CallOption {
+	return servedByCallOption{info: info}
+}
+
+type servedByCallOption struct {
+	grpc.EmptyCallOption
+
+	info *api.Info
+}
+
+// Composite spreads calls across several plugins of the same type. Plugins
+// whose circuit breaker is open are skipped. Calls of idempotent methods
+// failing with Unavailable are retried on the next plugin, as the plugin may
+// have run the call before failing; other calls return the error (see
+// WithIdempotent). It implements
+// grpc.ClientConnInterface, so any facade can be bound to it (see
+// InitFacade).
+type Composite struct {
+	plugins    []Plugin
+	info       api.Info
+	log        *slog.Logger
+	policy     BalancePolicy
+	requestKey RequestKeyFunc
+	idempotent map[string]bool
+	ring       []ringNode
+	next       atomic.Uint64
+}
+
+var _ grpc.ClientConnInterface = (*Composite)(nil)
+
+type ringNode struct {
+	hash   uint64
+	plugin int
+}
+
+type circuitStater interface {
+	CircuitState() CircuitState
+}
+
+// NewComposite returns a composite of the given plugins, which must all be of
+// the same type.
+func NewComposite(plugins []Plugin, opts ...CompositeOption) (*Composite, error) {
+	if len(plugins) == 0 {
+		return nil, errors.New("composite requires at least one plugin")
+	}
+
+	names := make([]string, 0, len(plugins))
+	pluginType := plugins[0].Info().Type()
+	for _, plugin := range plugins {
+		if plugin.Info().Type() != pluginType {
+			return nil, fmt.Errorf("composite plugins must be of the same type: %q is of type %q, expected %q",
+				plugin.Info().Name(), plugin.Info().Type(), pluginType)
+		}
+		names = append(names, plugin.Info().Name())
+	}
+
+	c := &Composite{
+		plugins: slices.Clone(plugins),
+		info: &pluginInfo{
+			name:    strings.Join(names, ","),
+			typ:     pluginType,
+			version: plugins[0].Info().Version(),
+		},
+		log: plugins[0].Logger().With(Name, strings.Join(names, ",")),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.policy == ConsistentHash {
+		if c.requestKey == nil {
+			return nil, errors.New("consistent hash policy requires a request key function")
+		}
+		c.ring = buildHashRing(names)
+	}
+	return c, nil
+}
+
+// CompositeByType returns a composite of all loaded plugins of the given type.
+func (c *Catalog) CompositeByType(pluginType string, opts ...CompositeOption) (*Composite, error) {
+	plugins := c.LookupByType(pluginType)
+	if len(plugins) == 0 {
+		return nil, fmt.Errorf("no plugin of type %q loaded", pluginType)
+	}
+	return NewComposite(plugins, opts...)
+}
+
+// Plugins returns the plugins of the composite.
+func (c *Composite) Plugins() []Plugin {
+	return slices.Clone(c.plugins)
+}
+
+// Info returns the information of the composite. Its name is made of the
+// names of all plugins.
+func (c *Composite) Info() api.Info {
+	return c.info
+}
+
+// InitFacade initializes the given facade to make calls through the
+// composite and returns the initialized client.
+func (c *Composite) InitFacade(facade api.Facade) any {
+	facade.InitInfo(c.info)
+	facade.InitLog(c.log)
+	return facade.InitClient(c)
+}
+
+func (c *Composite) Invoke(ctx context.Context, method string, args, reply any, opts ...grpc.CallOption) error {
+	var err error
+	for _, plugin := range c.candidates(method, args) {
+		err = plugin.ClientConnection().Invoke(ctx, method, args, reply, opts...)
+		if status.Code(err) != codes.Unavailable || ctx.Err() != nil || !c.failsOver(method) {
+			c.served(plugin, method, opts)
+			return err
+		}
+		plugin.Logger().Debug("Plugin unavailable; failing over", "method", method, "error", err)
+	}
+	if err == nil {
+		err = status.Errorf(codes.Unavailable, "no healthy %s plugin available", c.info.Type())
+	}
+	return err
+}
+
+func (c *Composite) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	var err error
+	for _, plugin := range c.candidates(method, nil) {
+		var stream grpc.ClientStream
+		stream, err = plugin.ClientConnection().NewStream(ctx, desc, method, opts...)
+		if status.Code(err) != codes.Unavailable || ctx.Err() != nil || !c.failsOver(method) {
+			c.served(plugin, method, opts)
+			return stream, err
+		}
+		plugin.Logger().Debug("Plugin unavailable; failing over", "method", method, "error", err)
+	}
+	if err == nil {
+		err = status.Errorf(codes.Unavailable, "no healthy %s plugin available", c.info.Type())
+	}
+	return nil, err
+}
+
+// failsOver reports whether the calls of the method are retried on the next
+// plugin when unavailable, which is only safe for idempotent methods.
+func (c *Composite) failsOver(method string) bool {
+	if idempotent, ok := c.idempotent[method]; ok {
+		return idempotent
+	}
+	return isIdempotentMethod(method)
+}
+
+// candidates returns the healthy plugins in the order they should be tried
+// for the given call.
+func (c *Composite) candidates(method string, req any) []Plugin {
+	var ordered []Plugin
+	switch c.policy {
+	case ConsistentHash:
+		ordered = c.ringOrder(c.requestKey(method, req))
+	case PriorityFailover:
+		ordered = c.plugins
+	default:
+		start := int(c.next.Add(1)-1) % len(c.plugins)
+		ordered = append(slices.Clone(c.plugins[start:]), c.plugins[:start]...)
+	}
+
+	healthy := make([]Plugin, 0, len(ordered))
+	for _, plugin := range ordered {
+		if stater, ok := plugin.(circuitStater); ok && stater.CircuitState() == CircuitOpen {
+			continue
+		}
+		healthy = append(healthy, plugin)
+	}
+	return healthy
+}
+
+// ringOrder returns the plugins in the order they appear on the hash ring,
+// starting at the position of the given key.
+func (c *Composite) ringOrder(key string) []Plugin {
+	h := hashKey(key)
+	start, _ := slices.BinarySearchFunc(c.ring, h, func(n ringNode, h uint64) int {
+		return cmp.Compare(n.hash, h)
+	})
+
+	seen := make(map[int]struct{}, len(c.plugins))
+	ordered := make([]Plugin, 0, len(c.plugins))
+	for i := range c.ring {
+		node := c.ring[(start+i)%len(c.ring)]
+		if _, ok := seen[node.plugin]; ok {
+			continue
+		}
+		seen[node.plugin] = struct{}{}
+		ordered = append(ordered, c.plugins[node.plugin])
+	}
+	return ordered
+}
+
+func (c *Composite) served(plugin Plugin, method string, opts []grpc.CallOption) {
+	plugin.Logger().Debug("Call served by plugin", "method", method)
+	for _, opt := range opts {
+		if servedBy, ok := opt.(servedByCallOption); ok && servedBy.info != nil {
+			*servedBy.info = plugin.Info()
+		}
+	}
+}
+
+func buildHashRing(names []string) []ringNode {
+	ring := make([]ringNode, 0, len(names)*compositeVirtualNodes)
+	for i, name := range names {
+		for v := range compositeVirtualNodes {
+			ring = append(ring, ringNode{hash: hashKey(name + "#" + strconv.Itoa(v)), plugin: i})
+		}
+	}
+	slices.SortFunc(ring, func(a, b ringNode) int {
+		return cmp.Compare(a.hash, b.hash)
+	})
+	return ring
+}
+
+func hashKey(key string) uint64 {
+	sum := sha256.Sum256([]byte(key))
+	return binary.BigEndian.Uint64(sum[:8])
+}
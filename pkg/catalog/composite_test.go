@@ -0,0 +1,196 @@
+package catalog
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/openkcm/plugin-sdk/api"
+)
+
+type fakeConn struct {
+	grpc.ClientConnInterface
+
+	calls int
+	err   error
+}
+
+func (c *fakeConn) Invoke(context.Context, string, any, any, ...grpc.CallOption) error {
+	c.calls++
+	return c.err
+}
+
+type fakeBalancedPlugin struct {
+	pluginImpl
+}
+
+func newFakeBalancedPlugin(name string, err error) *fakeBalancedPlugin {
+	return &fakeBalancedPlugin{pluginImpl: pluginImpl{
+		conn:   &fakeConn{err: err},
+		info:   &pluginInfo{name: name, typ: "fake"},
+		logger: discardLogger(),
+	}}
+}
+
+func (p *fakeBalancedPlugin) calls() int {
+	return p.conn.(*fakeConn).calls
+}
+
+func TestNewComposite(t *testing.T) {
+	t.Parallel()
+
+	if _, err := NewComposite(nil); err == nil {
+		t.Fatal("expected error for empty composite")
+	}
+
+	other := newFakeBalancedPlugin("other", nil)
+	other.info = &pluginInfo{name: "other", typ: "other"}
+	if _, err := NewComposite([]Plugin{newFakeBalancedPlugin("a", nil), other}); err == nil {
+		t.Fatal("expected error for mixed plugin types")
+	}
+
+	if _, err := NewComposite([]Plugin{newFakeBalancedPlugin("a", nil)}, WithBalancePolicy(ConsistentHash)); err == nil {
+		t.Fatal("expected error for consistent hash without request key")
+	}
+
+	c, err := NewComposite([]Plugin{newFakeBalancedPlugin("a", nil), newFakeBalancedPlugin("b", nil)})
+	if err != nil {
+		t.Fatalf("NewComposite(): %v", err)
+	}
+	if got := c.Info().Name(); got != "a,b" {
+		t.Fatalf("expected composite name %q, got %q", "a,b", got)
+	}
+}
+
+func TestCompositeRoundRobin(t *testing.T) {
+	t.Parallel()
+
+	a, b := newFakeBalancedPlugin("a", nil), newFakeBalancedPlugin("b", nil)
+	c, err := NewComposite([]Plugin{a, b})
+	if err != nil {
+		t.Fatalf("NewComposite(): %v", err)
+	}
+
+	var served []string
+	for range 4 {
+		var info api.Info
+		if err := c.Invoke(context.Background(), "/svc/Method", nil, nil, ServedBy(&info)); err != nil {
+			t.Fatalf("Invoke(): %v", err)
+		}
+		served = append(served, info.Name())
+	}
+
+	if a.calls() != 2 || b.calls() != 2 {
+		t.Fatalf("expected calls to be spread evenly, got a=%d b=%d", a.calls(), b.calls())
+	}
+	if served[0] == served[1] {
+		t.Fatalf("expected consecutive calls to be served by different plugins, got %v", served)
+	}
+}
+
+func TestCompositePriorityFailover(t *testing.T) {
+	t.Parallel()
+
+	primary := newFakeBalancedPlugin("primary", status.Error(codes.Unavailable, "down"))
+	secondary := newFakeBalancedPlugin("secondary", nil)
+	c, err := NewComposite([]Plugin{primary, secondary},
+		WithBalancePolicy(PriorityFailover),
+		WithIdempotent("/svc/Method", true),
+	)
+	if err != nil {
+		t.Fatalf("NewComposite(): %v", err)
+	}
+
+	var info api.Info
+	if err := c.Invoke(context.Background(), "/svc/Method", nil, nil, ServedBy(&info)); err != nil {
+		t.Fatalf("Invoke(): %v", err)
+	}
+	if info.Name() != "secondary" {
+		t.Fatalf("expected call to fail over to secondary, got %q", info.Name())
+	}
+
+	t.Run("non-idempotent calls are not failed over", func(t *testing.T) {
+		err := c.Invoke(context.Background(), "/svc/Create", nil, nil, ServedBy(&info))
+		if status.Code(err) != codes.Unavailable || info.Name() != "primary" {
+			t.Fatalf("expected Unavailable from primary, got %v from %q", err, info.Name())
+		}
+	})
+
+	t.Run("business errors are not failed over", func(t *testing.T) {
+		primary.conn.(*fakeConn).err = status.Error(codes.NotFound, "missing")
+		err := c.Invoke(context.Background(), "/svc/Method", nil, nil, ServedBy(&info))
+		if status.Code(err) != codes.NotFound || info.Name() != "primary" {
+			t.Fatalf("expected NotFound from primary, got %v from %q", err, info.Name())
+		}
+	})
+}
+
+func TestCompositeSkipsOpenCircuit(t *testing.T) {
+	t.Parallel()
+
+	broken := newFakeBalancedPlugin("broken", nil)
+	broken.breaker = newCircuitBreaker(CircuitBreakerPolicy{FailureThreshold: 1}, discardLogger())
+	done, _ := broken.breaker.allow()
+	done(status.Error(codes.Unavailable, "down"))
+
+	healthy := newFakeBalancedPlugin("healthy", nil)
+	c, err := NewComposite([]Plugin{broken, healthy}, WithBalancePolicy(PriorityFailover))
+	if err != nil {
+		t.Fatalf("NewComposite(): %v", err)
+	}
+
+	if err := c.Invoke(context.Background(), "/svc/Method", nil, nil); err != nil {
+		t.Fatalf("Invoke(): %v", err)
+	}
+	if broken.calls() != 0 || healthy.calls() != 1 {
+		t.Fatalf("expected plugin with open circuit to be skipped, got broken=%d healthy=%d", broken.calls(), healthy.calls())
+	}
+
+	only, err := NewComposite([]Plugin{broken})
+	if err != nil {
+		t.Fatalf("NewComposite(): %v", err)
+	}
+	if err := only.Invoke(context.Background(), "/svc/Method", nil, nil); status.Code(err) != codes.Unavailable {
+		t.Fatalf("expected Unavailable without healthy plugins, got %v", err)
+	}
+}
+
+func TestCompositeConsistentHash(t *testing.T) {
+	t.Parallel()
+
+	plugins := []Plugin{
+		newFakeBalancedPlugin("a", nil),
+		newFakeBalancedPlugin("b", nil),
+		newFakeBalancedPlugin("c", nil),
+	}
+	c, err := NewComposite(plugins,
+		WithBalancePolicy(ConsistentHash),
+		WithRequestKey(func(_ string, req any) string { return req.(string) }),
+	)
+	if err != nil {
+		t.Fatalf("NewComposite(): %v", err)
+	}
+
+	servedBy := func(key string) string {
+		var info api.Info
+		if err := c.Invoke(context.Background(), "/svc/Method", key, nil, ServedBy(&info)); err != nil {
+			t.Fatalf("Invoke(): %v", err)
+		}
+		return info.Name()
+	}
+
+	distinct := make(map[string]struct{})
+	for _, key := range []string{"key-1", "key-2", "key-3", "key-4", "key-5", "key-6"} {
+		first := servedBy(key)
+		if again := servedBy(key); again != first {
+			t.Fatalf("expected key %q to be served by %q, got %q", key, first, again)
+		}
+		distinct[first] = struct{}{}
+	}
+	if len(distinct) < 2 {
+		t.Fatalf("expected keys to be spread across plugins, got %v", distinct)
+	}
+}
@@ -0,0 +1,178 @@
+package catalog
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+)
+
+// ManifestSuffix is the suffix of the manifest files looked up by Discover.
+const ManifestSuffix = ".manifest.json"
+
+// Manifest describes a plugin binary dropped in a plugin directory.
+type Manifest struct {
+	// Name of the plugin.
+	Name string `json:"name"`
+
+	// Type is the plugin type.
+	Type string `json:"type"`
+
+	// Version is the version of the plugin type implemented by the plugin.
+	Version uint32 `json:"version,omitempty"`
+
+	// Binary is the path of the plugin binary, relative to the directory.
+	// It defaults to the name of the manifest file without ManifestSuffix.
+	Binary string `json:"binary,omitempty"`
+
+	// Checksum is the hex-encoded SHA256 hash of the plugin binary.
+	Checksum string `json:"checksum"`
+
+	// Services are the fully qualified gRPC service names implemented by
+	// the plugin.
+	Services []string `json:"services,omitempty"`
+
+	// Config is the default YAML configuration of the plugin.
+	Config string `json:"config,omitempty"`
+
+	// Tags are the metadata associated with the plugin.
+	Tags []string `json:"tags,omitempty"`
+}
+
+// Discover scans dir for plugin manifests, i.e. files named after a plugin
+// binary with the ManifestSuffix, and returns the configurations of the
+// plugins they describe, sorted by name. Every manifest is validated and the
+// binary it refers to is verified against its checksum; all the problems
+// found are reported together.
+func Discover(dir string) (PluginConfigs, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var configs PluginConfigs
+	var errs []error
+	names := make(map[string]string)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ManifestSuffix) {
+			continue
+		}
+
+		config, err := loadManifest(dir, entry.Name())
+		if err != nil {
+			errs = append(errs, fmt.Errorf("manifest %q: %w", entry.Name(), err))
+			continue
+		}
+		if other, ok := names[config.Name]; ok {
+			errs = append(errs, fmt.Errorf("manifest %q: plugin name %q already used by manifest %q", entry.Name(), config.Name, other))
+			continue
+		}
+		names[config.Name] = entry.Name()
+		configs = append(configs, config)
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	slices.SortFunc(configs, func(a, b PluginConfig) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return configs, nil
+}
+
+func loadManifest(dir, file string) (PluginConfig, error) {
+	f, err := os.Open(filepath.Join(dir, file))
+	if err != nil {
+		return PluginConfig{}, err
+	}
+	defer f.Close()
+
+	var manifest Manifest
+	decoder := json.NewDecoder(f)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&manifest); err != nil {
+		return PluginConfig{}, fmt.Errorf("invalid manifest: %w", err)
+	}
+	if manifest.Binary == "" {
+		manifest.Binary = strings.TrimSuffix(file, ManifestSuffix)
+	}
+
+	if err := manifest.validate(); err != nil {
+		return PluginConfig{}, err
+	}
+
+	path := filepath.Join(dir, manifest.Binary)
+	if err := verifyBinary(path, manifest.Checksum); err != nil {
+		return PluginConfig{}, err
+	}
+
+	return PluginConfig{
+		Name:              manifest.Name,
+		Type:              manifest.Type,
+		Path:              path,
+		Checksum:          strings.ToLower(manifest.Checksum),
+		Version:           manifest.Version,
+		YamlConfiguration: manifest.Config,
+		Tags:              manifest.Tags,
+		ServiceNames:      manifest.Services,
+	}, nil
+}
+
+func (m *Manifest) validate() error {
+	var errs []error
+	if m.Name == "" {
+		errs = append(errs, errors.New("missing name"))
+	}
+	if m.Type == "" {
+		errs = append(errs, errors.New("missing type"))
+	}
+	if !filepath.IsLocal(m.Binary) {
+		errs = append(errs, fmt.Errorf("binary %q is not within the plugin directory", m.Binary))
+	}
+	if _, err := buildSecureConfig(m.Checksum); err != nil || m.Checksum == "" {
+		errs = append(errs, errors.New("missing or invalid checksum"))
+	}
+	for _, service := range m.Services {
+		if !strings.Contains(service, ".") || strings.ContainsAny(service, " /") {
+			errs = append(errs, fmt.Errorf("service %q is not a fully qualified gRPC service name", service))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// verifyBinary checks that the binary is an executable regular file matching
+// the checksum.
+func verifyBinary(path, checksum string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if !info.Mode().IsRegular() {
+		return fmt.Errorf("binary %q is not a regular file", path)
+	}
+	if runtime.GOOS != "windows" && info.Mode().Perm()&0o111 == 0 {
+		return fmt.Errorf("binary %q is not executable", path)
+	}
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return err
+	}
+	if actual := hex.EncodeToString(hash.Sum(nil)); !strings.EqualFold(actual, checksum) {
+		return fmt.Errorf("checksum of binary %q does not match: got %s", path, actual)
+	}
+	return nil
+}
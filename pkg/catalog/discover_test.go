@@ -0,0 +1,106 @@
+package catalog
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeTestPlugin(t *testing.T, dir, binary string, manifest Manifest) {
+	t.Helper()
+
+	content := []byte("#!/bin/sh\n# " + binary + "\n")
+	if err := os.WriteFile(filepath.Join(dir, binary), content, 0o755); err != nil {
+		t.Fatalf("WriteFile(): %v", err)
+	}
+	if manifest.Checksum == "" {
+		sum := sha256.Sum256(content)
+		manifest.Checksum = hex.EncodeToString(sum[:])
+	}
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		t.Fatalf("Marshal(): %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, binary+ManifestSuffix), data, 0o644); err != nil {
+		t.Fatalf("WriteFile(): %v", err)
+	}
+}
+
+func TestDiscover(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeTestPlugin(t, dir, "keystore", Manifest{
+		Name:     "keystore",
+		Type:     "KeystoreProvider",
+		Version:  2,
+		Services: []string{"plugin.keystore.v1.KeystoreProvider"},
+		Config:   "region: eu",
+		Tags:     []string{"hsm"},
+	})
+	writeTestPlugin(t, dir, "audit", Manifest{Name: "audit", Type: "Notification"})
+	// Files without manifests are ignored.
+	if err := os.WriteFile(filepath.Join(dir, "README"), nil, 0o644); err != nil {
+		t.Fatalf("WriteFile(): %v", err)
+	}
+
+	configs, err := Discover(dir)
+	if err != nil {
+		t.Fatalf("Discover(): %v", err)
+	}
+	if len(configs) != 2 || configs[0].Name != "audit" || configs[1].Name != "keystore" {
+		t.Fatalf("unexpected plugins %+v", configs)
+	}
+
+	keystore := configs[1]
+	if keystore.Path != filepath.Join(dir, "keystore") || keystore.Type != "KeystoreProvider" ||
+		keystore.Version != 2 || keystore.YamlConfiguration != "region: eu" ||
+		len(keystore.ServiceNames) != 1 || len(keystore.Tags) != 1 || keystore.Checksum == "" {
+		t.Fatalf("unexpected plugin config %+v", keystore)
+	}
+}
+
+func TestDiscoverInvalidManifests(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		manifest Manifest
+		wantErr  string
+	}{
+		{name: "missing name", manifest: Manifest{Type: "T"}, wantErr: "missing name"},
+		{name: "missing type", manifest: Manifest{Name: "p"}, wantErr: "missing type"},
+		{name: "checksum mismatch", manifest: Manifest{Name: "p", Type: "T", Checksum: strings.Repeat("0", 64)}, wantErr: "does not match"},
+		{name: "invalid checksum", manifest: Manifest{Name: "p", Type: "T", Checksum: "abc"}, wantErr: "invalid checksum"},
+		{name: "binary outside directory", manifest: Manifest{Name: "p", Type: "T", Binary: "../p"}, wantErr: "not within"},
+		{name: "invalid service", manifest: Manifest{Name: "p", Type: "T", Services: []string{"Keystore"}}, wantErr: "fully qualified"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			writeTestPlugin(t, dir, "plugin", tt.manifest)
+			_, err := Discover(dir)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestDiscoverDuplicateNames(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeTestPlugin(t, dir, "a", Manifest{Name: "plugin", Type: "T"})
+	writeTestPlugin(t, dir, "b", Manifest{Name: "plugin", Type: "T"})
+
+	if _, err := Discover(dir); err == nil || !strings.Contains(err.Error(), "already used") {
+		t.Fatalf("expected duplicate name error, got %v", err)
+	}
+}
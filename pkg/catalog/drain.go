@@ -0,0 +1,160 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var errPluginShuttingDown = status.Error(codes.Unavailable, "plugin is shutting down")
+
+// DrainError is returned by Catalog.Shutdown when calls to some plugins were
+// still in flight when the context was done. Those plugins are unloaded
+// regardless.
+type DrainError struct {
+	// Plugins are the names of the plugins that did not drain.
+	Plugins []string
+
+	// Err is the reason the wait was stopped, i.e. the context error.
+	Err error
+}
+
+func (e *DrainError) Error() string {
+	return "plugins did not drain: " + strings.Join(e.Plugins, ", ") + ": " + e.Err.Error()
+}
+
+func (e *DrainError) Unwrap() error {
+	return e.Err
+}
+
+// Shutdown gracefully unloads all plugins. New calls made through the facades
+// are rejected with Unavailable, calls in flight are waited for and the
+// plugins are then deinitialized, all within the deadline of ctx. If calls to
+// some plugins were still in flight when ctx was done, a *DrainError naming
+// them is returned.
+func (c *Catalog) Shutdown(ctx context.Context) error {
+	plugins := c.pluginImpls()
+	for _, p := range plugins {
+		p.tracker.drain()
+	}
+
+	var mu sync.Mutex
+	var undrained []string
+	var wg sync.WaitGroup
+	for _, p := range plugins {
+		wg.Go(func() {
+			if err := p.tracker.wait(ctx); err != nil {
+				p.logger.WarnContext(ctx, "Plugin did not drain", "in_flight", p.tracker.inFlight(), "error", err)
+				mu.Lock()
+				undrained = append(undrained, p.info.Name())
+				mu.Unlock()
+			}
+		})
+	}
+	wg.Wait()
+
+	var drainErr error
+	if len(undrained) > 0 {
+		drainErr = &DrainError{Plugins: undrained, Err: ctx.Err()}
+	}
+	return errors.Join(drainErr, c.closers.(closerGroup).shutdown(ctx))
+}
+
+func (c *Catalog) pluginImpls() []*pluginImpl {
+	var plugins []*pluginImpl
+	for _, closer := range c.closers.(closerGroup) {
+		if pluginClose, ok := closer.(pluginCloser); ok {
+			if p, ok := pluginClose.plugin.(*pluginImpl); ok && p.tracker != nil {
+				plugins = append(plugins, p)
+			}
+		}
+	}
+	return plugins
+}
+
+// callTracker tracks the calls in flight on a plugin connection so that they
+// can be drained before the plugin is unloaded.
+type callTracker struct {
+	mu       sync.Mutex
+	inflight int
+	draining bool
+	drained  chan struct{}
+}
+
+func newCallTracker() *callTracker {
+	return &callTracker{drained: make(chan struct{})}
+}
+
+func (t *callTracker) begin() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.draining {
+		return errPluginShuttingDown
+	}
+	t.inflight++
+	return nil
+}
+
+func (t *callTracker) end() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.inflight--
+	if t.draining && t.inflight == 0 {
+		close(t.drained)
+	}
+}
+
+// drain stops accepting new calls.
+func (t *callTracker) drain() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.draining {
+		return
+	}
+	t.draining = true
+	if t.inflight == 0 {
+		close(t.drained)
+	}
+}
+
+// wait blocks until all calls in flight are done or the context is done.
+func (t *callTracker) wait(ctx context.Context) error {
+	select {
+	case <-t.drained:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (t *callTracker) inFlight() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.inflight
+}
+
+func (t *callTracker) UnaryClientInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if err := t.begin(); err != nil {
+		return err
+	}
+	defer t.end()
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
+
+func (t *callTracker) StreamClientInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	if err := t.begin(); err != nil {
+		return nil, err
+	}
+	release := sync.OnceFunc(t.end)
+	stream, err := streamer(ctx, desc, cc, method, opts...)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	return newReleasingStream(ctx, desc, stream, release), nil
+}
@@ -0,0 +1,141 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/openkcm/plugin-sdk/api"
+	testv1 "github.com/openkcm/plugin-sdk/proto/plugin/test/v1"
+)
+
+type testRepository struct {
+	plugins map[string]api.PluginRepo
+}
+
+func (r testRepository) Plugins() map[string]api.PluginRepo { return r.plugins }
+func (r testRepository) Services() []api.ServiceRepo        { return nil }
+
+type blockingTestPlugin struct {
+	testv1.UnimplementedTestServiceServer
+
+	started chan struct{}
+	release chan struct{}
+}
+
+func (p *blockingTestPlugin) Test(context.Context, *testv1.TestRequest) (*testv1.TestResponse, error) {
+	close(p.started)
+	<-p.release
+	return &testv1.TestResponse{Response: "done"}, nil
+}
+
+func newBlockingCatalog(t *testing.T) (*Catalog, *testPluginRepo, *blockingTestPlugin) {
+	t.Helper()
+
+	server := &blockingTestPlugin{started: make(chan struct{}), release: make(chan struct{})}
+	repo := &testPluginRepo{versions: []api.Version{testFacadeVersion{version: 1}}}
+	cat, err := New(context.Background(), Config{
+		Logger:        discardLogger(),
+		PluginConfigs: []PluginConfig{{Name: "blocking", Type: testv1.Type}},
+	}, testRepository{plugins: map[string]api.PluginRepo{testv1.Type: repo}},
+		MakeBuiltIn("blocking", testv1.TestServicePluginServer(server)))
+	if err != nil {
+		t.Fatalf("New(): %v", err)
+	}
+	return cat, repo, server
+}
+
+func TestCallTracker(t *testing.T) {
+	t.Parallel()
+
+	tracker := newCallTracker()
+	if err := tracker.begin(); err != nil {
+		t.Fatalf("begin(): %v", err)
+	}
+
+	tracker.drain()
+	if err := tracker.begin(); status.Code(err) != codes.Unavailable {
+		t.Fatalf("expected new calls to be rejected while draining, got %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := tracker.wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected wait to time out, got %v", err)
+	}
+
+	tracker.end()
+	if err := tracker.wait(context.Background()); err != nil {
+		t.Fatalf("expected tracker to be drained, got %v", err)
+	}
+}
+
+func TestCatalogShutdownDrainsCalls(t *testing.T) {
+	t.Parallel()
+
+	cat, repo, server := newBlockingCatalog(t)
+
+	result := make(chan error, 1)
+	go func() {
+		_, err := repo.facades[0].Test(context.Background(), &testv1.TestRequest{})
+		result <- err
+	}()
+	<-server.started
+
+	shutdown := make(chan error, 1)
+	go func() {
+		shutdown <- cat.Shutdown(context.Background())
+	}()
+
+	// Wait for the catalog to stop accepting calls before releasing the
+	// call in flight.
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		_, err := repo.facades[0].Test(context.Background(), &testv1.TestRequest{})
+		if status.Code(err) == codes.Unavailable {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected new calls to be rejected, got %v", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	close(server.release)
+
+	if err := <-result; err != nil {
+		t.Fatalf("expected call in flight to complete, got %v", err)
+	}
+	if err := <-shutdown; err != nil {
+		t.Fatalf("Shutdown(): %v", err)
+	}
+}
+
+func TestCatalogShutdownReportsUndrainedPlugins(t *testing.T) {
+	t.Parallel()
+
+	cat, repo, server := newBlockingCatalog(t)
+
+	go func() {
+		_, _ = repo.facades[0].Test(context.Background(), &testv1.TestRequest{})
+	}()
+	<-server.started
+	time.AfterFunc(100*time.Millisecond, func() { close(server.release) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	var drainErr *DrainError
+	if err := cat.Shutdown(ctx); !errors.As(err, &drainErr) {
+		t.Fatalf("expected DrainError, got %v", err)
+	}
+	if len(drainErr.Plugins) != 1 || drainErr.Plugins[0] != "blocking" {
+		t.Fatalf("expected plugin %q not to drain, got %q", "blocking", drainErr.Plugins)
+	}
+	if !errors.Is(drainErr, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", drainErr.Err)
+	}
+}
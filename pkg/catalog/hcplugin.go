@@ -29,6 +29,16 @@ func (p *HCRPCPlugin) GRPCServer(broker *goplugin.GRPCBroker, s *grpc.Server) er
 }
 
 func (p *HCRPCPlugin) GRPCClient(ctx context.Context, b *goplugin.GRPCBroker, c *grpc.ClientConn) (any, error) {
+	// The go-plugin broker of a plugin does not survive the host it was
+	// launched by, so plugins that may be reattached to are served the host
+	// services over broker streams instead.
+	if p.config.brokersOverStreams() {
+		return &HCPlugin{
+			conn:    c,
+			closers: closerGroup{serveHostServicesOverStreams(c, p.config)},
+		}, nil
+	}
+
 	// Manually start up the server via b.Accept since b.AcceptAndServe does
 	// some logging we don't care for. Although b.AcceptAndServe is currently
 	// the only way to feed the TLS config to the brokered connection, AutoMTLS
@@ -38,7 +48,7 @@ func (p *HCRPCPlugin) GRPCClient(ctx context.Context, b *goplugin.GRPCBroker, c
 		return nil, errs.Wrap(err)
 	}
 
-	server := newHostServer(p.config.Logger, p.config.Name)
+	server := newHostServer(p.config.Logger, p.config.Name, p.config.HostServices)
 
 	var wg sync.WaitGroup
 	wg.Add(1)
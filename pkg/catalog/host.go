@@ -9,9 +9,13 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+
+	"github.com/openkcm/plugin-sdk/api"
 )
 
-func newHostServer(log *slog.Logger, pluginName string) *grpc.Server {
+// newHostServer returns the server providing the given host services to the
+// plugin of the given name.
+func newHostServer(log *slog.Logger, pluginName string, hostServices []api.ServiceServer) *grpc.Server {
 	s := grpc.NewServer(
 		grpc.ChainStreamInterceptor(
 			streamPanicInterceptor(log),
@@ -22,6 +26,9 @@ func newHostServer(log *slog.Logger, pluginName string) *grpc.Server {
 			unaryPluginInterceptor(pluginName),
 		),
 	)
+	for _, hostService := range hostServices {
+		hostService.RegisterServer(s)
+	}
 	return s
 }
 
@@ -8,7 +8,7 @@ import (
 
 func TestNewHostServer(t *testing.T) {
 	// Act
-	got := newHostServer(nil, "test")
+	got := newHostServer(nil, "test", nil)
 
 	// Assert
 	if got == nil {
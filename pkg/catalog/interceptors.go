@@ -0,0 +1,99 @@
+package catalog
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// interceptedConn applies client interceptors to the calls made on the
+// wrapped connection. It is used to decorate plugin connections that are not
+// dialed by the catalog itself (e.g. the connection handed out by go-plugin).
+type interceptedConn struct {
+	grpc.ClientConnInterface
+
+	unary  grpc.UnaryClientInterceptor
+	stream grpc.StreamClientInterceptor
+}
+
+// withClientInterceptors returns a connection that runs the given interceptors
+// in order for every call made on conn. If no interceptors are given, conn is
+// returned as is.
+func withClientInterceptors(conn grpc.ClientConnInterface, unary []grpc.UnaryClientInterceptor, stream []grpc.StreamClientInterceptor) grpc.ClientConnInterface {
+	if len(unary) == 0 && len(stream) == 0 {
+		return conn
+	}
+	return interceptedConn{
+		ClientConnInterface: conn,
+		unary:               chainUnaryClientInterceptors(unary),
+		stream:              chainStreamClientInterceptors(stream),
+	}
+}
+
+func (c interceptedConn) Invoke(ctx context.Context, method string, args, reply any, opts ...grpc.CallOption) error {
+	invoker := func(ctx context.Context, method string, args, reply any, _ *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.ClientConnInterface.Invoke(ctx, method, args, reply, opts...)
+	}
+	return c.unary(ctx, method, args, reply, nil, invoker, opts...)
+}
+
+func (c interceptedConn) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	streamer := func(ctx context.Context, desc *grpc.StreamDesc, _ *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return c.ClientConnInterface.NewStream(ctx, desc, method, opts...)
+	}
+	return c.stream(ctx, desc, nil, method, streamer, opts...)
+}
+
+func chainUnaryClientInterceptors(interceptors []grpc.UnaryClientInterceptor) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, next := interceptors[i], invoker
+			invoker = func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				return interceptor(ctx, method, req, reply, cc, next, opts...)
+			}
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+func chainStreamClientInterceptors(interceptors []grpc.StreamClientInterceptor) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, next := interceptors[i], streamer
+			streamer = func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+				return interceptor(ctx, desc, cc, method, next, opts...)
+			}
+		}
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+}
+
+// releasingStream calls release once the stream is finished, i.e. when
+// receiving a message fails (including io.EOF at the end of the stream), when
+// the response of a stream without server streaming is received, or when the
+// context of the stream ends. A stream abandoned before it is finished holds
+// its call until its context ends, so callers must cancel the context of the
+// streams they do not consume to the end.
+type releasingStream struct {
+	grpc.ClientStream
+
+	serverStreams bool
+	release       func()
+}
+
+// newReleasingStream returns the stream wrapped to call release once it is
+// finished. release must be safe to call more than once.
+func newReleasingStream(ctx context.Context, desc *grpc.StreamDesc, stream grpc.ClientStream, release func()) grpc.ClientStream {
+	context.AfterFunc(ctx, release)
+	return &releasingStream{ClientStream: stream, serverStreams: desc.ServerStreams, release: release}
+}
+
+func (s *releasingStream) RecvMsg(m any) error {
+	err := s.ClientStream.RecvMsg(m)
+	// Streams without server streaming end with their single response,
+	// typically received with CloseAndRecv after CloseSend.
+	if err != nil || !s.serverStreams {
+		s.release()
+	}
+	return err
+}
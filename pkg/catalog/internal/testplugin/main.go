@@ -4,6 +4,7 @@ package main
 import (
 	"context"
 
+	"github.com/openkcm/plugin-sdk/api"
 	"github.com/openkcm/plugin-sdk/pkg/plugin"
 	testv1 "github.com/openkcm/plugin-sdk/proto/plugin/test/v1"
 	configv1 "github.com/openkcm/plugin-sdk/proto/service/common/config/v1"
@@ -26,6 +27,13 @@ func (p *TestPlugin) Configure(ctx context.Context, req *configv1.ConfigureReque
 	}, nil
 }
 
+func (p *TestPlugin) Capabilities() api.Capabilities {
+	return api.Capabilities{
+		Version:  "1.2.3",
+		Metadata: map[string]string{"feature": "test"},
+	}
+}
+
 // main() serves the plugin. Serve() will not return. If there is a
 // failure, the process will exit with a non-zero exit code.
 func main() {
@@ -0,0 +1,347 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/openkcm/plugin-sdk/api"
+	configv1 "github.com/openkcm/plugin-sdk/proto/service/common/config/v1"
+)
+
+// StartMode controls when the process of an external plugin is started.
+type StartMode string
+
+const (
+	// StartModeEager starts the plugin when the catalog is created.
+	StartModeEager StartMode = "eager"
+	// StartModeLazy starts the plugin on the first call made to it. The
+	// plugin is configured when it is started, so an invalid configuration
+	// fails the first call rather than the configuration itself.
+	StartModeLazy StartMode = "lazy"
+)
+
+var errPluginClosed = status.Error(codes.Unavailable, "plugin is closed")
+
+// isOnDemand reports whether the plugin process is started on demand, either
+// because it is started lazily or because it is stopped when idle.
+func (c *PluginConfig) isOnDemand() bool {
+	return c.IsExternal() && (c.StartMode == StartModeLazy || c.IdleTimeout > 0)
+}
+
+// assumedServiceNames returns the services a lazily started plugin is assumed
+// to implement when it does not declare them: the services of the facades
+// matching its version and the config service. A plugin bound to any version
+// is assumed to implement the most preferred facade version allowed by its
+// version constraint, the one it is bound to when started eagerly.
+func assumedServiceNames(pluginRepo api.PluginRepo, config PluginConfig) []string {
+	var names []string
+	for _, v := range pluginRepo.Versions() {
+		if facade := v.New(); config.allowsFacadeVersion(facade.Version()) {
+			names = append(names, facade.GRPCServiceName())
+			if config.bindsAnyVersion() {
+				break
+			}
+		}
+	}
+	return append(names, configv1.GRPCServiceFullName)
+}
+
+// lazyPlugin starts the plugin process on the first call and stops it when
+// it has been idle for the configured timeout. The last configuration sent
+// to the plugin is replayed every time the process is started.
+type lazyPlugin struct {
+	log         *slog.Logger
+	start       func(ctx context.Context) (*pluginImpl, error)
+	idleTimeout time.Duration
+	info        *pluginInfo
+
+	mu        sync.Mutex
+	current   *pluginImpl
+	starting  *lazyStart
+	inflight  int
+	lastUsed  time.Time
+	idleTimer *time.Timer
+	configure *configv1.ConfigureRequest
+	closed    bool
+}
+
+// lazyStart is an ongoing start of the plugin process. err is set before
+// done is closed.
+type lazyStart struct {
+	done chan struct{}
+	err  error
+}
+
+var _ grpc.ClientConnInterface = (*lazyPlugin)(nil)
+
+// loadPluginOnDemand returns a plugin whose process is managed by a
+// lazyPlugin. Plugins started lazily must declare their services up front
+// since the plugin cannot be asked before it is started.
+func loadPluginOnDemand(ctx context.Context, config PluginConfig) (*pluginImpl, error) {
+	// The policies are applied to the on-demand connection so that state,
+	// like the circuit breaker, survives restarts of the process.
+	instanceConfig := config
+	instanceConfig.Resilience = nil
+	instanceConfig.ValidateResponses = false
+
+	info := newPluginInfo(config)
+	l := &lazyPlugin{
+		log:         config.Logger,
+		idleTimeout: config.IdleTimeout,
+		info:        info,
+		start: func(ctx context.Context) (*pluginImpl, error) {
+			if instanceConfig.Instances > 1 {
+				return loadPluginPool(ctx, instanceConfig)
+			}
+			return loadPlugin(ctx, instanceConfig)
+		},
+	}
+
+	grpcServiceNames := config.ServiceNames
+	if config.StartMode != StartModeLazy {
+		p, err := l.acquire(ctx)
+		if err != nil {
+			return nil, err
+		}
+		grpcServiceNames = p.grpcServiceNames
+		l.release()
+	} else if len(grpcServiceNames) == 0 {
+		return nil, errors.New("lazily started plugin does not declare its service names")
+	}
+
+	p := &pluginImpl{
+		closerGroup:      closerGroup{l},
+		conn:             l,
+		info:             info,
+		logger:           config.Logger,
+		grpcServiceNames: grpcServiceNames,
+		bindAnyVersion:   config.bindsAnyVersion(),
+		allowsVersion:    config.allowsFacadeVersion,
+	}
+	p.intercept(config)
+	return p, nil
+}
+
+func (l *lazyPlugin) Invoke(ctx context.Context, method string, args, reply any, opts ...grpc.CallOption) error {
+	if method == configv1.Config_Configure_FullMethodName {
+		if deferred, err := l.recordConfigure(args); deferred || err != nil {
+			return err
+		}
+	}
+
+	p, err := l.acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer l.release()
+	return p.conn.Invoke(ctx, method, args, reply, opts...)
+}
+
+func (l *lazyPlugin) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	p, err := l.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stream, err := p.conn.NewStream(ctx, desc, method, opts...)
+	if err != nil {
+		l.release()
+		return nil, err
+	}
+	release := sync.OnceFunc(l.release)
+	return newReleasingStream(ctx, desc, stream, release), nil
+}
+
+// recordConfigure remembers the configuration so it can be replayed when
+// the process is (re)started. If the process is neither running nor being
+// started, configuring is deferred until it is started: the configuration
+// is then only validated by the plugin when it is replayed, and a rejected
+// configuration fails the call that started the process.
+func (l *lazyPlugin) recordConfigure(args any) (bool, error) {
+	req, ok := args.(*configv1.ConfigureRequest)
+	if !ok {
+		return false, status.Errorf(codes.Internal, "unexpected configure request %T", args)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.configure = proto.CloneOf(req)
+	if l.current == nil && l.starting == nil {
+		l.log.Debug("Deferring plugin configuration until the plugin is started")
+		return true, nil
+	}
+	return false, nil
+}
+
+// acquire returns the running plugin, starting it if needed, and accounts a
+// call against it. Every successful call must be paired with release. The
+// process is started without holding the lock, callers wait for the start
+// to complete or for their context to be done.
+func (l *lazyPlugin) acquire(ctx context.Context) (*pluginImpl, error) {
+	for {
+		l.mu.Lock()
+		if l.closed {
+			l.mu.Unlock()
+			return nil, errPluginClosed
+		}
+		if l.current != nil {
+			if l.idleTimer != nil {
+				l.idleTimer.Stop()
+			}
+			l.inflight++
+			p := l.current
+			l.mu.Unlock()
+			return p, nil
+		}
+		start := l.starting
+		if start == nil {
+			// The start is not canceled when the caller that triggered it
+			// gives up, since other callers may wait for it.
+			start = &lazyStart{done: make(chan struct{})}
+			l.starting = start
+			go l.startPlugin(context.WithoutCancel(ctx), start)
+		}
+		l.mu.Unlock()
+
+		select {
+		case <-start.done:
+			if start.err != nil {
+				return nil, start.err
+			}
+		case <-ctx.Done():
+			return nil, status.FromContextError(ctx.Err()).Err()
+		}
+	}
+}
+
+// startPlugin starts the process and records it as the running plugin, or
+// records why it failed to start.
+func (l *lazyPlugin) startPlugin(ctx context.Context, start *lazyStart) {
+	defer close(start.done)
+
+	l.mu.Lock()
+	configure := l.configure
+	l.mu.Unlock()
+
+	p, err := l.startProcess(ctx, configure)
+
+	l.mu.Lock()
+	l.starting = nil
+	closed := l.closed
+	if err == nil && !closed {
+		l.current = p
+		l.lastUsed = time.Now()
+		l.armIdleTimerLocked()
+	}
+	l.mu.Unlock()
+
+	switch {
+	case err != nil:
+		start.err = err
+	case closed:
+		start.err = errPluginClosed
+		if err := p.Close(); err != nil {
+			l.log.ErrorContext(ctx, "Failed to stop plugin started while closing", "error", err)
+		}
+	default:
+		l.info.setAdvertised(p.info)
+	}
+}
+
+func (l *lazyPlugin) startProcess(ctx context.Context, configure *configv1.ConfigureRequest) (_ *pluginImpl, err error) {
+	l.log.InfoContext(ctx, "Starting plugin on demand")
+	p, err := l.start(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "failed to start plugin: %v", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = p.Close()
+		}
+	}()
+
+	if configure != nil {
+		if _, err := configv1.NewConfigClient(p.conn).Configure(ctx, configure); err != nil {
+			l.log.ErrorContext(ctx, "Failed to replay plugin configuration", "error", err)
+			return nil, fmt.Errorf("failed to configure plugin: %w", err)
+		}
+	}
+	return p, nil
+}
+
+func (l *lazyPlugin) release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.inflight--
+	l.lastUsed = time.Now()
+	l.armIdleTimerLocked()
+}
+
+// armIdleTimerLocked arms the idle timer if no call is in flight.
+func (l *lazyPlugin) armIdleTimerLocked() {
+	if l.inflight > 0 || l.idleTimeout <= 0 || l.closed {
+		return
+	}
+	if l.idleTimer == nil {
+		l.idleTimer = time.AfterFunc(l.idleTimeout, l.stopIdle)
+	} else {
+		l.idleTimer.Reset(l.idleTimeout)
+	}
+}
+
+// stopIdle stops the process if no call was made since the idle timer was
+// armed.
+func (l *lazyPlugin) stopIdle() {
+	l.mu.Lock()
+	p := l.current
+	if l.inflight > 0 || p == nil || time.Since(l.lastUsed) < l.idleTimeout {
+		l.mu.Unlock()
+		return
+	}
+	l.current = nil
+	l.mu.Unlock()
+
+	l.log.Info("Stopping idle plugin", "idle_timeout", l.idleTimeout)
+	if err := p.Close(); err != nil {
+		l.log.Error("Failed to stop idle plugin", "error", err)
+	}
+}
+
+func (l *lazyPlugin) Close() error {
+	return l.shutdown(context.Background())
+}
+
+func (l *lazyPlugin) shutdown(ctx context.Context) error {
+	l.mu.Lock()
+	l.closed = true
+	if l.idleTimer != nil {
+		l.idleTimer.Stop()
+	}
+	p := l.current
+	l.current = nil
+	start := l.starting
+	l.mu.Unlock()
+
+	// A process being started is stopped once started, wait for it so that
+	// no process outlives the plugin.
+	if start != nil {
+		select {
+		case <-start.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if p == nil {
+		return nil
+	}
+	return p.shutdown(ctx)
+}
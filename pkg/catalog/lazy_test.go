@@ -0,0 +1,190 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/openkcm/plugin-sdk/api"
+	testv1 "github.com/openkcm/plugin-sdk/proto/plugin/test/v1"
+	configv1 "github.com/openkcm/plugin-sdk/proto/service/common/config/v1"
+)
+
+type testFacadeVersion struct {
+	version uint
+	service string
+}
+
+func (v testFacadeVersion) New() api.Facade {
+	return &testFacade{version: v.version, service: v.service}
+}
+func (testFacadeVersion) Deprecated() bool { return false }
+
+type testFacade struct {
+	testv1.TestServicePluginClient
+
+	version uint
+	service string
+	info    api.Info
+}
+
+func (f *testFacade) InitInfo(info api.Info) { f.info = info }
+func (f *testFacade) InitLog(*slog.Logger)   {}
+func (f *testFacade) Version() uint          { return f.version }
+
+// GRPCServiceName returns the service of the facade version, if set, so that
+// the versions of the facade are told apart.
+func (f *testFacade) GRPCServiceName() string {
+	if f.service != "" {
+		return f.service
+	}
+	return f.TestServicePluginClient.GRPCServiceName()
+}
+
+type testPluginRepo struct {
+	versions []api.Version
+	facades  []*testFacade
+}
+
+func (r *testPluginRepo) Binder() any {
+	return func(f *testFacade) { r.facades = append(r.facades, f) }
+}
+func (r *testPluginRepo) Versions() []api.Version      { return r.versions }
+func (r *testPluginRepo) Constraints() api.Constraints { return api.ZeroOrMore() }
+func (r *testPluginRepo) Clear()                       { r.facades = nil }
+
+func TestAssumedServiceNames(t *testing.T) {
+	t.Parallel()
+
+	repo := &testPluginRepo{versions: []api.Version{testFacadeVersion{version: 2}, testFacadeVersion{version: 1}}}
+
+	got := assumedServiceNames(repo, PluginConfig{})
+	if len(got) != 2 || got[0] != testv1.GRPCServiceFullName || got[1] != configv1.GRPCServiceFullName {
+		t.Fatalf("unexpected service names %q", got)
+	}
+	if got := assumedServiceNames(repo, PluginConfig{Version: 3}); len(got) != 1 {
+		t.Fatalf("expected only the config service, got %q", got)
+	}
+	if got := assumedServiceNames(repo, PluginConfig{VersionConstraint: ">=1"}); len(got) != 2 {
+		t.Fatalf("expected the preferred version and the config service, got %q", got)
+	}
+	if got := assumedServiceNames(repo, PluginConfig{VersionConstraint: ">=3"}); len(got) != 1 {
+		t.Fatalf("expected only the config service, got %q", got)
+	}
+}
+
+func TestLoadPluginOnDemand(t *testing.T) {
+	t.Parallel()
+
+	config := PluginConfig{
+		Name:         "lazy",
+		Type:         testv1.Type,
+		Path:         "./testpluginbinary",
+		StartMode:    StartModeLazy,
+		IdleTimeout:  50 * time.Millisecond,
+		ServiceNames: []string{testv1.GRPCServiceFullName, configv1.GRPCServiceFullName},
+	}
+	plugin, err := loadPluginAsExternal(context.Background(), discardLogger(), config)
+	if err != nil {
+		t.Fatalf("loadPluginAsExternal(): %v", err)
+	}
+	defer plugin.Close()
+
+	lazy := unwrapConn(plugin.conn).(*lazyPlugin)
+	running := func() bool {
+		lazy.mu.Lock()
+		defer lazy.mu.Unlock()
+		return lazy.current != nil
+	}
+
+	if running() {
+		t.Fatal("expected plugin not to be started")
+	}
+
+	configurer, err := plugin.makeConfigurer(grpcServiceNameSet(plugin.GrpcServiceNames()))
+	if err != nil {
+		t.Fatalf("makeConfigurer(): %v", err)
+	}
+	if err := configurer.Configure(context.Background(), "config"); err != nil {
+		t.Fatalf("Configure(): %v", err)
+	}
+	if running() {
+		t.Fatal("expected configuration not to start the plugin")
+	}
+
+	client := testv1.NewTestServiceClient(plugin.ClientConnection())
+	if _, err := client.Test(context.Background(), &testv1.TestRequest{}); err != nil {
+		t.Fatalf("Test(): %v", err)
+	}
+	if !running() {
+		t.Fatal("expected plugin to be started on first call")
+	}
+	if plugin.info.SDKVersion() == "" {
+		t.Fatal("expected the advertised info to be recorded once started")
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for running() && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if running() {
+		t.Fatal("expected idle plugin to be stopped")
+	}
+
+	if _, err := client.Test(context.Background(), &testv1.TestRequest{}); err != nil {
+		t.Fatalf("Test() after idle stop: %v", err)
+	}
+}
+
+func TestLoadPluginOnDemandUndeclaredServices(t *testing.T) {
+	t.Parallel()
+
+	config := PluginConfig{
+		Name:      "lazy",
+		Type:      testv1.Type,
+		Path:      "./testpluginbinary",
+		StartMode: StartModeLazy,
+	}
+	if _, err := loadPluginAsExternal(context.Background(), discardLogger(), config); err == nil {
+		t.Fatal("expected error for lazy plugin without service names")
+	}
+}
+
+func TestLazyPluginWaitersRespectContext(t *testing.T) {
+	t.Parallel()
+
+	unblock := make(chan struct{})
+	l := &lazyPlugin{
+		log:  discardLogger(),
+		info: newPluginInfo(PluginConfig{Name: "lazy"}),
+		start: func(context.Context) (*pluginImpl, error) {
+			<-unblock
+			return nil, errors.New("start failed")
+		},
+	}
+
+	first := make(chan error, 1)
+	go func() {
+		_, err := l.acquire(context.Background())
+		first <- err
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := l.acquire(ctx); status.Code(err) != codes.DeadlineExceeded {
+		t.Fatalf("expected waiter to give up with its context, got %v", err)
+	}
+
+	close(unblock)
+	if err := <-first; status.Code(err) != codes.Unavailable {
+		t.Fatalf("expected the start failure, got %v", err)
+	}
+	if l.starting != nil {
+		t.Fatal("expected the failed start to be cleared")
+	}
+}
@@ -11,6 +11,8 @@ import (
 	"os"
 	"os/exec"
 	"sort"
+	"sync"
+	"time"
 
 	"google.golang.org/grpc"
 
@@ -18,6 +20,8 @@ import (
 
 	"github.com/openkcm/plugin-sdk/api"
 	"github.com/openkcm/plugin-sdk/internal/bootstrap"
+	initv1 "github.com/openkcm/plugin-sdk/internal/proto/service/init/v1"
+	"github.com/openkcm/plugin-sdk/internal/semver"
 	"github.com/openkcm/plugin-sdk/internal/slog2hclog"
 )
 
@@ -33,6 +37,21 @@ type PluginConfig struct {
 	// Path is the path on disk to the plugin.
 	Path string
 
+	// Address is the address of an already running remote plugin served with
+	// plugin.ServeRemote, either unix:///path/to/socket or tcp://host:port.
+	// Remote plugins are connected to rather than launched, hence the options
+	// related to the plugin process are ignored.
+	Address string
+
+	// TLS configures the mutual TLS used to connect to a remote plugin. It is
+	// required unless InsecureUnixSocket is set.
+	TLS *TLSConfig
+
+	// InsecureUnixSocket allows connecting without TLS to a remote plugin
+	// served over a Unix socket with pluginoption.WithInsecureUnixSocket,
+	// relying on the permissions of the socket file instead.
+	InsecureUnixSocket bool
+
 	// Args are the command line arguments to supply to the plugin
 	Args []string
 
@@ -44,6 +63,12 @@ type PluginConfig struct {
 
 	Version uint32
 
+	// VersionConstraint is a semantic version constraint, e.g. ">=1.2, <2",
+	// the version advertised by the plugin (see api.HasCapabilities) must
+	// satisfy. Unless Version is set as well, the plugin is then bound to the
+	// most preferred facade version it implements rather than to version 1.
+	VersionConstraint string
+
 	DataSource DataSource
 
 	YamlConfiguration string
@@ -58,12 +83,90 @@ type PluginConfig struct {
 
 	// Tags are the metadata associated with a plugin these can be used to filter plugins later e.g. ['FeatureA'] on client side.
 	Tags []string
+
+	// Instances is the number of processes launched for an external plugin.
+	// The instances are initialized and configured identically and calls are
+	// sent to the least loaded one. Values lower than two launch a single
+	// process. It is ignored for builtin plugins.
+	Instances int
+
+	// StartMode controls when the process of an external plugin is started.
+	// Defaults to StartModeEager.
+	StartMode StartMode
+
+	// IdleTimeout, if set, stops the process of an external plugin after it
+	// has not served any call for the given duration. The process is
+	// transparently restarted and reconfigured on the next call.
+	IdleTimeout time.Duration
+
+	// ServiceNames are the fully qualified gRPC service names implemented by
+	// the plugin. Loading fails if the started plugin does not advertise all
+	// of them. If empty for a lazily started plugin, the plugin is assumed to
+	// implement the service of its type and version as well as the config
+	// service.
+	ServiceNames []string
+
+	// Resilience configures the deadlines, retries, circuit breaker and
+	// concurrency limit applied to calls made to the plugin. If nil, calls
+	// are passed through as is.
+	Resilience *ResiliencePolicy
+
+	// ValidateResponses rejects the responses of the plugin that do not
+	// satisfy their protovalidate constraints with codes.Internal before they
+	// reach the facades.
+	ValidateResponses bool
+
+	// reattach is the state the plugin is reattached from and persisted to.
+	// It is set by the catalog when a state file is configured.
+	reattach *reattachState
+
+	// keepRunning leaves the plugin process running when the plugin is
+	// closed.
+	keepRunning bool
 }
 
 func (c *PluginConfig) IsExternal() bool {
 	return c.Path != ""
 }
 
+// IsRemote reports whether the plugin is an already running remote plugin.
+func (c *PluginConfig) IsRemote() bool {
+	return c.Address != ""
+}
+
+// brokersOverStreams reports whether the host services are served to the
+// plugin over broker streams instead of the go-plugin broker.
+func (c *PluginConfig) brokersOverStreams() bool {
+	return c.IsRemote() || c.reattach != nil
+}
+
+// facadeVersion returns the configured facade version, version 1 by default.
+func (c *PluginConfig) facadeVersion() uint {
+	if c.Version > 1 {
+		return uint(c.Version)
+	}
+	return 1
+}
+
+// bindsAnyVersion reports whether the plugin is bound to the most preferred
+// facade version it implements rather than to the configured version.
+func (c *PluginConfig) bindsAnyVersion() bool {
+	return c.Version == 0 && c.VersionConstraint != ""
+}
+
+// allowsFacadeVersion reports whether the plugin may be bound to the facade
+// version of its type: the configured version or, when bound to any version,
+// the versions allowed by the version constraint, the facade versions being
+// the major versions of the plugin type. An invalid constraint allows every
+// version, as it fails the plugin once its version is checked.
+func (c *PluginConfig) allowsFacadeVersion(version uint) bool {
+	if !c.bindsAnyVersion() {
+		return version == c.facadeVersion()
+	}
+	constraint, err := semver.ParseConstraint(c.VersionConstraint)
+	return err != nil || constraint.AllowsMajor(uint64(version))
+}
+
 func (c *PluginConfig) IsEnabled() bool {
 	return !c.Disabled
 }
@@ -114,14 +217,22 @@ type pluginImpl struct {
 	closerGroup
 
 	conn             grpc.ClientConnInterface
-	info             api.Info
+	info             *pluginInfo
 	logger           *slog.Logger
 	grpcServiceNames []string
+	bindAnyVersion   bool
+	allowsVersion    func(version uint) bool
+	breaker          *circuitBreaker
+	tracker          *callTracker
 }
 
 func (p *pluginImpl) Close() error {
 	return p.closerGroup.Close()
 }
+
+func (p *pluginImpl) shutdown(ctx context.Context) error {
+	return p.closerGroup.shutdown(ctx)
+}
 func (p *pluginImpl) ClientConnection() grpc.ClientConnInterface {
 	return p.conn
 }
@@ -135,9 +246,19 @@ func (p *pluginImpl) GrpcServiceNames() []string {
 	return p.grpcServiceNames
 }
 
+// CircuitState returns the state of the circuit breaker of the plugin. Plugins
+// without a circuit breaker are always reported as closed.
+func (p *pluginImpl) CircuitState() CircuitState {
+	return p.breaker.State()
+}
+
 func loadPlugin(ctx context.Context, config PluginConfig) (*pluginImpl, error) {
 	config.Logger.InfoContext(ctx, "Loading plugin", "name", config.Name, "path", config.Path)
 
+	if config.reattach != nil {
+		return loadReattachablePlugin(ctx, config)
+	}
+
 	cmd := pluginCmd(config.Path, config.Args...)
 	injectEnv(config, cmd)
 
@@ -148,26 +269,30 @@ func loadPlugin(ctx context.Context, config PluginConfig) (*pluginImpl, error) {
 	}
 
 	// Start the plugin client
+	clientConfig := newClientConfig(config)
+	clientConfig.SecureConfig = seccfg
+	clientConfig.Cmd = cmd
+	return startPlugin(ctx, config, goplugin.NewClient(clientConfig))
+}
 
-	pluginClient := goplugin.NewClient(&goplugin.ClientConfig{
-		SecureConfig: seccfg,
-		Logger:       slog2hclog.NewWithLevel(config.Logger, config.LogLevel),
-		HandshakeConfig: goplugin.HandshakeConfig{
-			ProtocolVersion:  1,
-			MagicCookieKey:   config.Type,
-			MagicCookieValue: config.Type,
-		},
+func newClientConfig(config PluginConfig) *goplugin.ClientConfig {
+	return &goplugin.ClientConfig{
+		Logger:           slog2hclog.NewWithLevel(config.Logger, config.LogLevel),
+		HandshakeConfig:  bootstrap.HandshakeConfig(config.Type),
 		AutoMTLS:         true,
-		Plugins:          map[string]goplugin.Plugin{config.Name: &HCRPCPlugin{config: config}},
-		Cmd:              cmd,
+		VersionedPlugins: versionedPlugins(config),
 		AllowedProtocols: []goplugin.Protocol{goplugin.ProtocolGRPC},
-	})
+	}
+}
 
+// startPlugin starts or reattaches to the plugin process of the client and
+// initializes the plugin.
+func startPlugin(ctx context.Context, config PluginConfig, pluginClient *goplugin.Client) (*pluginImpl, error) {
 	// Connect via RPC
 	rpcClient, err := pluginClient.Client()
 	if err != nil {
 		pluginClient.Kill()
-		return nil, err
+		return nil, protocolVersionError(err)
 	}
 
 	// Request the plugin
@@ -184,26 +309,28 @@ func loadPlugin(ctx context.Context, config PluginConfig) (*pluginImpl, error) {
 	}
 
 	// Plugin has been loaded and initialized. Ensure the plugin client is
-	// killed when the plugin is closed.
-	plugin.closers = append(plugin.closers, closerFunc(pluginClient.Kill))
-
-	var version uint = 1
-	if config.Version > 1 {
-		version = uint(config.Version)
-	}
-	info := &pluginInfo{
-		name:    config.Name,
-		typ:     config.Type,
-		tags:    config.Tags,
-		version: version,
+	// killed when the plugin is closed, unless the process is left running
+	// for the host to reattach to it.
+	if config.keepRunning {
+		if conn, ok := plugin.conn.(io.Closer); ok {
+			plugin.closers = append(plugin.closers, conn)
+		}
+	} else {
+		plugin.closers = append(plugin.closers, closerFunc(pluginClient.Kill))
 	}
 
-	return newPlugin(ctx, plugin.conn, info, config.Logger, plugin.closers, config.HostServices)
-
+	p, err := newPlugin(ctx, plugin.conn, newPluginInfo(config), config, plugin.closers)
+	if err != nil {
+		_ = plugin.closers.Close()
+		return nil, err
+	}
+	return p, nil
 }
 
-// injectEnv injects the environment variables into the command
+// injectEnv injects the environment variables into the command, along with
+// the magic cookie of plugins built with earlier versions of the SDK.
 func injectEnv(config PluginConfig, cmd *exec.Cmd) {
+	cmd.Env = append(cmd.Env, bootstrap.LegacyMagicCookie(config.Type))
 	if len(config.Env) != 0 {
 		for key, val := range config.Env {
 			cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", key, val))
@@ -211,12 +338,34 @@ func injectEnv(config PluginConfig, cmd *exec.Cmd) {
 	}
 }
 
+// newPluginInfo returns the info of the plugin. Its version is the configured
+// facade version, or the version of the facade the plugin is bound to when it
+// is bound to any version, which is zero until it is bound.
+func newPluginInfo(config PluginConfig) *pluginInfo {
+	info := &pluginInfo{
+		name: config.Name,
+		typ:  config.Type,
+		tags: config.Tags,
+	}
+	if !config.bindsAnyVersion() {
+		info.version = config.facadeVersion()
+	}
+	return info
+}
+
+// pluginInfo is the information of a loaded plugin. The information
+// advertised by the plugin may be updated after the plugin is loaded, e.g.
+// when a lazily started plugin is started, hence it is guarded by mu.
 type pluginInfo struct {
-	name      string
-	typ       string
-	buildInfo string
-	tags      []string
-	version   uint
+	name string
+	typ  string
+	tags []string
+
+	mu           sync.RWMutex
+	buildInfo    string
+	version      uint
+	sdkVersion   string
+	capabilities api.Capabilities
 }
 
 func (info *pluginInfo) Name() string {
@@ -229,24 +378,114 @@ func (info *pluginInfo) Type() string {
 
 func (info *pluginInfo) Tags() []string { return info.tags }
 
-func (info *pluginInfo) Build() string { return info.buildInfo }
+func (info *pluginInfo) Build() string {
+	info.mu.RLock()
+	defer info.mu.RUnlock()
+	return info.buildInfo
+}
 
 func (info *pluginInfo) Version() uint {
+	info.mu.RLock()
+	defer info.mu.RUnlock()
 	return info.version
 }
 
+// setVersion records the version of the facade the plugin is bound to.
+func (info *pluginInfo) setVersion(version uint) {
+	info.mu.Lock()
+	defer info.mu.Unlock()
+	info.version = version
+}
+
+func (info *pluginInfo) SDKVersion() string {
+	info.mu.RLock()
+	defer info.mu.RUnlock()
+	return info.sdkVersion
+}
+
+func (info *pluginInfo) Capabilities() api.Capabilities {
+	info.mu.RLock()
+	defer info.mu.RUnlock()
+	return info.capabilities
+}
+
 func (info *pluginInfo) SetValue(value string) {
+	info.mu.Lock()
+	defer info.mu.Unlock()
 	info.buildInfo = value
 }
 
+// setCapabilities records what the plugin advertised during initialization.
+// The build information may still be replaced by the one returned when the
+// plugin is configured.
+func (info *pluginInfo) setCapabilities(resp *initv1.InitResponse) {
+	info.mu.Lock()
+	defer info.mu.Unlock()
+	info.sdkVersion = resp.GetSdkVersion()
+	info.capabilities = api.Capabilities{
+		Version:   resp.GetPluginVersion(),
+		BuildInfo: resp.GetBuildInfo(),
+		Metadata:  resp.GetCapabilities(),
+	}
+	if info.capabilities.BuildInfo != "" {
+		info.buildInfo = info.capabilities.BuildInfo
+	}
+}
+
+// setAdvertised copies the information advertised by the started plugin,
+// e.g. for a plugin started on demand.
+func (info *pluginInfo) setAdvertised(started api.Info) {
+	sdkVersion, capabilities, buildInfo := started.SDKVersion(), started.Capabilities(), started.Build()
+
+	info.mu.Lock()
+	defer info.mu.Unlock()
+	info.sdkVersion = sdkVersion
+	info.capabilities = capabilities
+	info.buildInfo = buildInfo
+}
+
+// checkVersionConstraint checks that the version advertised by the plugin
+// satisfies the constraint, if any.
+func checkVersionConstraint(info *pluginInfo, constraint string) error {
+	if constraint == "" {
+		return nil
+	}
+	c, err := semver.ParseConstraint(constraint)
+	if err != nil {
+		return err
+	}
+	advertised := info.Capabilities().Version
+	if advertised == "" {
+		return fmt.Errorf("plugin does not advertise its version, required by version constraint %q", c)
+	}
+	version, err := semver.Parse(advertised)
+	if err != nil {
+		return fmt.Errorf("plugin advertises an invalid version: %w", err)
+	}
+	if !c.Check(version) {
+		return fmt.Errorf("plugin version %s does not satisfy version constraint %q", version, c)
+	}
+	return nil
+}
+
 type pluginCloser struct {
 	plugin io.Closer
 	log    *slog.Logger
 }
 
 func (c pluginCloser) Close() error {
+	return c.shutdown(context.Background())
+}
+
+func (c pluginCloser) shutdown(ctx context.Context) error {
 	c.log.Info("Plugins unloading")
-	if err := c.plugin.Close(); err != nil {
+	var err error
+	if s, ok := c.plugin.(shutdowner); ok {
+		err = s.shutdown(ctx)
+	} else {
+		err = c.plugin.Close()
+	}
+	if err != nil {
 		c.log.Error("Failed to unload plugin", "error", err)
 		return err
 	}
@@ -276,30 +515,98 @@ func buildSecureConfig(checksum string) (*goplugin.SecureConfig, error) {
 	}, nil
 }
 
-func newPlugin(ctx context.Context, conn grpc.ClientConnInterface, info api.Info, logger *slog.Logger, closers closerGroup, hostServices []api.ServiceServer) (*pluginImpl, error) {
-	grpcServiceNames, err := initPlugin(ctx, conn, hostServices)
+func newPlugin(ctx context.Context, conn grpc.ClientConnInterface, info *pluginInfo, config PluginConfig, closers closerGroup) (*pluginImpl, error) {
+	logger := config.Logger
+	resp, err := initPlugin(ctx, conn, config.HostServices, config.brokersOverStreams())
 	if err != nil {
 		return nil, err
 	}
+	info.setCapabilities(resp)
+	if err := checkVersionConstraint(info, config.VersionConstraint); err != nil {
+		return nil, err
+	}
+	if err := checkDeclaredServices(config.ServiceNames, resp.GetPluginServiceNames()); err != nil {
+		return nil, err
+	}
 
-	closers = append(closers, closerFunc(func() {
-		ctx, cancel := context.WithTimeout(context.Background(), deinitTimeout)
-		defer cancel()
-		if err := bootstrap.Deinit(ctx, conn); err != nil {
-			logger.ErrorContext(ctx, "Failed to deinitialize plugin", "error", err)
-		} else {
-			logger.Debug("Plugin deinitialized")
-		}
-	}))
+	// A plugin left running is not deinitialized, so that it keeps its state
+	// for the host to reattach to it.
+	if !config.keepRunning {
+		closers = append(closers, deinitCloser{conn: conn, log: logger})
+	}
 
-	return &pluginImpl{
+	p := &pluginImpl{
 		closerGroup: closers,
 
 		conn:             conn,
 		info:             info,
 		logger:           logger,
-		grpcServiceNames: grpcServiceNames,
-	}, nil
+		grpcServiceNames: resp.GetPluginServiceNames(),
+		bindAnyVersion:   config.bindsAnyVersion(),
+		allowsVersion:    config.allowsFacadeVersion,
+	}
+
+	// The interceptors are applied after initialization so that the
+	// bootstrap calls are not subject to the policies meant for facades.
+	p.intercept(config)
+
+	return p, nil
+}
+
+// checkDeclaredServices fails if the plugin does not advertise all the
+// services it is declared to implement.
+func checkDeclaredServices(declared, advertised []string) error {
+	actual := grpcServiceNameSet(advertised)
+	for _, name := range declared {
+		if _, ok := actual[name]; !ok {
+			return fmt.Errorf("plugin does not implement declared service %q", name)
+		}
+	}
+	return nil
+}
+
+// intercept applies the client interceptors configured for the plugin to
+// its connection.
+func (p *pluginImpl) intercept(config PluginConfig) {
+	p.tracker = newCallTracker()
+	unary := []grpc.UnaryClientInterceptor{p.tracker.UnaryClientInterceptor}
+	stream := []grpc.StreamClientInterceptor{p.tracker.StreamClientInterceptor}
+	if config.Resilience != nil {
+		r := newResilience(config.Resilience, p.logger)
+		p.breaker = r.breaker
+		unary = append(unary, r.UnaryClientInterceptor)
+		stream = append(stream, r.StreamClientInterceptor)
+	}
+	if config.ValidateResponses {
+		// Validating closest to the plugin makes invalid responses count as
+		// failures of the attempt they are returned by.
+		v := newResponseValidator(p.logger)
+		unary = append(unary, v.UnaryClientInterceptor)
+		stream = append(stream, v.StreamClientInterceptor)
+	}
+	p.conn = withClientInterceptors(p.conn, unary, stream)
+}
+
+// deinitCloser deinitializes the plugin when closed. The deinitialization is
+// bounded by deinitTimeout and, on shutdown, by the deadline of the context.
+type deinitCloser struct {
+	conn grpc.ClientConnInterface
+	log  *slog.Logger
+}
+
+func (d deinitCloser) Close() error {
+	return d.shutdown(context.Background())
+}
+
+func (d deinitCloser) shutdown(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, deinitTimeout)
+	defer cancel()
+	if err := bootstrap.Deinit(ctx, d.conn); err != nil {
+		d.log.ErrorContext(ctx, "Failed to deinitialize plugin", "error", err)
+	} else {
+		d.log.Debug("Plugin deinitialized")
+	}
+	return nil
 }
 
 // Bind implements the Plugin interface method of the same name.
@@ -320,14 +627,14 @@ func (p *pluginImpl) Bind(facades ...api.Facade) (Configurer, error) {
 	return configurer, nil
 }
 
-func initPlugin(ctx context.Context, conn grpc.ClientConnInterface, hostServices []api.ServiceServer) ([]string, error) {
+func initPlugin(ctx context.Context, conn grpc.ClientConnInterface, hostServices []api.ServiceServer, brokerStreams bool) (*initv1.InitResponse, error) {
 	var hostServiceGRPCServiceNames []string
 	for _, hostService := range hostServices {
 		hostServiceGRPCServiceNames = append(hostServiceGRPCServiceNames, hostService.GRPCServiceName())
 	}
 	ctx, cancel := context.WithTimeout(ctx, initTimeout)
 	defer cancel()
-	return bootstrap.Init(ctx, conn, hostServiceGRPCServiceNames)
+	return bootstrap.Init(ctx, conn, hostServiceGRPCServiceNames, brokerStreams)
 }
 
 func (p *pluginImpl) makeConfigurer(grpcServiceNames map[string]struct{}) (Configurer, error) {
@@ -337,7 +644,7 @@ func (p *pluginImpl) makeConfigurer(grpcServiceNames map[string]struct{}) (Confi
 		return nil, err
 	}
 
-	_, err = p.bindRepo(bindable, grpcServiceNames, false)
+	_, err = p.bindRepo(bindable, grpcServiceNames, false, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -345,38 +652,62 @@ func (p *pluginImpl) makeConfigurer(grpcServiceNames map[string]struct{}) (Confi
 	return repo.configurer, nil
 }
 
-func (p *pluginImpl) bindRepo(repo bindableServiceRepo, grpcServiceNames map[string]struct{}, failOnNotFound bool) (any, error) {
+// bindRepo binds the facade of the preferred version implemented by the plugin
+// to the repo. The plugin repo is bound to a facade version allowed by the
+// plugin config, like the services assumed for lazily started plugins. The
+// binding is recorded in the report, if any.
+func (p *pluginImpl) bindRepo(repo bindableServiceRepo, grpcServiceNames map[string]struct{}, failOnNotFound bool, report *PluginCompatibility) (any, error) {
 	versions := repo.Versions()
 
+	// Unless bound to any version, only the configured version is accepted.
+	accepts := func(version uint) bool { return p.bindAnyVersion || version == p.info.Version() }
+	if failOnNotFound && p.allowsVersion != nil {
+		accepts = p.allowsVersion
+	}
+
 	var impl any
+	var bound api.Version
+	var implemented []uint
 	for _, version := range versions {
 		facade := version.New()
 
 		if _, ok := grpcServiceNames[facade.GRPCServiceName()]; ok {
+			implemented = append(implemented, facade.Version())
 			delete(grpcServiceNames, facade.GRPCServiceName())
-			// Use the first matching version (in case the plugin implements
+			// Use the first accepted version (in case the plugin implements
 			// more than one). The rest will be removed from the list of
 			// service names above so we can properly warn of unhandled
 			// services without false negatives.
-			if impl != nil {
+			if impl != nil || !accepts(facade.Version()) {
 				continue
 			}
-			warnIfDeprecated(p.logger, version, versions[0])
 			impl = p.bindFacade(repo, facade)
-		}
-
-		if impl != nil && facade.Version() == p.info.Version() {
+			bound = version
 			break
-		} else {
-			impl = nil
 		}
 	}
 
 	if impl == nil && failOnNotFound {
-		return nil, fmt.Errorf("requested plugin `%s` of version `%d` implementation not found",
-			p.info.Type(),
-			p.info.Version(),
-		)
+		requested := fmt.Sprintf("requested plugin `%s` of version `%d`", p.info.Type(), p.info.Version())
+		if p.bindAnyVersion {
+			requested = fmt.Sprintf("requested plugin `%s` of any version", p.info.Type())
+		}
+		if len(implemented) == 0 {
+			return nil, fmt.Errorf("%s implementation not found: plugin implements none of the supported versions", requested)
+		}
+		return nil, fmt.Errorf("%s implementation not found: plugin implements versions %v", requested, implemented)
+	}
+
+	if impl != nil {
+		binding := newFacadeBinding(bound, versions[0])
+		if failOnNotFound {
+			// The plugin repo determines the version of the plugin.
+			p.info.setVersion(binding.Version)
+		}
+		warnIfDeprecated(p.logger, binding)
+		if report != nil {
+			report.Bindings = append(report.Bindings, binding)
+		}
 	}
 
 	return impl, nil
@@ -388,41 +719,56 @@ func (p *pluginImpl) bindFacade(repo bindable, facade api.Facade) any {
 	return impl
 }
 
-func (p *pluginImpl) bindRepos(pluginRepo bindablePluginRepo, serviceRepos []bindableServiceRepo) (Configurer, error) {
+// bindRepos binds the plugin to the plugin repo and the service repos and
+// reports how it was bound.
+func (p *pluginImpl) bindRepos(pluginRepo bindablePluginRepo, serviceRepos []bindableServiceRepo) (Configurer, PluginCompatibility, error) {
 	grpcServiceNames := grpcServiceNameSet(p.grpcServiceNames)
+	report := PluginCompatibility{
+		Name: p.info.Name(),
+		Type: p.info.Type(),
+	}
 
-	impl, err := p.bindRepo(pluginRepo, grpcServiceNames, true)
+	impl, err := p.bindRepo(pluginRepo, grpcServiceNames, true, &report)
 	if err != nil {
-		return nil, err
+		return nil, report, err
 	}
 	for _, serviceRepo := range serviceRepos {
-		_, err := p.bindRepo(serviceRepo, grpcServiceNames, false)
+		_, err := p.bindRepo(serviceRepo, grpcServiceNames, false, &report)
 		if err != nil {
-			return nil, err
+			return nil, report, err
 		}
 	}
 
 	configurer, err := p.makeConfigurer(grpcServiceNames)
 	if err != nil {
-		return nil, err
+		return nil, report, err
 	}
 
 	switch {
 	case impl == nil:
-		return nil, fmt.Errorf("no supported plugin interface found in: %q", p.grpcServiceNames)
+		return nil, report, fmt.Errorf("no supported plugin interface found in: %q", p.grpcServiceNames)
 	case len(grpcServiceNames) > 0:
-		for _, grpcServiceName := range sortStringSet(grpcServiceNames) {
+		report.UnboundServices = sortStringSet(grpcServiceNames)
+		for _, grpcServiceName := range report.UnboundServices {
 			p.logger.With("plugin_service", grpcServiceName).Warn("Unsupported plugin service found")
 		}
 	}
 
-	return configurer, nil
+	return configurer, report, nil
 }
 
-func warnIfDeprecated(log *slog.Logger, thisVersion, latestVersion api.Version) {
-	if thisVersion.Deprecated() {
-		log.Warn("Service is deprecated and will be removed in a future release")
+func warnIfDeprecated(log *slog.Logger, binding FacadeBinding) {
+	if !binding.Deprecated {
+		return
+	}
+	log = log.With("service", binding.Service, "version", binding.Version)
+	if binding.ReplacementService != "" {
+		log.Warn("Service is deprecated and will be removed in a future release",
+			"replacement_service", binding.ReplacementService,
+			"replacement_version", binding.ReplacementVersion)
+		return
 	}
+	log.Warn("Service is deprecated and will be removed in a future release")
 }
 
 func (p *pluginImpl) initFacade(facade api.Facade) any {
@@ -5,11 +5,14 @@ import (
 	"errors"
 	"log/slog"
 	"os/exec"
+	"slices"
+	"strings"
 	"testing"
 
 	"google.golang.org/grpc"
 
 	"github.com/openkcm/plugin-sdk/api"
+	testv1 "github.com/openkcm/plugin-sdk/proto/plugin/test/v1"
 )
 
 type fakeCloser struct {
@@ -72,6 +75,7 @@ func TestInjectEnv(t *testing.T) {
 	}
 
 	cfg := PluginConfig{
+		Type: "Test",
 		Env: map[string]string{
 			"A": "1",
 			"B": "2",
@@ -80,8 +84,11 @@ func TestInjectEnv(t *testing.T) {
 
 	injectEnv(cfg, cmd)
 
-	if len(cmd.Env) != 3 {
-		t.Fatalf("expected 3 env vars, got %d", len(cmd.Env))
+	if len(cmd.Env) != 4 {
+		t.Fatalf("expected 4 env vars, got %d", len(cmd.Env))
+	}
+	if !slices.Contains(cmd.Env, "Test=Test") {
+		t.Fatalf("expected the legacy magic cookie, got %v", cmd.Env)
 	}
 }
 
@@ -110,6 +117,29 @@ func TestPluginInfo(t *testing.T) {
 	}
 }
 
+func TestPluginInfoCapabilities(t *testing.T) {
+	t.Parallel()
+
+	plugin, err := loadPluginAsExternal(context.Background(), discardLogger(), PluginConfig{
+		Name: "capabilities",
+		Type: testv1.Type,
+		Path: "./testpluginbinary",
+	})
+	if err != nil {
+		t.Fatalf("loadPluginAsExternal(): %v", err)
+	}
+	defer plugin.Close()
+
+	info := plugin.Info()
+	if info.SDKVersion() == "" {
+		t.Fatal("expected SDK version to be advertised")
+	}
+	capabilities := info.Capabilities()
+	if capabilities.Version != "1.2.3" || capabilities.Metadata["feature"] != "test" {
+		t.Fatalf("unexpected capabilities %+v", capabilities)
+	}
+}
+
 func TestPluginStruct(t *testing.T) {
 	t.Parallel()
 
@@ -243,6 +273,7 @@ func TestInitPluginFailure(t *testing.T) {
 		[]api.ServiceServer{
 			&fakePluginServiceServer{name: "svc"},
 		},
+		false,
 	)
 
 	if err == nil {
@@ -264,8 +295,7 @@ func TestNewPluginInitFailure(t *testing.T) {
 		context.Background(),
 		nil,
 		&pluginInfo{name: "p"},
-		slog.New(slog.NewTextHandler(discardPluginWriter{}, nil)),
-		nil,
+		PluginConfig{Logger: slog.New(slog.NewTextHandler(discardPluginWriter{}, nil))},
 		nil,
 	)
 
@@ -290,3 +320,133 @@ func TestLoadPluginInvalidChecksum(t *testing.T) {
 		t.Fatal("expected error")
 	}
 }
+
+func TestVersionConstraint(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		constraint string
+		wantErr    string
+	}{
+		{name: "satisfied", constraint: ">=1.2"},
+		{name: "not satisfied", constraint: ">=2", wantErr: "plugin version 1.2.3 does not satisfy version constraint"},
+		// The facade versions are the major versions of the plugin type.
+		{name: "facade version not allowed", constraint: ">=1.2, <2", wantErr: "plugin implements versions [2]"},
+		{name: "invalid", constraint: ">=x", wantErr: "invalid version constraint"},
+		// Without a constraint the plugin is bound to version 1 only.
+		{name: "none", wantErr: "plugin implements versions [2]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := &testPluginRepo{versions: []api.Version{testFacadeVersion{version: 2}}}
+			cat, err := New(context.Background(), Config{
+				Logger: discardLogger(),
+				PluginConfigs: []PluginConfig{{
+					Name:              "constrained",
+					Type:              testv1.Type,
+					Path:              "./testpluginbinary",
+					VersionConstraint: tt.constraint,
+				}},
+			}, testRepository{plugins: map[string]api.PluginRepo{testv1.Type: repo}})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("New(): %v", err)
+			}
+			defer cat.Close()
+
+			if len(repo.facades) != 1 || repo.facades[0].Version() != 2 {
+				t.Fatalf("expected facade version 2 to be bound, got %v", repo.facades)
+			}
+			if got := repo.facades[0].info.Version(); got != 2 {
+				t.Fatalf("expected plugin info of version 2, got %d", got)
+			}
+		})
+	}
+}
+
+func TestBindRepoVersionConstraint(t *testing.T) {
+	t.Parallel()
+
+	versions := []api.Version{
+		testFacadeVersion{version: 2, service: "plugin.test.v2.TestService"},
+		testFacadeVersion{version: 1, service: testv1.GRPCServiceFullName},
+	}
+	config := PluginConfig{Name: "constrained", Type: testv1.Type, VersionConstraint: ">=1.2, <2"}
+	bind := func(grpcServiceNames []string) *testPluginRepo {
+		t.Helper()
+
+		repo := &testPluginRepo{versions: versions}
+		bindable, err := makeBindablePluginRepo(repo)
+		if err != nil {
+			t.Fatalf("makeBindablePluginRepo(): %v", err)
+		}
+		p := &pluginImpl{
+			info:           newPluginInfo(config),
+			logger:         discardLogger(),
+			bindAnyVersion: config.bindsAnyVersion(),
+			allowsVersion:  config.allowsFacadeVersion,
+		}
+		if _, err := p.bindRepo(bindable, grpcServiceNameSet(grpcServiceNames), true, nil); err != nil {
+			t.Fatalf("bindRepo(): %v", err)
+		}
+		return repo
+	}
+
+	// An eager plugin is bound to the services it advertises, a lazy one to
+	// the services it is assumed to implement.
+	eager := bind([]string{"plugin.test.v2.TestService", testv1.GRPCServiceFullName})
+	lazy := bind(assumedServiceNames(&testPluginRepo{versions: versions}, config))
+
+	for name, repo := range map[string]*testPluginRepo{"eager": eager, "lazy": lazy} {
+		if len(repo.facades) != 1 || repo.facades[0].Version() != 1 {
+			t.Fatalf("expected the %s plugin to be bound to facade version 1, got %v", name, repo.facades)
+		}
+	}
+}
+
+func TestDeclaredServices(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		services []string
+		wantErr  string
+	}{
+		{name: "advertised", services: []string{testv1.GRPCServiceFullName}},
+		{name: "not advertised", services: []string{"plugin.missing.v1.Missing"}, wantErr: `plugin does not implement declared service "plugin.missing.v1.Missing"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := &testPluginRepo{versions: []api.Version{testFacadeVersion{version: 1}}}
+			cat, err := New(context.Background(), Config{
+				Logger: discardLogger(),
+				PluginConfigs: []PluginConfig{{
+					Name:         "declared",
+					Type:         testv1.Type,
+					Path:         "./testpluginbinary",
+					ServiceNames: tt.services,
+				}},
+			}, testRepository{plugins: map[string]api.PluginRepo{testv1.Type: repo}})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("New(): %v", err)
+			}
+			defer cat.Close()
+		})
+	}
+}
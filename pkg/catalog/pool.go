@@ -0,0 +1,182 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	configv1 "github.com/openkcm/plugin-sdk/proto/service/common/config/v1"
+)
+
+// broadcastMethods are the methods that are sent to every instance of a
+// plugin pool so that all instances share the same state.
+var broadcastMethods = map[string]struct{}{
+	configv1.Config_Configure_FullMethodName: {},
+}
+
+// loadPluginPool launches the configured number of instances of an external
+// plugin and presents them as a single plugin.
+func loadPluginPool(ctx context.Context, config PluginConfig) (_ *pluginImpl, err error) {
+	var closers closerGroup
+	defer func() {
+		if err != nil {
+			_ = closers.Close()
+		}
+	}()
+
+	baseLogger := config.Logger
+	instances := make([]*pluginImpl, 0, config.Instances)
+	for i := range config.Instances {
+		// The policies are applied to the pool connection rather than to
+		// every instance.
+		instanceConfig := config
+		instanceConfig.Logger = baseLogger.With("instance", i)
+		instanceConfig.Resilience = nil
+		instanceConfig.ValidateResponses = false
+
+		instance, err := loadPlugin(ctx, instanceConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load instance %d: %w", i, err)
+		}
+		closers = append(closers, instance)
+
+		if len(instances) > 0 && !sameServiceNames(instances[0].grpcServiceNames, instance.grpcServiceNames) {
+			return nil, fmt.Errorf("instance %d advertises services %q, expected %q",
+				i, instance.grpcServiceNames, instances[0].grpcServiceNames)
+		}
+		instances = append(instances, instance)
+	}
+
+	baseLogger.InfoContext(ctx, "Loaded plugin pool", "instances", len(instances))
+
+	p := &pluginImpl{
+		closerGroup:      closers,
+		conn:             newPoolConn(instances),
+		info:             instances[0].info,
+		logger:           baseLogger,
+		grpcServiceNames: instances[0].grpcServiceNames,
+		bindAnyVersion:   config.bindsAnyVersion(),
+		allowsVersion:    config.allowsFacadeVersion,
+	}
+	p.intercept(config)
+	return p, nil
+}
+
+func sameServiceNames(a, b []string) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(a, b)
+}
+
+// poolConn sends each call to the instance with the fewest calls in flight.
+// Calls to broadcast methods are sent to all instances in turn.
+type poolConn struct {
+	instances []*pluginImpl
+	inflight  []atomic.Int64
+	next      atomic.Uint64
+
+	// broadcastMu serializes the broadcasts. applied holds, per broadcast
+	// method, the arguments last applied to all instances.
+	broadcastMu sync.Mutex
+	applied     map[string]any
+}
+
+var _ grpc.ClientConnInterface = (*poolConn)(nil)
+
+func newPoolConn(instances []*pluginImpl) *poolConn {
+	return &poolConn{
+		instances: instances,
+		inflight:  make([]atomic.Int64, len(instances)),
+		applied:   make(map[string]any),
+	}
+}
+
+func (c *poolConn) Invoke(ctx context.Context, method string, args, reply any, opts ...grpc.CallOption) error {
+	if _, ok := broadcastMethods[method]; ok {
+		return c.broadcast(ctx, method, args, reply, opts...)
+	}
+
+	i := c.acquire()
+	defer c.inflight[i].Add(-1)
+	return c.instances[i].conn.Invoke(ctx, method, args, reply, opts...)
+}
+
+func (c *poolConn) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	i := c.acquire()
+	release := sync.OnceFunc(func() { c.inflight[i].Add(-1) })
+
+	stream, err := c.instances[i].conn.NewStream(ctx, desc, method, opts...)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	return newReleasingStream(ctx, desc, stream, release), nil
+}
+
+// acquire selects the least loaded instance and accounts a call against it.
+// Ties are broken in a round-robin fashion.
+func (c *poolConn) acquire() int {
+	start := int(c.next.Add(1)-1) % len(c.instances)
+	selected := start
+	for n := 1; n < len(c.instances); n++ {
+		i := (start + n) % len(c.instances)
+		if c.inflight[i].Load() < c.inflight[selected].Load() {
+			selected = i
+		}
+	}
+	c.inflight[selected].Add(1)
+	return selected
+}
+
+// broadcast invokes the method on every instance. The reply of the first
+// instance is returned to the caller. If an instance fails, the arguments
+// previously applied to all instances are sent again to the instances that
+// already succeeded, so that all instances keep sharing the same state.
+func (c *poolConn) broadcast(ctx context.Context, method string, args, reply any, opts ...grpc.CallOption) error {
+	c.broadcastMu.Lock()
+	defer c.broadcastMu.Unlock()
+
+	for i, instance := range c.instances {
+		instanceReply := reply
+		if i > 0 {
+			instanceReply = newReply(reply)
+		}
+		if err := instance.conn.Invoke(ctx, method, args, instanceReply, opts...); err != nil {
+			instance.logger.ErrorContext(ctx, "Failed to invoke plugin pool instance", "method", method, "error", err)
+			c.rollback(ctx, method, c.instances[:i], reply, opts...)
+			return err
+		}
+	}
+	c.applied[method] = args
+	return nil
+}
+
+// rollback sends the arguments previously applied to all instances to the
+// given instances again. Nothing is sent if the method was never applied,
+// e.g. when the initial configuration fails, since the plugin is then not
+// loaded.
+func (c *poolConn) rollback(ctx context.Context, method string, instances []*pluginImpl, reply any, opts ...grpc.CallOption) {
+	previous, ok := c.applied[method]
+	if !ok {
+		return
+	}
+	for _, instance := range instances {
+		if err := instance.conn.Invoke(ctx, method, previous, newReply(reply), opts...); err != nil {
+			instance.logger.ErrorContext(ctx, "Failed to roll back plugin pool instance", "method", method, "error", err)
+		}
+	}
+}
+
+// newReply returns an empty reply of the same type as the given one.
+func newReply(reply any) any {
+	if msg, ok := reply.(proto.Message); ok {
+		return msg.ProtoReflect().New().Interface()
+	}
+	return reply
+}
@@ -0,0 +1,154 @@
+package catalog
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	testv1 "github.com/openkcm/plugin-sdk/proto/plugin/test/v1"
+	configv1 "github.com/openkcm/plugin-sdk/proto/service/common/config/v1"
+)
+
+func newFakePoolConn(n int) (*poolConn, []*fakeConn) {
+	var instances []*pluginImpl
+	var conns []*fakeConn
+	for range n {
+		conn := &fakeConn{}
+		conns = append(conns, conn)
+		instances = append(instances, &pluginImpl{conn: conn, logger: discardLogger()})
+	}
+	return newPoolConn(instances), conns
+}
+
+func TestPoolConnLeastLoaded(t *testing.T) {
+	t.Parallel()
+
+	pool, conns := newFakePoolConn(3)
+
+	// Simulate calls in flight on the first two instances.
+	pool.inflight[0].Add(2)
+	pool.inflight[1].Add(1)
+
+	for range 3 {
+		if err := pool.Invoke(context.Background(), "/svc/Method", nil, nil); err != nil {
+			t.Fatalf("Invoke(): %v", err)
+		}
+	}
+	if conns[2].calls != 3 {
+		t.Fatalf("expected calls to go to the idle instance, got %d/%d/%d", conns[0].calls, conns[1].calls, conns[2].calls)
+	}
+	if got := pool.inflight[2].Load(); got != 0 {
+		t.Fatalf("expected calls to be released, got %d in flight", got)
+	}
+}
+
+func TestPoolConnBroadcastsConfigure(t *testing.T) {
+	t.Parallel()
+
+	pool, conns := newFakePoolConn(3)
+
+	err := pool.Invoke(context.Background(), configv1.Config_Configure_FullMethodName,
+		&configv1.ConfigureRequest{}, &configv1.ConfigureResponse{})
+	if err != nil {
+		t.Fatalf("Invoke(): %v", err)
+	}
+	for i, conn := range conns {
+		if conn.calls != 1 {
+			t.Fatalf("expected instance %d to be configured once, got %d", i, conn.calls)
+		}
+	}
+}
+
+// configRecorder records the configurations it is sent and rejects the
+// configuration fail.
+type configRecorder struct {
+	grpc.ClientConnInterface
+
+	configurations []string
+	fail           string
+}
+
+func (c *configRecorder) Invoke(_ context.Context, _ string, args, _ any, _ ...grpc.CallOption) error {
+	configuration := args.(*configv1.ConfigureRequest).GetYamlConfiguration()
+	c.configurations = append(c.configurations, configuration)
+	if configuration == c.fail {
+		return status.Error(codes.InvalidArgument, "invalid configuration")
+	}
+	return nil
+}
+
+func TestPoolConnRollsBackFailedConfigure(t *testing.T) {
+	t.Parallel()
+
+	recorders := []*configRecorder{{}, {}, {fail: "new"}}
+	var instances []*pluginImpl
+	for _, recorder := range recorders {
+		instances = append(instances, &pluginImpl{conn: recorder, logger: discardLogger()})
+	}
+	pool := newPoolConn(instances)
+	configure := func(configuration string) error {
+		return pool.Invoke(context.Background(), configv1.Config_Configure_FullMethodName,
+			&configv1.ConfigureRequest{YamlConfiguration: configuration}, &configv1.ConfigureResponse{})
+	}
+
+	if err := configure("old"); err != nil {
+		t.Fatalf("Invoke(): %v", err)
+	}
+	if err := configure("new"); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected the error of the failing instance, got %v", err)
+	}
+
+	want := [][]string{{"old", "new", "old"}, {"old", "new", "old"}, {"old", "new"}}
+	for i, recorder := range recorders {
+		if !slices.Equal(recorder.configurations, want[i]) {
+			t.Fatalf("instance %d configured with %q, want %q", i, recorder.configurations, want[i])
+		}
+	}
+}
+
+func TestLoadPluginPool(t *testing.T) {
+	t.Parallel()
+
+	config := PluginConfig{
+		Name:      "pool",
+		Type:      testv1.Type,
+		Path:      "./testpluginbinary",
+		Instances: 2,
+	}
+	plugin, err := loadPluginAsExternal(context.Background(), discardLogger(), config)
+	if err != nil {
+		t.Fatalf("loadPluginAsExternal(): %v", err)
+	}
+	defer plugin.Close()
+
+	pool, ok := unwrapConn(plugin.conn).(*poolConn)
+	if !ok {
+		t.Fatalf("expected pool connection, got %T", unwrapConn(plugin.conn))
+	}
+	if len(pool.instances) != 2 {
+		t.Fatalf("expected 2 instances, got %d", len(pool.instances))
+	}
+
+	client := testv1.NewTestServiceClient(plugin.ClientConnection())
+	for range 2 {
+		resp, err := client.Test(context.Background(), &testv1.TestRequest{})
+		if err != nil {
+			t.Fatalf("Test(): %v", err)
+		}
+		if resp.GetResponse() != "test" {
+			t.Fatalf("unexpected response %q", resp.GetResponse())
+		}
+	}
+
+	configurer, err := plugin.makeConfigurer(grpcServiceNameSet(plugin.GrpcServiceNames()))
+	if err != nil {
+		t.Fatalf("makeConfigurer(): %v", err)
+	}
+	if err := configurer.Configure(context.Background(), "config"); err != nil {
+		t.Fatalf("Configure(): %v", err)
+	}
+}
@@ -0,0 +1,65 @@
+package catalog
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	goplugin "github.com/hashicorp/go-plugin"
+
+	"github.com/openkcm/plugin-sdk/internal/bootstrap"
+)
+
+// supportedProtocolVersions are the versions of the plugin protocol the host
+// loads plugins with, i.e. the major versions of the SDK the plugins may have
+// been built against. The plugin and the host agree on the latest version
+// they both support.
+var supportedProtocolVersions = []int{bootstrap.ProtocolVersion}
+
+// incompatibleVersionPattern matches the error of go-plugin when the plugin
+// does not speak any of the protocol versions of the host.
+var incompatibleVersionPattern = regexp.MustCompile(`incompatible API version with plugin\. Plugin version: (\d+)`)
+
+// ProtocolVersionError is returned when a plugin is built against a major
+// version of the SDK the host does not support.
+type ProtocolVersionError struct {
+	// PluginVersion is the protocol version the plugin was built with.
+	PluginVersion int
+
+	// HostVersions are the protocol versions supported by the host.
+	HostVersions []int
+}
+
+func (e *ProtocolVersionError) Error() string {
+	versions := make([]string, 0, len(e.HostVersions))
+	for _, version := range e.HostVersions {
+		versions = append(versions, "v"+strconv.Itoa(version))
+	}
+	return fmt.Sprintf("plugin built with SDK v%d, host requires %s", e.PluginVersion, strings.Join(versions, " or "))
+}
+
+// versionedPlugins returns the plugin sets of the supported protocol versions.
+func versionedPlugins(config PluginConfig) map[int]goplugin.PluginSet {
+	plugins := make(map[int]goplugin.PluginSet, len(supportedProtocolVersions))
+	for _, version := range supportedProtocolVersions {
+		plugins[version] = goplugin.PluginSet{config.Name: &HCRPCPlugin{config: config}}
+	}
+	return plugins
+}
+
+// protocolVersionError translates the opaque handshake failure of go-plugin
+// when the protocol versions of the plugin and the host do not match into a
+// ProtocolVersionError. Other errors are returned unchanged.
+func protocolVersionError(err error) error {
+	match := incompatibleVersionPattern.FindStringSubmatch(err.Error())
+	if match == nil {
+		return err
+	}
+	version, convErr := strconv.Atoi(match[1])
+	if convErr != nil {
+		return err
+	}
+	return &ProtocolVersionError{PluginVersion: version, HostVersions: slices.Clone(supportedProtocolVersions)}
+}
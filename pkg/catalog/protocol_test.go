@@ -0,0 +1,89 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	goplugin "github.com/hashicorp/go-plugin"
+
+	"github.com/openkcm/plugin-sdk/internal/bootstrap"
+	testv1 "github.com/openkcm/plugin-sdk/proto/plugin/test/v1"
+)
+
+func TestVersionedPlugins(t *testing.T) {
+	t.Parallel()
+
+	plugins := versionedPlugins(PluginConfig{Name: "plugin"})
+	if _, ok := plugins[bootstrap.ProtocolVersion]["plugin"]; !ok || len(plugins) != len(supportedProtocolVersions) {
+		t.Fatalf("unexpected versioned plugins %v", plugins)
+	}
+}
+
+func TestProtocolVersionMismatch(t *testing.T) {
+	t.Parallel()
+
+	config := PluginConfig{
+		Name:   "mismatch",
+		Type:   testv1.Type,
+		Path:   "./testpluginbinary",
+		Logger: discardLogger(),
+	}
+	clientConfig := newClientConfig(config)
+	clientConfig.Cmd = pluginCmd(config.Path)
+	// Pretend the host only supports a later major version of the SDK.
+	clientConfig.VersionedPlugins = map[int]goplugin.PluginSet{
+		bootstrap.ProtocolVersion + 1: {config.Name: &HCRPCPlugin{config: config}},
+	}
+
+	_, err := startPlugin(context.Background(), config, goplugin.NewClient(clientConfig))
+	var versionErr *ProtocolVersionError
+	if !errors.As(err, &versionErr) || versionErr.PluginVersion != bootstrap.ProtocolVersion {
+		t.Fatalf("expected protocol version error, got %v", err)
+	}
+}
+
+// TestIncompatibleVersionPattern pins the wording of the error of go-plugin
+// on a protocol version mismatch, which incompatibleVersionPattern recovers
+// the version of the plugin from.
+func TestIncompatibleVersionPattern(t *testing.T) {
+	t.Parallel()
+
+	config := PluginConfig{
+		Name:   "pattern",
+		Type:   testv1.Type,
+		Path:   "./testpluginbinary",
+		Logger: discardLogger(),
+	}
+	clientConfig := newClientConfig(config)
+	clientConfig.Cmd = pluginCmd(config.Path)
+	clientConfig.VersionedPlugins = map[int]goplugin.PluginSet{
+		bootstrap.ProtocolVersion + 1: {config.Name: &HCRPCPlugin{config: config}},
+	}
+
+	client := goplugin.NewClient(clientConfig)
+	defer client.Kill()
+	_, err := client.Client()
+	if err == nil {
+		t.Fatal("expected the handshake to fail")
+	}
+	match := incompatibleVersionPattern.FindStringSubmatch(err.Error())
+	if match == nil || match[1] != strconv.Itoa(bootstrap.ProtocolVersion) {
+		t.Fatalf("go-plugin error %q does not match %v", err, incompatibleVersionPattern)
+	}
+}
+
+func TestProtocolVersionErrorMessage(t *testing.T) {
+	t.Parallel()
+
+	err := &ProtocolVersionError{PluginVersion: 1, HostVersions: []int{2, 3}}
+	if got, want := err.Error(), "plugin built with SDK v1, host requires v2 or v3"; got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+
+	other := errors.New("plugin exited")
+	if got := protocolVersionError(other); got != other {
+		t.Fatalf("expected other errors to be returned unchanged, got %v", got)
+	}
+}
@@ -0,0 +1,389 @@
+package catalog
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	goplugin "github.com/hashicorp/go-plugin"
+)
+
+// reattachDialTimeout bounds the check that a plugin recorded in the state
+// file is still listening.
+const reattachDialTimeout = time.Second
+
+// reattachEntry is what is persisted about an external plugin for the host to
+// reattach to it after a restart.
+type reattachEntry struct {
+	Protocol        goplugin.Protocol `json:"protocol"`
+	ProtocolVersion int               `json:"protocol_version"`
+	Network         string            `json:"network"`
+	Address         string            `json:"address"`
+	Pid             int               `json:"pid"`
+
+	// Checksum is the hex-encoded SHA256 hash of the plugin binary.
+	Checksum string `json:"checksum"`
+
+	// ConfigHash is the hex-encoded SHA256 hash of the plugin configuration.
+	ConfigHash string `json:"config_hash"`
+
+	// ClientCertificate and ClientKey are the PEM-encoded certificate and
+	// key the host authenticates to the plugin with, and ServerCertificate
+	// the PEM-encoded certificate of the plugin. They are generated by
+	// AutoMTLS when the plugin is launched.
+	ClientCertificate string `json:"client_certificate,omitempty"`
+	ClientKey         string `json:"client_key,omitempty"`
+	ServerCertificate string `json:"server_certificate,omitempty"`
+}
+
+// hasTLS reports whether the TLS material of the plugin was recorded.
+func (e reattachEntry) hasTLS() bool {
+	return e.ClientCertificate != "" && e.ClientKey != "" && e.ServerCertificate != ""
+}
+
+// setTLS records the client certificate of the TLS config and the
+// certificate of the plugin.
+func (e *reattachEntry) setTLS(config *tls.Config, serverCert *x509.Certificate) error {
+	if config == nil || len(config.Certificates) == 0 || serverCert == nil {
+		return errors.New("plugin is not served over mTLS")
+	}
+	clientCert := config.Certificates[0]
+	key, err := x509.MarshalPKCS8PrivateKey(clientCert.PrivateKey)
+	if err != nil {
+		return err
+	}
+	e.ClientCertificate = string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: clientCert.Certificate[0]}))
+	e.ClientKey = string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: key}))
+	e.ServerCertificate = string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: serverCert.Raw}))
+	return nil
+}
+
+// tlsConfig returns the TLS config to reconnect to the plugin with, the same
+// go-plugin sets up with AutoMTLS.
+func (e reattachEntry) tlsConfig() (*tls.Config, error) {
+	clientCert, err := tls.X509KeyPair([]byte(e.ClientCertificate), []byte(e.ClientKey))
+	if err != nil {
+		return nil, fmt.Errorf("invalid client certificate: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM([]byte(e.ServerCertificate)) {
+		return nil, errors.New("invalid server certificate")
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{clientCert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS12,
+		ServerName:   "localhost",
+		RootCAs:      pool,
+		ClientCAs:    pool,
+	}, nil
+}
+
+func (e reattachEntry) reattachConfig() (*goplugin.ReattachConfig, error) {
+	var addr net.Addr
+	switch e.Network {
+	case "unix":
+		addr = &net.UnixAddr{Name: e.Address, Net: "unix"}
+	case "tcp":
+		tcpAddr, err := net.ResolveTCPAddr("tcp", e.Address)
+		if err != nil {
+			return nil, err
+		}
+		addr = tcpAddr
+	default:
+		return nil, fmt.Errorf("unsupported network %q", e.Network)
+	}
+	return &goplugin.ReattachConfig{
+		Protocol:        e.Protocol,
+		ProtocolVersion: e.ProtocolVersion,
+		Addr:            addr,
+		Pid:             e.Pid,
+	}, nil
+}
+
+// reachable reports whether the plugin is still listening.
+func (e reattachEntry) reachable() bool {
+	conn, err := net.DialTimeout(e.Network, e.Address, reattachDialTimeout)
+	if err != nil {
+		return false
+	}
+	_ = conn.Close()
+	return true
+}
+
+// reattachState is the state file the reattach configurations of the external
+// plugins are persisted to, keyed by plugin name.
+type reattachState struct {
+	path string
+
+	mu sync.Mutex
+}
+
+func (s *reattachState) get(name string) (reattachEntry, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, err := s.readLocked()
+	if err != nil {
+		return reattachEntry{}, false, err
+	}
+	entry, ok := entries[name]
+	return entry, ok, nil
+}
+
+// put records the entry of the plugin, or removes it if entry is nil.
+func (s *reattachState) put(name string, entry *reattachEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, err := s.readLocked()
+	if err != nil {
+		return err
+	}
+	if entry == nil {
+		delete(entries, name)
+	} else {
+		entries[name] = *entry
+	}
+
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	// Write atomically so that a crash does not leave a truncated file.
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
+
+func (s *reattachState) readLocked() (map[string]reattachEntry, error) {
+	entries := make(map[string]reattachEntry)
+	data, err := os.ReadFile(s.path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return entries, nil
+	case err != nil:
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("invalid plugin state file %q: %w", s.path, err)
+	}
+	return entries, nil
+}
+
+// pluginFingerprint returns the checksum of the plugin binary and the hash of
+// the configuration the plugin is launched and configured with. A running
+// plugin is only reattached to if both are unchanged.
+func pluginFingerprint(config PluginConfig) (checksum, configHash string, err error) {
+	f, err := os.Open(config.Path)
+	if err != nil {
+		return "", "", err
+	}
+	defer f.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return "", "", err
+	}
+	checksum = hex.EncodeToString(hash.Sum(nil))
+
+	if config.Checksum != "" && !strings.EqualFold(config.Checksum, checksum) {
+		return "", "", fmt.Errorf("checksum of plugin %q does not match", config.Path)
+	}
+
+	data, err := json.Marshal(struct {
+		Type              string
+		Path              string
+		Args              []string
+		Env               map[string]string
+		Version           uint32
+		LogLevel          string
+		YamlConfiguration string
+	}{
+		Type:              config.Type,
+		Path:              config.Path,
+		Args:              config.Args,
+		Env:               config.Env,
+		Version:           config.Version,
+		LogLevel:          config.LogLevel,
+		YamlConfiguration: config.YamlConfiguration,
+	})
+	if err != nil {
+		return "", "", err
+	}
+	sum := sha256.Sum256(data)
+	return checksum, hex.EncodeToString(sum[:]), nil
+}
+
+// loadReattachablePlugin reattaches to the running plugin recorded in the
+// state file if its binary and configuration are unchanged. Otherwise, the
+// plugin is launched and recorded.
+func loadReattachablePlugin(ctx context.Context, config PluginConfig) (*pluginImpl, error) {
+	checksum, configHash, err := pluginFingerprint(config)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, ok, err := config.reattach.get(config.Name)
+	if err != nil {
+		return nil, err
+	}
+	// Only plugins still listening are reattached to or stopped, the process
+	// recorded may otherwise not be the plugin anymore.
+	if ok && entry.reachable() {
+		switch {
+		case !entry.hasTLS():
+			config.Logger.InfoContext(ctx, "Plugin launched without mTLS, stopping it", "pid", entry.Pid)
+			stopPlugin(config, entry)
+		case entry.Checksum == checksum && entry.ConfigHash == configHash:
+			p, err := reattachPlugin(ctx, config, entry)
+			if err == nil {
+				config.Logger.InfoContext(ctx, "Reattached to running plugin", "pid", entry.Pid)
+				forgetOnClose(p, config)
+				return p, nil
+			}
+			config.Logger.WarnContext(ctx, "Failed to reattach to running plugin, launching it", "pid", entry.Pid, "error", err)
+		default:
+			config.Logger.InfoContext(ctx, "Plugin changed since it was launched, stopping it", "pid", entry.Pid)
+			stopPlugin(config, entry)
+		}
+	}
+
+	cmd := pluginCmd(config.Path, config.Args...)
+	if config.keepRunning {
+		cmd = detachedPluginCmd(config.Path, config.Args...)
+	}
+	injectEnv(config, cmd)
+
+	clientConfig := newClientConfig(config)
+	clientConfig.Cmd = cmd
+	pluginClient := goplugin.NewClient(clientConfig)
+	// The certificates of AutoMTLS are generated when the plugin is
+	// launched. The certificate of the plugin is captured when the host
+	// connects to it, they are recorded to reconnect after a restart.
+	if _, err := pluginClient.Start(); err != nil {
+		pluginClient.Kill()
+		return nil, protocolVersionError(err)
+	}
+	var serverCert atomic.Pointer[x509.Certificate]
+	if clientConfig.TLSConfig != nil {
+		clientConfig.TLSConfig.VerifyConnection = func(state tls.ConnectionState) error {
+			if len(state.PeerCertificates) > 0 {
+				serverCert.CompareAndSwap(nil, state.PeerCertificates[0])
+			}
+			return nil
+		}
+	}
+	p, err := startPlugin(ctx, config, pluginClient)
+	if err != nil {
+		return nil, err
+	}
+
+	rc := pluginClient.ReattachConfig()
+	entry = reattachEntry{
+		Protocol:        rc.Protocol,
+		ProtocolVersion: pluginClient.NegotiatedVersion(),
+		Network:         rc.Addr.Network(),
+		Address:         rc.Addr.String(),
+		Pid:             rc.Pid,
+		Checksum:        checksum,
+		ConfigHash:      configHash,
+	}
+	if err := entry.setTLS(clientConfig.TLSConfig, serverCert.Load()); err != nil {
+		_ = p.Close()
+		return nil, fmt.Errorf("failed to record plugin TLS material: %w", err)
+	}
+	if err := config.reattach.put(config.Name, &entry); err != nil {
+		_ = p.Close()
+		return nil, fmt.Errorf("failed to record plugin state: %w", err)
+	}
+	forgetOnClose(p, config)
+	return p, nil
+}
+
+// forgetOnClose removes the entry of the plugin from the state file when the
+// plugin is closed, unless it is kept running.
+func forgetOnClose(p *pluginImpl, config PluginConfig) {
+	if config.keepRunning {
+		return
+	}
+	p.closerGroup = append(p.closerGroup, closerFunc(func() {
+		if err := config.reattach.put(config.Name, nil); err != nil {
+			config.Logger.Error("Failed to remove plugin state", "error", err)
+		}
+	}))
+}
+
+// reattachClientConfig returns the client config to reattach to the plugin
+// recorded by the entry. Entries recorded without TLS material are connected
+// to in plaintext, which is only done to stop them.
+func reattachClientConfig(config PluginConfig, entry reattachEntry) (*goplugin.ClientConfig, error) {
+	rc, err := entry.reattachConfig()
+	if err != nil {
+		return nil, err
+	}
+	clientConfig := newClientConfig(config)
+	clientConfig.Reattach = rc
+	clientConfig.AutoMTLS = false
+	if entry.hasTLS() {
+		if clientConfig.TLSConfig, err = entry.tlsConfig(); err != nil {
+			return nil, err
+		}
+	}
+	return clientConfig, nil
+}
+
+func reattachPlugin(ctx context.Context, config PluginConfig, entry reattachEntry) (*pluginImpl, error) {
+	clientConfig, err := reattachClientConfig(config, entry)
+	if err != nil {
+		return nil, err
+	}
+	rc := clientConfig.Reattach
+	// go-plugin does not negotiate the protocol version when reattaching, the
+	// plugins of the version negotiated at launch are used.
+	plugins, ok := clientConfig.VersionedPlugins[rc.ProtocolVersion]
+	if !ok {
+		return nil, &ProtocolVersionError{PluginVersion: rc.ProtocolVersion, HostVersions: slices.Clone(supportedProtocolVersions)}
+	}
+	clientConfig.Plugins = plugins
+	return startPlugin(ctx, config, goplugin.NewClient(clientConfig))
+}
+
+// stopPlugin stops a running plugin that is not reattached to.
+func stopPlugin(config PluginConfig, entry reattachEntry) {
+	clientConfig, err := reattachClientConfig(config, entry)
+	if err != nil {
+		config.Logger.Warn("Failed to stop outdated plugin", "pid", entry.Pid, "error", err)
+		return
+	}
+	pluginClient := goplugin.NewClient(clientConfig)
+	if _, err := pluginClient.Client(); err != nil {
+		config.Logger.Warn("Failed to connect to outdated plugin", "pid", entry.Pid, "error", err)
+	}
+	pluginClient.Kill()
+}
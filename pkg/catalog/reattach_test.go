@@ -0,0 +1,140 @@
+package catalog
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/openkcm/plugin-sdk/api"
+	testv1 "github.com/openkcm/plugin-sdk/proto/plugin/test/v1"
+)
+
+func TestReattachState(t *testing.T) {
+	t.Parallel()
+
+	state := &reattachState{path: filepath.Join(t.TempDir(), "plugins.json")}
+	if _, ok, err := state.get("plugin"); err != nil || ok {
+		t.Fatalf("expected no entry in missing state file, got %v, %v", ok, err)
+	}
+
+	want := reattachEntry{Network: "unix", Address: "/tmp/plugin.sock", Pid: 42, Checksum: "sum", ConfigHash: "hash"}
+	if err := state.put("plugin", &want); err != nil {
+		t.Fatalf("put(): %v", err)
+	}
+	got, ok, err := state.get("plugin")
+	if err != nil || !ok || got != want {
+		t.Fatalf("expected %+v, got %+v, %v, %v", want, got, ok, err)
+	}
+
+	if err := state.put("plugin", nil); err != nil {
+		t.Fatalf("put(): %v", err)
+	}
+	if _, ok, err := state.get("plugin"); err != nil || ok {
+		t.Fatalf("expected entry to be removed, got %v, %v", ok, err)
+	}
+}
+
+func TestCatalogReattachesRunningPlugins(t *testing.T) {
+	t.Parallel()
+
+	stateFile := filepath.Join(t.TempDir(), "plugins.json")
+	state := &reattachState{path: stateFile}
+	load := func(yaml string, keepRunning bool) *Catalog {
+		t.Helper()
+
+		repo := &testPluginRepo{versions: []api.Version{testFacadeVersion{version: 1}}}
+		cat, err := New(context.Background(), Config{
+			Logger: discardLogger(),
+			PluginConfigs: []PluginConfig{{
+				Name:              "reattach",
+				Type:              testv1.Type,
+				Path:              "./testpluginbinary",
+				YamlConfiguration: yaml,
+			}},
+			StateFile:          stateFile,
+			KeepPluginsRunning: keepRunning,
+		}, testRepository{plugins: map[string]api.PluginRepo{testv1.Type: repo}})
+		if err != nil {
+			t.Fatalf("New(): %v", err)
+		}
+		if _, err := repo.facades[0].Test(context.Background(), &testv1.TestRequest{}); err != nil {
+			t.Fatalf("Test(): %v", err)
+		}
+		return cat
+	}
+	entry := func() reattachEntry {
+		t.Helper()
+
+		entry, ok, err := state.get("reattach")
+		if err != nil || !ok {
+			t.Fatalf("expected plugin state, got %v, %v", ok, err)
+		}
+		return entry
+	}
+
+	if err := load("a: 1", true).Close(); err != nil {
+		t.Fatalf("Close(): %v", err)
+	}
+	launched := entry()
+	if !launched.reachable() {
+		t.Fatal("expected plugin to keep running")
+	}
+	if !launched.hasTLS() {
+		t.Fatal("expected the mTLS material of the plugin to be recorded")
+	}
+
+	if err := load("a: 1", true).Close(); err != nil {
+		t.Fatalf("Close(): %v", err)
+	}
+	if reattached := entry(); reattached.Pid != launched.Pid {
+		t.Fatalf("expected plugin %d to be reattached to, got %d", launched.Pid, reattached.Pid)
+	}
+
+	cat := load("a: 2", false)
+	relaunched := entry()
+	if relaunched.Pid == launched.Pid {
+		t.Fatal("expected changed plugin to be launched again")
+	}
+	if launched.reachable() {
+		t.Fatal("expected outdated plugin to be stopped")
+	}
+
+	if err := cat.Close(); err != nil {
+		t.Fatalf("Close(): %v", err)
+	}
+	if _, ok, _ := state.get("reattach"); ok {
+		t.Fatal("expected state of stopped plugin to be removed")
+	}
+	if relaunched.reachable() {
+		t.Fatal("expected plugin to be stopped")
+	}
+
+	// A plugin reattached to without being kept running is stopped and
+	// forgotten when the catalog is closed.
+	if err := load("b: 1", true).Close(); err != nil {
+		t.Fatalf("Close(): %v", err)
+	}
+	kept := entry()
+	cat = load("b: 1", false)
+	if reattached := entry(); reattached.Pid != kept.Pid {
+		t.Fatalf("expected plugin %d to be reattached to, got %d", kept.Pid, reattached.Pid)
+	}
+	if err := cat.Close(); err != nil {
+		t.Fatalf("Close(): %v", err)
+	}
+	if _, ok, _ := state.get("reattach"); ok {
+		t.Fatal("expected state of stopped reattached plugin to be removed")
+	}
+	if kept.reachable() {
+		t.Fatal("expected reattached plugin to be stopped")
+	}
+}
+
+func TestKeepPluginsRunningRequiresStateFile(t *testing.T) {
+	t.Parallel()
+
+	_, err := New(context.Background(), Config{Logger: discardLogger(), KeepPluginsRunning: true}, testRepository{})
+	if err == nil {
+		t.Fatal("expected error without state file")
+	}
+}
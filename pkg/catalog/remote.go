@@ -0,0 +1,187 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/openkcm/plugin-sdk/api"
+	"github.com/openkcm/plugin-sdk/internal/bootstrap"
+	configv1 "github.com/openkcm/plugin-sdk/proto/service/common/config/v1"
+)
+
+// TLSConfig configures the mutual TLS used to connect to a remote plugin.
+type TLSConfig struct {
+	// CertFile and KeyFile are the certificate and key the host
+	// authenticates with.
+	CertFile string
+	KeyFile  string
+
+	// CAFile is the CA the certificate of the plugin is verified against.
+	CAFile string
+
+	// ServerName overrides the name the certificate of the plugin is
+	// verified for. It defaults to the host of the address.
+	ServerName string
+}
+
+// remotePlugin maintains the connection to a remote plugin. The host services
+// are served over broker streams opened to the plugin, and the plugin is
+// reinitialized and reconfigured whenever the connection is re-established
+// since it may have been restarted in between.
+type remotePlugin struct {
+	conn         *grpc.ClientConn
+	log          *slog.Logger
+	hostServices []api.ServiceServer
+
+	mu        sync.Mutex
+	configure *configv1.ConfigureRequest
+}
+
+func loadRemotePlugin(ctx context.Context, config PluginConfig) (_ *pluginImpl, err error) {
+	config.Logger.InfoContext(ctx, "Connecting to remote plugin", "address", config.Address)
+
+	network, address, err := bootstrap.ParseAddress(config.Address)
+	if err != nil {
+		return nil, err
+	}
+	creds, err := remoteCredentials(network, config.TLS, config.InsecureUnixSocket)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := grpc.NewClient(
+		"passthrough:///"+address,
+		grpc.WithTransportCredentials(creds),
+		grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, network, addr)
+		}),
+		// Going idle would close the broker stream.
+		grpc.WithIdleTimeout(0),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var closers closerGroup
+	defer func() {
+		if err != nil {
+			_ = closers.Close()
+		}
+	}()
+	closers = append(closers, conn, serveHostServicesOverStreams(conn, config))
+
+	r := &remotePlugin{
+		conn:         conn,
+		log:          config.Logger,
+		hostServices: config.HostServices,
+	}
+	watchCtx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	wg.Go(func() { r.watch(watchCtx) })
+	closers = append(closers, closerFunc(func() {
+		cancel()
+		wg.Wait()
+	}))
+
+	pluginConn := withClientInterceptors(conn, []grpc.UnaryClientInterceptor{r.recordConfigure}, nil)
+	return newPlugin(ctx, pluginConn, newPluginInfo(config), config, closers)
+}
+
+func remoteCredentials(network string, config *TLSConfig, insecureUnixSocket bool) (credentials.TransportCredentials, error) {
+	if config == nil {
+		if network != "unix" || !insecureUnixSocket {
+			return nil, errors.New("remote plugins require mutual TLS, unless served over a Unix socket with InsecureUnixSocket")
+		}
+		return insecure.NewCredentials(), nil
+	}
+
+	tlsConfig, err := bootstrap.MutualTLSConfig(config.CertFile, config.KeyFile, config.CAFile, false)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig.ServerName = config.ServerName
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+// watch reinitializes the plugin when the connection is re-established after
+// it was lost.
+func (r *remotePlugin) watch(ctx context.Context) {
+	state := r.conn.GetState()
+	lost := false
+	for r.conn.WaitForStateChange(ctx, state) {
+		state = r.conn.GetState()
+		switch state {
+		case connectivity.Idle:
+			// The connection went away, reconnect right away.
+			lost = true
+			r.conn.Connect()
+		case connectivity.TransientFailure:
+			if !lost {
+				r.log.Warn("Lost connection to remote plugin")
+			}
+			lost = true
+		case connectivity.Ready:
+			if lost {
+				lost = false
+				r.reinitialize(ctx)
+			}
+		}
+	}
+}
+
+func (r *remotePlugin) reinitialize(ctx context.Context) {
+	r.log.Info("Reconnected to remote plugin, reinitializing")
+	for {
+		err := r.reinit(ctx)
+		if err == nil || ctx.Err() != nil {
+			return
+		}
+		r.log.Error("Failed to reinitialize remote plugin", "error", err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(brokerRetryInterval):
+		}
+	}
+}
+
+func (r *remotePlugin) reinit(ctx context.Context) error {
+	if _, err := initPlugin(ctx, r.conn, r.hostServices, true); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	configure := r.configure
+	r.mu.Unlock()
+	if configure == nil {
+		return nil
+	}
+	_, err := configv1.NewConfigClient(r.conn).Configure(ctx, configure)
+	return err
+}
+
+// recordConfigure remembers the last configuration applied to the plugin so
+// it can be replayed on reconnect.
+func (r *remotePlugin) recordConfigure(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if err := invoker(ctx, method, req, reply, cc, opts...); err != nil {
+		return err
+	}
+	if req, ok := req.(*configv1.ConfigureRequest); ok && method == configv1.Config_Configure_FullMethodName {
+		r.mu.Lock()
+		r.configure = proto.CloneOf(req)
+		r.mu.Unlock()
+	}
+	return nil
+}
@@ -0,0 +1,290 @@
+package catalog
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	goplugin "github.com/hashicorp/go-plugin"
+
+	"github.com/openkcm/plugin-sdk/api"
+	pluginoption "github.com/openkcm/plugin-sdk/api/plugin-option"
+	"github.com/openkcm/plugin-sdk/internal/bootstrap"
+	testv1 "github.com/openkcm/plugin-sdk/proto/plugin/test/v1"
+	configv1 "github.com/openkcm/plugin-sdk/proto/service/common/config/v1"
+)
+
+// remoteTestPlugin answers test calls with the build info returned by the
+// config host service.
+type remoteTestPlugin struct {
+	testv1.UnimplementedTestServiceServer
+	configv1.UnimplementedConfigServer
+
+	mu         sync.Mutex
+	host       configv1.ConfigServiceClient
+	configured string
+}
+
+func (p *remoteTestPlugin) BrokerHostServices(broker api.ServiceBroker) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !broker.BrokerClient(&p.host) {
+		return errors.New("config host service not available")
+	}
+	return nil
+}
+
+func (p *remoteTestPlugin) Configure(_ context.Context, req *configv1.ConfigureRequest) (*configv1.ConfigureResponse, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.configured = req.GetYamlConfiguration()
+	return &configv1.ConfigureResponse{}, nil
+}
+
+func (p *remoteTestPlugin) Test(ctx context.Context, _ *testv1.TestRequest) (*testv1.TestResponse, error) {
+	p.mu.Lock()
+	host := p.host
+	p.mu.Unlock()
+	resp, err := host.Configure(ctx, &configv1.ConfigureRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return &testv1.TestResponse{Response: resp.GetBuildInfo()}, nil
+}
+
+func (p *remoteTestPlugin) configuration() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.configured
+}
+
+type hostConfigService struct {
+	configv1.UnimplementedConfigServer
+}
+
+func (hostConfigService) Configure(context.Context, *configv1.ConfigureRequest) (*configv1.ConfigureResponse, error) {
+	buildInfo := "host"
+	return &configv1.ConfigureResponse{BuildInfo: &buildInfo}, nil
+}
+
+// serveRemote serves the plugin on the address until the returned function is
+// called.
+func serveRemote(t *testing.T, address string, p *remoteTestPlugin, opts ...pluginoption.ServerOption) func() {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- bootstrap.ServeRemote(address, append([]pluginoption.ServerOption{
+			pluginoption.WithPluginServer(testv1.TestServicePluginServer(p)),
+			pluginoption.WithServiceServer(configv1.ConfigServiceServer(p)),
+			pluginoption.WithTestConfig(&goplugin.ServeTestConfig{Context: ctx}),
+		}, opts...)...)
+	}()
+
+	network, addr, err := bootstrap.ParseAddress(address)
+	if err != nil {
+		t.Fatalf("ParseAddress(): %v", err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		conn, err := net.Dial(network, addr)
+		if err == nil {
+			_ = conn.Close()
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("remote plugin not listening: %v", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	return func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("ServeRemote(): %v", err)
+		}
+	}
+}
+
+func newRemoteCatalog(t *testing.T, config PluginConfig) (*Catalog, *testPluginRepo) {
+	t.Helper()
+
+	repo := &testPluginRepo{versions: []api.Version{testFacadeVersion{version: 1}}}
+	config.Name = "remote"
+	config.Type = testv1.Type
+	config.YamlConfiguration = "key: value"
+	cat, err := New(context.Background(), Config{
+		Logger:        discardLogger(),
+		PluginConfigs: []PluginConfig{config},
+		HostServices:  []api.ServiceServer{configv1.ConfigServiceServer(hostConfigService{})},
+	}, testRepository{plugins: map[string]api.PluginRepo{testv1.Type: repo}})
+	if err != nil {
+		t.Fatalf("New(): %v", err)
+	}
+	t.Cleanup(func() { _ = cat.Close() })
+	return cat, repo
+}
+
+func callRemote(t *testing.T, repo *testPluginRepo) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	resp, err := repo.facades[0].Test(ctx, &testv1.TestRequest{})
+	if err != nil {
+		t.Fatalf("Test(): %v", err)
+	}
+	if resp.GetResponse() != "host" {
+		t.Fatalf("expected response from host service, got %q", resp.GetResponse())
+	}
+}
+
+func TestRemotePluginUnix(t *testing.T) {
+	t.Parallel()
+
+	address := "unix://" + filepath.Join(t.TempDir(), "plugin.sock")
+	p := &remoteTestPlugin{}
+	stop := serveRemote(t, address, p, pluginoption.WithInsecureUnixSocket())
+
+	_, repo := newRemoteCatalog(t, PluginConfig{Address: address, InsecureUnixSocket: true})
+	callRemote(t, repo)
+	if got := p.configuration(); got != "key: value" {
+		t.Fatalf("expected plugin to be configured, got %q", got)
+	}
+
+	// Restart the plugin, the host reinitializes and reconfigures it.
+	stop()
+	restarted := &remoteTestPlugin{}
+	defer serveRemote(t, address, restarted, pluginoption.WithInsecureUnixSocket())()
+
+	deadline := time.Now().Add(10 * time.Second)
+	for restarted.configuration() == "" {
+		if time.Now().After(deadline) {
+			t.Fatal("expected restarted plugin to be reconfigured")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	callRemote(t, repo)
+}
+
+func TestRemotePluginMutualTLS(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	ca, caKey := writeTestCA(t, dir)
+	writeTestCert(t, dir, "plugin", ca, caKey)
+	writeTestCert(t, dir, "host", ca, caKey)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen(): %v", err)
+	}
+	address := "tcp://" + listener.Addr().String()
+	_ = listener.Close()
+
+	defer serveRemote(t, address, &remoteTestPlugin{}, pluginoption.WithMutualTLS(
+		filepath.Join(dir, "plugin.crt"), filepath.Join(dir, "plugin.key"), filepath.Join(dir, "ca.crt"),
+	))()
+
+	_, repo := newRemoteCatalog(t, PluginConfig{
+		Address: address,
+		TLS: &TLSConfig{
+			CertFile:   filepath.Join(dir, "host.crt"),
+			KeyFile:    filepath.Join(dir, "host.key"),
+			CAFile:     filepath.Join(dir, "ca.crt"),
+			ServerName: "plugin",
+		},
+	})
+	callRemote(t, repo)
+}
+
+func TestRemotePluginRequiresTLS(t *testing.T) {
+	t.Parallel()
+
+	if _, err := remoteCredentials("tcp", nil, true); err == nil {
+		t.Fatal("expected error for TCP without TLS")
+	}
+	if _, err := remoteCredentials("unix", nil, false); err == nil {
+		t.Fatal("expected error for unix sockets without TLS nor opt-in")
+	}
+	if _, err := remoteCredentials("unix", nil, true); err != nil {
+		t.Fatalf("expected unix sockets without TLS to be allowed when opted in, got %v", err)
+	}
+}
+
+func writeTestCA(t *testing.T, dir string) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey(): %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("CreateCertificate(): %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("ParseCertificate(): %v", err)
+	}
+	writePEM(t, filepath.Join(dir, "ca.crt"), "CERTIFICATE", der)
+	return cert, key
+}
+
+func writeTestCert(t *testing.T, dir, name string, ca *x509.Certificate, caKey *ecdsa.PrivateKey) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey(): %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+	if err != nil {
+		t.Fatalf("CreateCertificate(): %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("MarshalECPrivateKey(): %v", err)
+	}
+	writePEM(t, filepath.Join(dir, name+".crt"), "CERTIFICATE", der)
+	writePEM(t, filepath.Join(dir, name+".key"), "EC PRIVATE KEY", keyDER)
+}
+
+func writePEM(t *testing.T, path, typ string, der []byte) {
+	t.Helper()
+
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: der}), 0o600); err != nil {
+		t.Fatalf("WriteFile(): %v", err)
+	}
+}
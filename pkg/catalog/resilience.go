@@ -0,0 +1,412 @@
+package catalog
+
+import (
+	"context"
+	"log/slog"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+const (
+	defaultInitialBackoff    = 100 * time.Millisecond
+	defaultMaxBackoff        = 5 * time.Second
+	defaultBackoffMultiplier = 2.0
+	defaultFailureThreshold  = 5
+	defaultOpenTimeout       = 30 * time.Second
+	defaultHalfOpenProbes    = 1
+)
+
+// ResiliencePolicy configures the client-side policies that are applied to
+// the calls made by the facades to a plugin.
+type ResiliencePolicy struct {
+	// CallPolicy is the default policy for all methods of the plugin.
+	CallPolicy
+
+	// Methods overrides the default policy for specific methods, keyed by
+	// the full gRPC method name (e.g. "/plugin.test.v1.TestService/Test").
+	// Unset fields fall back to the default policy.
+	Methods map[string]CallPolicy
+
+	// CircuitBreaker, if set, stops calls to the plugin after consecutive
+	// failures until the plugin recovers.
+	CircuitBreaker *CircuitBreakerPolicy
+
+	// MaxConcurrentCalls limits the number of calls in flight to the plugin.
+	// Additional calls wait until a slot is free or their context is done.
+	// If zero, there is no limit.
+	MaxConcurrentCalls int
+}
+
+// CallPolicy configures the policy for calls to a plugin method.
+type CallPolicy struct {
+	// Timeout is the deadline applied to a call, including all of its
+	// retries, unless the caller provided an earlier one. If zero, no
+	// deadline is applied.
+	Timeout time.Duration
+
+	// Retry configures retries of failed calls. Only methods that are
+	// idempotent are retried.
+	Retry *RetryPolicy
+
+	// Idempotent marks the method as safe to retry. If nil, the
+	// idempotency_level option of the method definition is used.
+	Idempotent *bool
+}
+
+// RetryPolicy configures retries with exponential backoff.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first
+	// one. Values lower than two disable retries.
+	MaxAttempts int
+
+	// InitialBackoff is the delay before the first retry. Defaults to 100ms.
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the delay between retries. Defaults to 5s.
+	MaxBackoff time.Duration
+
+	// BackoffMultiplier is applied to the delay after every retry.
+	// Defaults to 2.
+	BackoffMultiplier float64
+
+	// RetryableCodes are the status codes that are retried. Defaults to
+	// Unavailable and ResourceExhausted.
+	RetryableCodes []codes.Code
+}
+
+// CircuitBreakerPolicy configures the circuit breaker of a plugin.
+type CircuitBreakerPolicy struct {
+	// FailureThreshold is the number of consecutive failures that opens the
+	// circuit. Defaults to 5.
+	FailureThreshold int
+
+	// OpenTimeout is how long the circuit stays open before probe calls are
+	// let through. Defaults to 30s.
+	OpenTimeout time.Duration
+
+	// HalfOpenProbes is the number of concurrent probe calls allowed while
+	// the circuit is half-open. Defaults to 1.
+	HalfOpenProbes int
+}
+
+// CircuitState is the state of the circuit breaker of a plugin.
+type CircuitState int
+
+const (
+	// CircuitClosed lets all calls through.
+	CircuitClosed CircuitState = iota
+	// CircuitOpen rejects all calls.
+	CircuitOpen
+	// CircuitHalfOpen lets a limited number of probe calls through.
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// forMethod returns the call policy for the given method.
+func (p *ResiliencePolicy) forMethod(method string) CallPolicy {
+	policy := p.CallPolicy
+	override, ok := p.Methods[method]
+	if !ok {
+		return policy
+	}
+	if override.Timeout > 0 {
+		policy.Timeout = override.Timeout
+	}
+	if override.Retry != nil {
+		policy.Retry = override.Retry
+	}
+	if override.Idempotent != nil {
+		policy.Idempotent = override.Idempotent
+	}
+	return policy
+}
+
+func (p CallPolicy) attempts(method string) int {
+	if p.Retry == nil || p.Retry.MaxAttempts < 2 {
+		return 1
+	}
+	if p.Idempotent != nil {
+		if *p.Idempotent {
+			return p.Retry.MaxAttempts
+		}
+		return 1
+	}
+	if isIdempotentMethod(method) {
+		return p.Retry.MaxAttempts
+	}
+	return 1
+}
+
+func (r *RetryPolicy) retryable(err error) bool {
+	retryableCodes := r.RetryableCodes
+	if len(retryableCodes) == 0 {
+		retryableCodes = []codes.Code{codes.Unavailable, codes.ResourceExhausted}
+	}
+	return slices.Contains(retryableCodes, status.Code(err))
+}
+
+// backoff returns the delay before the given retry (starting at 1), with
+// jitter applied.
+func (r *RetryPolicy) backoff(retry int) time.Duration {
+	initial := r.InitialBackoff
+	if initial <= 0 {
+		initial = defaultInitialBackoff
+	}
+	maxBackoff := r.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = defaultMaxBackoff
+	}
+	multiplier := r.BackoffMultiplier
+	if multiplier < 1 {
+		multiplier = defaultBackoffMultiplier
+	}
+
+	backoff := float64(initial)
+	for i := 1; i < retry && backoff < float64(maxBackoff); i++ {
+		backoff *= multiplier
+	}
+	delay := time.Duration(min(backoff, float64(maxBackoff)))
+	return delay/2 + rand.N(delay/2+1)
+}
+
+// isIdempotentMethod reports whether the method definition, if registered,
+// is marked as idempotent or free of side effects.
+func isIdempotentMethod(method string) bool {
+	name := protoreflect.FullName(strings.ReplaceAll(strings.TrimPrefix(method, "/"), "/", "."))
+	desc, err := protoregistry.GlobalFiles.FindDescriptorByName(name)
+	if err != nil {
+		return false
+	}
+	methodDesc, ok := desc.(protoreflect.MethodDescriptor)
+	if !ok {
+		return false
+	}
+	options, ok := methodDesc.Options().(*descriptorpb.MethodOptions)
+	if !ok {
+		return false
+	}
+	return options.GetIdempotencyLevel() != descriptorpb.MethodOptions_IDEMPOTENCY_UNKNOWN
+}
+
+// resilience implements the client interceptors for a ResiliencePolicy.
+type resilience struct {
+	policy  *ResiliencePolicy
+	breaker *circuitBreaker
+	limiter chan struct{}
+}
+
+func newResilience(policy *ResiliencePolicy, log *slog.Logger) *resilience {
+	r := &resilience{policy: policy}
+	if policy.CircuitBreaker != nil {
+		r.breaker = newCircuitBreaker(*policy.CircuitBreaker, log)
+	}
+	if policy.MaxConcurrentCalls > 0 {
+		r.limiter = make(chan struct{}, policy.MaxConcurrentCalls)
+	}
+	return r
+}
+
+func (r *resilience) UnaryClientInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	policy := r.policy.forMethod(method)
+	if policy.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, policy.Timeout)
+		defer cancel()
+	}
+
+	attempts := policy.attempts(method)
+	for attempt := 1; ; attempt++ {
+		err := r.invoke(ctx, method, req, reply, cc, invoker, opts...)
+		if err == nil || attempt >= attempts || !policy.Retry.retryable(err) || r.breaker.State() == CircuitOpen {
+			return err
+		}
+
+		t := time.NewTimer(policy.Retry.backoff(attempt))
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return err
+		case <-t.C:
+		}
+	}
+}
+
+func (r *resilience) StreamClientInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	// Deadlines, retries and concurrency limits do not apply to streams
+	// since their lifetime is controlled by the caller. The circuit breaker
+	// still guards the establishment of the stream.
+	done, err := r.breaker.allow()
+	if err != nil {
+		return nil, err
+	}
+	stream, err := streamer(ctx, desc, cc, method, opts...)
+	done(err)
+	return stream, err
+}
+
+func (r *resilience) invoke(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if r.limiter != nil {
+		select {
+		case r.limiter <- struct{}{}:
+			defer func() { <-r.limiter }()
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		}
+	}
+
+	done, err := r.breaker.allow()
+	if err != nil {
+		return err
+	}
+	err = invoker(ctx, method, req, reply, cc, opts...)
+	done(err)
+	return err
+}
+
+// circuitBreaker is a consecutive failure circuit breaker. A nil breaker lets
+// all calls through.
+type circuitBreaker struct {
+	policy CircuitBreakerPolicy
+	log    *slog.Logger
+	now    func() time.Time
+
+	mu       sync.Mutex
+	state    CircuitState
+	failures int
+	openedAt time.Time
+	probes   int
+}
+
+func newCircuitBreaker(policy CircuitBreakerPolicy, log *slog.Logger) *circuitBreaker {
+	if policy.FailureThreshold <= 0 {
+		policy.FailureThreshold = defaultFailureThreshold
+	}
+	if policy.OpenTimeout <= 0 {
+		policy.OpenTimeout = defaultOpenTimeout
+	}
+	if policy.HalfOpenProbes <= 0 {
+		policy.HalfOpenProbes = defaultHalfOpenProbes
+	}
+	return &circuitBreaker{
+		policy: policy,
+		log:    log,
+		now:    time.Now,
+	}
+}
+
+// State returns the current state of the breaker. An open breaker whose
+// timeout elapsed is reported as half-open.
+func (b *circuitBreaker) State() CircuitState {
+	if b == nil {
+		return CircuitClosed
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == CircuitOpen && b.now().Sub(b.openedAt) >= b.policy.OpenTimeout {
+		return CircuitHalfOpen
+	}
+	return b.state
+}
+
+// allow checks whether a call may proceed. If so, the returned function
+// must be called with the outcome of the call.
+func (b *circuitBreaker) allow() (func(error), error) {
+	if b == nil {
+		return func(error) {}, nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == CircuitOpen {
+		if b.now().Sub(b.openedAt) < b.policy.OpenTimeout {
+			return nil, status.Error(codes.Unavailable, "circuit breaker is open")
+		}
+		b.state = CircuitHalfOpen
+		b.probes = 0
+		b.log.Info("Plugin circuit breaker half-open")
+	}
+
+	if b.state == CircuitHalfOpen {
+		if b.probes >= b.policy.HalfOpenProbes {
+			return nil, status.Error(codes.Unavailable, "circuit breaker is half-open")
+		}
+		b.probes++
+		return b.doneProbe, nil
+	}
+
+	return b.done, nil
+}
+
+func (b *circuitBreaker) done(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state != CircuitClosed {
+		return
+	}
+	if !isFailure(err) {
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.failures >= b.policy.FailureThreshold {
+		b.open()
+	}
+}
+
+func (b *circuitBreaker) doneProbe(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probes--
+	if b.state != CircuitHalfOpen {
+		return
+	}
+	if isFailure(err) {
+		b.open()
+		return
+	}
+	b.state = CircuitClosed
+	b.failures = 0
+	b.log.Info("Plugin circuit breaker closed")
+}
+
+func (b *circuitBreaker) open() {
+	b.state = CircuitOpen
+	b.openedAt = b.now()
+	b.log.Warn("Plugin circuit breaker opened", "failures", b.failures, "open_timeout", b.policy.OpenTimeout)
+}
+
+// isFailure reports whether the error indicates that the plugin is unhealthy
+// as opposed to the request being rejected.
+func isFailure(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.ResourceExhausted, codes.DeadlineExceeded, codes.Internal, codes.Unknown:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,299 @@
+package catalog
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func discardLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(discardWriter{}, nil))
+}
+
+// unwrapConn returns the connection wrapped by the client interceptors.
+func unwrapConn(conn grpc.ClientConnInterface) grpc.ClientConnInterface {
+	if intercepted, ok := conn.(interceptedConn); ok {
+		return intercepted.ClientConnInterface
+	}
+	return conn
+}
+
+func countingInvoker(calls *atomic.Int32, errs ...error) grpc.UnaryInvoker {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		n := int(calls.Add(1))
+		if n <= len(errs) {
+			return errs[n-1]
+		}
+		return nil
+	}
+}
+
+func TestResilienceRetry(t *testing.T) {
+	t.Parallel()
+
+	idempotent := true
+	notIdempotent := false
+	unavailable := status.Error(codes.Unavailable, "unavailable")
+	retry := &RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+
+	tests := []struct {
+		name      string
+		policy    CallPolicy
+		errs      []error
+		wantCalls int32
+		wantCode  codes.Code
+	}{
+		{
+			name:      "idempotent method is retried until success",
+			policy:    CallPolicy{Retry: retry, Idempotent: &idempotent},
+			errs:      []error{unavailable, unavailable},
+			wantCalls: 3,
+			wantCode:  codes.OK,
+		}, {
+			name:      "retries are bounded by max attempts",
+			policy:    CallPolicy{Retry: retry, Idempotent: &idempotent},
+			errs:      []error{unavailable, unavailable, unavailable, unavailable},
+			wantCalls: 3,
+			wantCode:  codes.Unavailable,
+		}, {
+			name:      "non-idempotent method is not retried",
+			policy:    CallPolicy{Retry: retry, Idempotent: &notIdempotent},
+			errs:      []error{unavailable},
+			wantCalls: 1,
+			wantCode:  codes.Unavailable,
+		}, {
+			name:      "unknown idempotency is not retried",
+			policy:    CallPolicy{Retry: retry},
+			errs:      []error{unavailable},
+			wantCalls: 1,
+			wantCode:  codes.Unavailable,
+		}, {
+			name:      "non-retryable code is not retried",
+			policy:    CallPolicy{Retry: retry, Idempotent: &idempotent},
+			errs:      []error{status.Error(codes.NotFound, "not found")},
+			wantCalls: 1,
+			wantCode:  codes.NotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := newResilience(&ResiliencePolicy{CallPolicy: tc.policy}, discardLogger())
+
+			var calls atomic.Int32
+			err := r.UnaryClientInterceptor(context.Background(), "/svc/Method", nil, nil, nil, countingInvoker(&calls, tc.errs...))
+			if got := status.Code(err); got != tc.wantCode {
+				t.Fatalf("expected code %s, got %s", tc.wantCode, got)
+			}
+			if got := calls.Load(); got != tc.wantCalls {
+				t.Fatalf("expected %d calls, got %d", tc.wantCalls, got)
+			}
+		})
+	}
+}
+
+func TestResilienceMethodPolicy(t *testing.T) {
+	t.Parallel()
+
+	policy := &ResiliencePolicy{
+		CallPolicy: CallPolicy{Timeout: time.Hour},
+		Methods: map[string]CallPolicy{
+			"/svc/Fast": {Timeout: time.Millisecond},
+		},
+	}
+	r := newResilience(policy, discardLogger())
+
+	invoker := func(ctx context.Context, _ string, _, _ any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+		<-ctx.Done()
+		return status.FromContextError(ctx.Err()).Err()
+	}
+
+	err := r.UnaryClientInterceptor(context.Background(), "/svc/Fast", nil, nil, nil, invoker)
+	if got := status.Code(err); got != codes.DeadlineExceeded {
+		t.Fatalf("expected DeadlineExceeded, got %s", got)
+	}
+
+	if got := policy.forMethod("/svc/Other").Timeout; got != time.Hour {
+		t.Fatalf("expected default timeout, got %s", got)
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	b := newCircuitBreaker(CircuitBreakerPolicy{FailureThreshold: 2, OpenTimeout: time.Minute}, discardLogger())
+	b.now = func() time.Time { return now }
+
+	fail := status.Error(codes.Unavailable, "down")
+	for range 2 {
+		done, err := b.allow()
+		if err != nil {
+			t.Fatalf("unexpected rejection: %v", err)
+		}
+		done(fail)
+	}
+	if got := b.State(); got != CircuitOpen {
+		t.Fatalf("expected open circuit, got %s", got)
+	}
+	if _, err := b.allow(); status.Code(err) != codes.Unavailable {
+		t.Fatalf("expected call to be rejected, got %v", err)
+	}
+
+	now = now.Add(time.Minute)
+	if got := b.State(); got != CircuitHalfOpen {
+		t.Fatalf("expected half-open circuit, got %s", got)
+	}
+	probe, err := b.allow()
+	if err != nil {
+		t.Fatalf("expected probe to be allowed: %v", err)
+	}
+	if _, err := b.allow(); err == nil {
+		t.Fatal("expected second probe to be rejected")
+	}
+	probe(nil)
+	if got := b.State(); got != CircuitClosed {
+		t.Fatalf("expected closed circuit, got %s", got)
+	}
+
+	t.Run("business errors do not open the circuit", func(t *testing.T) {
+		for range 3 {
+			done, err := b.allow()
+			if err != nil {
+				t.Fatalf("unexpected rejection: %v", err)
+			}
+			done(status.Error(codes.NotFound, "missing"))
+		}
+		if got := b.State(); got != CircuitClosed {
+			t.Fatalf("expected closed circuit, got %s", got)
+		}
+	})
+}
+
+func TestResilienceMaxConcurrentCalls(t *testing.T) {
+	t.Parallel()
+
+	r := newResilience(&ResiliencePolicy{MaxConcurrentCalls: 1}, discardLogger())
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	go func() {
+		_ = r.UnaryClientInterceptor(context.Background(), "/svc/Method", nil, nil, nil,
+			func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error {
+				close(started)
+				<-release
+				return nil
+			})
+	}()
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	var calls atomic.Int32
+	err := r.UnaryClientInterceptor(ctx, "/svc/Method", nil, nil, nil, countingInvoker(&calls))
+	close(release)
+
+	if got := status.Code(err); got != codes.DeadlineExceeded {
+		t.Fatalf("expected DeadlineExceeded, got %s", got)
+	}
+	if calls.Load() != 0 {
+		t.Fatal("expected call to wait for a free slot")
+	}
+}
+
+func TestWithClientInterceptors(t *testing.T) {
+	t.Parallel()
+
+	server := grpc.NewServer()
+	conn, err := startPipeServer(server, discardLogger())
+	if err != nil {
+		t.Fatalf("startPipeServer(): %v", err)
+	}
+	defer conn.Close()
+
+	if got := withClientInterceptors(conn, nil, nil); got != grpc.ClientConnInterface(conn) {
+		t.Fatal("expected connection to be returned as is")
+	}
+
+	var order []string
+	first := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		order = append(order, "first")
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+	second := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		order = append(order, "second")
+		return status.Error(codes.Aborted, "second")
+	}
+	wrapped := withClientInterceptors(conn, []grpc.UnaryClientInterceptor{first, second}, nil)
+
+	err = wrapped.Invoke(context.Background(), "/svc/Method", nil, nil)
+	if got := status.Code(err); got != codes.Aborted {
+		t.Fatalf("expected Aborted, got %s", got)
+	}
+	if len(order) != 2 || order[0] != "first" || order[1] != "second" {
+		t.Fatalf("expected interceptors to run in order, got %v", order)
+	}
+}
+
+// responseStream is a client stream whose messages are received at once.
+type responseStream struct {
+	grpc.ClientStream
+}
+
+func (responseStream) RecvMsg(any) error { return nil }
+
+func TestReleasingStream(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		desc        *grpc.StreamDesc
+		recv        bool
+		cancel      bool
+		wantRelease bool
+	}{
+		{name: "client stream response received", desc: &grpc.StreamDesc{ClientStreams: true}, recv: true, wantRelease: true},
+		{name: "server stream message received", desc: &grpc.StreamDesc{ServerStreams: true}, recv: true},
+		{name: "abandoned stream", desc: &grpc.StreamDesc{ServerStreams: true}},
+		{name: "abandoned stream canceled", desc: &grpc.StreamDesc{ServerStreams: true}, cancel: true, wantRelease: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			released := make(chan struct{})
+			stream := newReleasingStream(ctx, tc.desc, responseStream{}, sync.OnceFunc(func() { close(released) }))
+
+			if tc.recv {
+				if err := stream.RecvMsg(nil); err != nil {
+					t.Fatalf("RecvMsg(): %v", err)
+				}
+			}
+			if tc.cancel {
+				cancel()
+			}
+
+			select {
+			case <-released:
+				if !tc.wantRelease {
+					t.Fatal("expected stream not to be released")
+				}
+			case <-time.After(50 * time.Millisecond):
+				if tc.wantRelease {
+					t.Fatal("expected stream to be released")
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/openkcm/plugin-sdk/internal/bootstrap"
+	brokerv1 "github.com/openkcm/plugin-sdk/internal/proto/service/broker/v1"
+)
+
+// brokerRetryInterval is the delay between attempts to reopen a broker
+// stream.
+const brokerRetryInterval = time.Second
+
+// serveHostServicesOverStreams serves the host services to the plugin over
+// broker streams opened to it on conn, for plugins that cannot be reached
+// through the go-plugin broker. A stream is kept open for as long as the
+// returned closer is not closed.
+func serveHostServicesOverStreams(conn grpc.ClientConnInterface, config PluginConfig) io.Closer {
+	hostNet := newPipeNet()
+	server := newHostServer(config.Logger, config.Name, config.HostServices)
+
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		if err := server.Serve(hostNet); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			config.Logger.Error("Host services server failed", "error", err)
+		}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	wg.Go(func() {
+		brokerStreams(ctx, conn, hostNet, config.Logger)
+	})
+
+	return closerFunc(func() {
+		cancel()
+		if !gracefulStopWithTimeout(server, time.Minute) {
+			config.Logger.Warn("Forced timed-out host service server to stop")
+		}
+		wg.Wait()
+	})
+}
+
+// brokerStreams keeps a broker stream open to the plugin until ctx is done.
+func brokerStreams(ctx context.Context, conn grpc.ClientConnInterface, hostNet *pipeNet, log *slog.Logger) {
+	for {
+		err := brokerStream(ctx, conn, hostNet)
+		switch {
+		case ctx.Err() != nil:
+			return
+		case status.Code(err) == codes.Unimplemented:
+			log.Warn("Plugin does not support host services over broker streams")
+			return
+		}
+		log.Debug("Host services stream ended", "error", err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(brokerRetryInterval):
+		}
+	}
+}
+
+func brokerStream(ctx context.Context, conn grpc.ClientConnInterface, hostNet *pipeNet) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := brokerv1.NewBrokerClient(conn).Connect(ctx, grpc.WaitForReady(true))
+	if err != nil {
+		return err
+	}
+	hostConn, err := hostNet.DialContext(ctx, "")
+	if err != nil {
+		return err
+	}
+	return bootstrap.Tunnel(hostConn, bootstrap.HostFrames(stream))
+}
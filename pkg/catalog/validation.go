@@ -0,0 +1,65 @@
+package catalog
+
+import (
+	"context"
+	"log/slog"
+
+	"buf.build/go/protovalidate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// responseValidator rejects the responses of a plugin that do not satisfy
+// their protovalidate constraints before they reach the facades, e.g. to
+// protect the host from misbehaving third-party plugins.
+type responseValidator struct {
+	validator protovalidate.Validator
+	log       *slog.Logger
+}
+
+func newResponseValidator(log *slog.Logger) *responseValidator {
+	return &responseValidator{validator: protovalidate.GlobalValidator, log: log}
+}
+
+func (v *responseValidator) UnaryClientInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if err := invoker(ctx, method, req, reply, cc, opts...); err != nil {
+		return err
+	}
+	return v.validate(method, reply)
+}
+
+func (v *responseValidator) StreamClientInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	stream, err := streamer(ctx, desc, cc, method, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &validatingClientStream{ClientStream: stream, validator: v, method: method}, nil
+}
+
+func (v *responseValidator) validate(method string, reply any) error {
+	msg, ok := reply.(proto.Message)
+	if !ok {
+		return nil
+	}
+	if err := v.validator.Validate(msg); err != nil {
+		v.log.Warn("Plugin returned an invalid response", "method", method, "error", err)
+		return status.Errorf(codes.Internal, "response validation failed: %v", err)
+	}
+	return nil
+}
+
+type validatingClientStream struct {
+	grpc.ClientStream
+
+	validator *responseValidator
+	method    string
+}
+
+func (s *validatingClientStream) RecvMsg(m any) error {
+	if err := s.ClientStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return s.validator.validate(s.method, m)
+}
@@ -0,0 +1,66 @@
+package catalog
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	systeminformationv1 "github.com/openkcm/plugin-sdk/proto/plugin/systeminformation/v1"
+)
+
+type validationTestStream struct {
+	grpc.ClientStream
+
+	reply *systeminformationv1.GetRequest
+}
+
+func (s *validationTestStream) RecvMsg(m any) error {
+	m.(*systeminformationv1.GetRequest).Id = s.reply.GetId()
+	m.(*systeminformationv1.GetRequest).Type = s.reply.GetType()
+	return nil
+}
+
+func TestResponseValidator(t *testing.T) {
+	t.Parallel()
+
+	valid := &systeminformationv1.GetRequest{Id: "key", Type: "hsm"}
+	invalid := &systeminformationv1.GetRequest{}
+	v := newResponseValidator(discardLogger())
+
+	for _, tt := range []struct {
+		name     string
+		reply    *systeminformationv1.GetRequest
+		wantCode codes.Code
+	}{
+		{name: "valid", reply: valid, wantCode: codes.OK},
+		{name: "invalid", reply: invalid, wantCode: codes.Internal},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			invoker := func(_ context.Context, _ string, _, reply any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+				reply.(*systeminformationv1.GetRequest).Id = tt.reply.GetId()
+				reply.(*systeminformationv1.GetRequest).Type = tt.reply.GetType()
+				return nil
+			}
+			err := v.UnaryClientInterceptor(context.Background(), "/method", nil, &systeminformationv1.GetRequest{}, nil, invoker)
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("expected unary call to fail with %v, got %v", tt.wantCode, err)
+			}
+
+			streamer := func(context.Context, *grpc.StreamDesc, *grpc.ClientConn, string, ...grpc.CallOption) (grpc.ClientStream, error) {
+				return &validationTestStream{reply: tt.reply}, nil
+			}
+			stream, err := v.StreamClientInterceptor(context.Background(), &grpc.StreamDesc{}, nil, "/method", streamer)
+			if err != nil {
+				t.Fatalf("StreamClientInterceptor(): %v", err)
+			}
+			if err := stream.RecvMsg(&systeminformationv1.GetRequest{}); status.Code(err) != tt.wantCode {
+				t.Fatalf("expected stream receive to fail with %v, got %v", tt.wantCode, err)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package plugin
 
 import (
+	"context"
 	"log/slog"
 	"strings"
 
@@ -48,6 +49,21 @@ func (f *Facade) InitLog(log *slog.Logger) {
 	f.Log = log
 }
 
+// LogCall logs at debug level that the given gRPC method of the plugin is
+// called with the given request, if any. It is used by the generated facades
+// before each call. The sensitive fields of the generated messages are
+// redacted when logged.
+func (f *Facade) LogCall(ctx context.Context, method string, req any) {
+	if f.Log == nil || !f.Log.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+	args := []any{"plugin_name", f.Name(), "plugin_type", f.Type(), "method", method}
+	if req != nil {
+		args = append(args, "request", req)
+	}
+	f.Log.DebugContext(ctx, "Calling plugin", args...)
+}
+
 // WrapErr wraps a given error such that it will be prefixed with the plugin
 // name. This method should be used by facade implementations to wrap errors
 // that come out of plugin implementations.
@@ -145,3 +161,11 @@ func (info pluginFacadeInfo) Build() string {
 func (info pluginFacadeInfo) Version() uint {
 	return info.version
 }
+
+func (info pluginFacadeInfo) SDKVersion() string {
+	return ""
+}
+
+func (info pluginFacadeInfo) Capabilities() api.Capabilities {
+	return api.Capabilities{}
+}
@@ -25,3 +25,13 @@ func ServeOptions(options ...pluginoption.ServerOption) error {
 
 	return bootstrap.Serve(opts...)
 }
+
+// ServeRemote serves the plugin on the given address, either
+// unix:///path/to/socket or tcp://host:port, for hosts that connect to an
+// already running plugin instead of launching it. Plugins must be configured
+// with pluginoption.WithMutualTLS or pluginoption.WithTLSConfig, unless served
+// over a Unix socket with pluginoption.WithInsecureUnixSocket. It returns when
+// the process is interrupted or terminated.
+func ServeRemote(address string, options ...pluginoption.ServerOption) error {
+	return bootstrap.ServeRemote(address, options...)
+}
@@ -0,0 +1,95 @@
+package plugintest
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/openkcm/plugin-sdk/api"
+	"github.com/openkcm/plugin-sdk/pkg/catalog"
+)
+
+// BuildAndLoad builds the plugin binary of the given package with go build
+// into a temporary directory and loads it as an external plugin with
+// catalog.New, so that the handshake, the environment and the process of the
+// plugin are tested end to end. The path and the checksum of the plugin
+// configuration are set to the built binary, its name defaults to
+// DefaultName and its type to the type of the facade F. The catalog and the
+// facade the plugin is bound to are returned, the catalog is closed when the
+// test ends. Only the HostServices and Logger options apply.
+func BuildAndLoad[F api.Facade](t testing.TB, pkg string, pluginConfig catalog.PluginConfig, opts ...Option) (*catalog.Catalog, F) {
+	t.Helper()
+
+	cfg := newConfig(opts)
+	if pluginConfig.Name == "" {
+		pluginConfig.Name = DefaultName
+	}
+	facade := facadeVersion[F]{}.New()
+	if client, ok := facade.(api.PluginClient); ok && pluginConfig.Type == "" {
+		pluginConfig.Type = client.Type()
+	}
+	pluginConfig.Path = Build(t, pkg)
+	checksum, err := fileChecksum(pluginConfig.Path)
+	if err != nil {
+		t.Fatalf("Failed to compute the checksum of plugin %q: %v", pluginConfig.Name, err)
+	}
+	pluginConfig.Checksum = checksum
+
+	repo := &facadeRepo[F]{}
+	cat, err := catalog.New(context.Background(), catalog.Config{
+		Logger:        cfg.logger,
+		PluginConfigs: []catalog.PluginConfig{pluginConfig},
+		HostServices:  cfg.hostServices,
+	}, repository{plugins: map[string]api.PluginRepo{pluginConfig.Type: repo}})
+	if err != nil {
+		t.Fatalf("Failed to load plugin %q: %v", pluginConfig.Name, err)
+	}
+	t.Cleanup(func() {
+		if err := cat.Close(); err != nil {
+			t.Errorf("Failed to close plugin %q: %v", pluginConfig.Name, err)
+		}
+	})
+	return cat, repo.facade
+}
+
+// Build builds the plugin binary of the given package with go build into a
+// temporary directory removed when the test ends, and returns its path. The
+// package must be a single main package, package patterns are rejected.
+func Build(t testing.TB, pkg string) string {
+	t.Helper()
+
+	if strings.Contains(pkg, "...") {
+		t.Fatalf("Failed to build plugin %q: package patterns are not supported", pkg)
+	}
+	name := "plugin"
+	if runtime.GOOS == "windows" {
+		name += ".exe"
+	}
+	path := filepath.Join(t.TempDir(), name)
+	cmd := exec.CommandContext(t.Context(), "go", "build", "-buildvcs=false", "-o", path, pkg)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("Failed to build plugin %q: %v\n%s", pkg, err, output)
+	}
+	return path
+}
+
+func fileChecksum(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
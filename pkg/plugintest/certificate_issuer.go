@@ -0,0 +1,178 @@
+package plugintest
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	certificateissuerv1 "github.com/openkcm/plugin-sdk/proto/plugin/certificate_issuer/v1"
+)
+
+const (
+	// DefaultCommonName is the common name of the certificates requested by
+	// the certificate issuer conformance suite, unless set otherwise.
+	DefaultCommonName = "conformance.openkcm.io"
+
+	// DefaultLocality is the locality of the certificates requested by the
+	// certificate issuer conformance suite, unless set otherwise.
+	DefaultLocality = "conformance"
+
+	// validityTolerance is the tolerated difference between the requested
+	// and the actual validity of a certificate, e.g. when its start is
+	// backdated.
+	validityTolerance = 24 * time.Hour
+)
+
+// CertificateIssuerSuite is the conformance suite of the
+// CertificateIssuerService plugins. It requests a certificate and checks
+// that the returned chain is made of parsable PEM certificates, that the
+// requested validity is honoured and that the common name and the locality
+// are present in the subject of the leaf certificate.
+type CertificateIssuerSuite struct {
+	// CommonName is the common name of the requested certificate,
+	// DefaultCommonName by default.
+	CommonName string
+
+	// Locality is the locality of the requested certificate,
+	// DefaultLocality by default.
+	Locality []string
+
+	// Validity is the validity of the requested certificate, 30 days by
+	// default.
+	Validity *certificateissuerv1.GetCertificateValidity
+
+	// PrivateKey is the private key of the requested certificate. By
+	// default, an ECDSA P-256 key is generated and sent PEM encoded in
+	// PKCS #8 form.
+	PrivateKey *certificateissuerv1.PrivateKey
+}
+
+// Run runs the suite against the plugin bound to the facade, e.g. loaded
+// with Load, and returns the report of its checks.
+func (s CertificateIssuerSuite) Run(ctx context.Context, plugin *certificateissuerv1.CertificateIssuerServiceFacade) Report {
+	if s.CommonName == "" {
+		s.CommonName = DefaultCommonName
+	}
+	if len(s.Locality) == 0 {
+		s.Locality = []string{DefaultLocality}
+	}
+	if s.Validity == nil {
+		s.Validity = &certificateissuerv1.GetCertificateValidity{
+			Value: 30,
+			Type:  certificateissuerv1.ValidityType_VALIDITY_TYPE_DAYS,
+		}
+	}
+
+	var report Report
+	leaf, err := s.getCertificate(ctx, plugin)
+	if !report.check("GetCertificate", err) {
+		report.skip("Validity", "Subject")
+		return report
+	}
+	report.check("Validity", s.checkValidity(leaf))
+	report.check("Subject", s.checkSubject(leaf))
+	return report
+}
+
+// getCertificate requests a certificate and returns the leaf of the chain.
+func (s CertificateIssuerSuite) getCertificate(ctx context.Context, plugin *certificateissuerv1.CertificateIssuerServiceFacade) (*x509.Certificate, error) {
+	privateKey := s.PrivateKey
+	if privateKey == nil {
+		var err error
+		if privateKey, err = generatePrivateKey(); err != nil {
+			return nil, fmt.Errorf("failed to generate private key: %w", err)
+		}
+	}
+
+	resp, err := plugin.GetCertificate(ctx, &certificateissuerv1.GetCertificateRequest{
+		CommonName: s.CommonName,
+		Locality:   s.Locality,
+		Validity:   s.Validity,
+		PrivateKey: privateKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+	chain, err := parseCertificateChain([]byte(resp.GetCertificateChain()))
+	if err != nil {
+		return nil, err
+	}
+	return chain[0], nil
+}
+
+func (s CertificateIssuerSuite) checkValidity(leaf *x509.Certificate) error {
+	var want time.Time
+	switch s.Validity.GetType() {
+	case certificateissuerv1.ValidityType_VALIDITY_TYPE_DAYS:
+		want = leaf.NotBefore.AddDate(0, 0, int(s.Validity.GetValue()))
+	case certificateissuerv1.ValidityType_VALIDITY_TYPE_MONTHS:
+		want = leaf.NotBefore.AddDate(0, int(s.Validity.GetValue()), 0)
+	case certificateissuerv1.ValidityType_VALIDITY_TYPE_YEARS:
+		want = leaf.NotBefore.AddDate(int(s.Validity.GetValue()), 0, 0)
+	default:
+		return fmt.Errorf("unsupported validity type %s", s.Validity.GetType())
+	}
+
+	if diff := leaf.NotAfter.Sub(want).Abs(); diff > validityTolerance {
+		return fmt.Errorf("certificate valid from %s to %s, want until %s", leaf.NotBefore, leaf.NotAfter, want)
+	}
+	return nil
+}
+
+func (s CertificateIssuerSuite) checkSubject(leaf *x509.Certificate) error {
+	if leaf.Subject.CommonName != s.CommonName {
+		return fmt.Errorf("got common name %q, want %q", leaf.Subject.CommonName, s.CommonName)
+	}
+	for _, locality := range s.Locality {
+		if !slices.Contains(leaf.Subject.Locality, locality) {
+			return fmt.Errorf("locality %q missing from %q", locality, leaf.Subject.Locality)
+		}
+	}
+	return nil
+}
+
+// parseCertificateChain parses the PEM encoded certificates of the chain,
+// starting with the leaf.
+func parseCertificateChain(data []byte) ([]*x509.Certificate, error) {
+	var chain []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			return nil, fmt.Errorf("unexpected PEM block %q in the certificate chain", block.Type)
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse certificate %d of the chain: %w", len(chain), err)
+		}
+		chain = append(chain, cert)
+	}
+	if len(chain) == 0 {
+		return nil, errors.New("no PEM certificate in the certificate chain")
+	}
+	return chain, nil
+}
+
+func generatePrivateKey() (*certificateissuerv1.PrivateKey, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return &certificateissuerv1.PrivateKey{
+		Data: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}),
+	}, nil
+}
@@ -0,0 +1,101 @@
+package plugintest
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errSkipped is the error of the checks not run because a check they depend
+// on failed.
+var errSkipped = errors.New("skipped, a check it depends on failed")
+
+// Report is the result of a conformance suite, one check per behaviour of the
+// plugin contract.
+type Report struct {
+	Checks []Check
+}
+
+// Check is the result of a conformance check. It passed if Err is nil.
+type Check struct {
+	Name string
+	Err  error
+}
+
+// Passed reports whether all the checks passed.
+func (r Report) Passed() bool {
+	return len(r.Failed()) == 0
+}
+
+// Failed returns the checks that did not pass.
+func (r Report) Failed() []Check {
+	var failed []Check
+	for _, check := range r.Checks {
+		if check.Err != nil {
+			failed = append(failed, check)
+		}
+	}
+	return failed
+}
+
+// String formats the report, one line per check.
+func (r Report) String() string {
+	var sb strings.Builder
+	for _, check := range r.Checks {
+		if check.Err != nil {
+			fmt.Fprintf(&sb, "FAIL %s: %v\n", check.Name, check.Err)
+		} else {
+			fmt.Fprintf(&sb, "PASS %s\n", check.Name)
+		}
+	}
+	return sb.String()
+}
+
+// Assert fails the test with the failed checks of the report, if any.
+func (r Report) Assert(t testing.TB) {
+	t.Helper()
+	for _, check := range r.Failed() {
+		t.Errorf("Conformance check %s failed: %v", check.Name, check.Err)
+	}
+}
+
+// check records the result of the named check and returns whether it passed.
+func (r *Report) check(name string, err error) bool {
+	r.Checks = append(r.Checks, Check{Name: name, Err: err})
+	return err == nil
+}
+
+// skip records the named checks as skipped.
+func (r *Report) skip(names ...string) {
+	for _, name := range names {
+		r.check(name, errSkipped)
+	}
+}
+
+// hasStatus reports whether the error has the code and the message of the
+// given status. The message may be prefixed by the facade with the plugin
+// name.
+func hasStatus(err error, st *status.Status) bool {
+	actual := status.Convert(err)
+	return actual.Code() == st.Code() && strings.HasSuffix(actual.Message(), st.Message())
+}
+
+// wantStatus returns an error unless the error has the given status.
+func wantStatus(err error, st *status.Status) error {
+	if hasStatus(err, st) {
+		return nil
+	}
+	return fmt.Errorf("got error %v, want %s: %s", err, st.Code(), st.Message())
+}
+
+// wantCode returns an error unless the error has the given code.
+func wantCode(err error, code codes.Code) error {
+	if status.Code(err) == code {
+		return nil
+	}
+	return fmt.Errorf("got error %v, want %s", err, code)
+}
@@ -0,0 +1,241 @@
+package plugintest_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/openkcm/plugin-sdk/pkg/plugintest"
+	certificateissuerv1 "github.com/openkcm/plugin-sdk/proto/plugin/certificate_issuer/v1"
+	identitymanagementv1 "github.com/openkcm/plugin-sdk/proto/plugin/identity_management/v1"
+	notificationv1 "github.com/openkcm/plugin-sdk/proto/plugin/notification/v1"
+)
+
+func failedChecks(report plugintest.Report) string {
+	var failed []string
+	for _, check := range report.Failed() {
+		failed = append(failed, check.Name)
+	}
+	return strings.Join(failed, ",")
+}
+
+// directory is an identity management plugin serving a fixed directory,
+// keyed by the IDs of the groups.
+type directory struct {
+	identitymanagementv1.UnimplementedIdentityManagementServiceServer
+
+	members map[string][]string
+	// lostGroups are the groups the users do not know they are members of.
+	lostGroups []string
+}
+
+func (d *directory) GetUser(_ context.Context, req *identitymanagementv1.GetUserRequest) (*identitymanagementv1.GetUserResponse, error) {
+	for _, members := range d.members {
+		if slices.Contains(members, req.GetUserId()) {
+			return &identitymanagementv1.GetUserResponse{User: &identitymanagementv1.User{Id: req.GetUserId()}}, nil
+		}
+	}
+	return nil, status.Error(codes.NotFound, "user not found")
+}
+
+func (d *directory) GetGroup(_ context.Context, req *identitymanagementv1.GetGroupRequest) (*identitymanagementv1.GetGroupResponse, error) {
+	if _, ok := d.members["id-"+req.GetGroupName()]; !ok {
+		return nil, status.Error(codes.NotFound, "group not found")
+	}
+	return &identitymanagementv1.GetGroupResponse{Group: &identitymanagementv1.Group{Id: "id-" + req.GetGroupName(), Name: req.GetGroupName()}}, nil
+}
+
+func (d *directory) GetAllGroups(context.Context, *identitymanagementv1.GetAllGroupsRequest) (*identitymanagementv1.GetAllGroupsResponse, error) {
+	resp := &identitymanagementv1.GetAllGroupsResponse{}
+	for id := range d.members {
+		resp.Groups = append(resp.Groups, &identitymanagementv1.Group{Id: id, Name: strings.TrimPrefix(id, "id-")})
+	}
+	return resp, nil
+}
+
+func (d *directory) GetUsersForGroup(_ context.Context, req *identitymanagementv1.GetUsersForGroupRequest) (*identitymanagementv1.GetUsersForGroupResponse, error) {
+	resp := &identitymanagementv1.GetUsersForGroupResponse{}
+	for _, member := range d.members[req.GetGroupId()] {
+		resp.Users = append(resp.Users, &identitymanagementv1.User{Id: member})
+	}
+	return resp, nil
+}
+
+func (d *directory) GetGroupsForUser(_ context.Context, req *identitymanagementv1.GetGroupsForUserRequest) (*identitymanagementv1.GetGroupsForUserResponse, error) {
+	resp := &identitymanagementv1.GetGroupsForUserResponse{}
+	for id, members := range d.members {
+		if slices.Contains(members, req.GetUserId()) && !slices.Contains(d.lostGroups, id) {
+			resp.Groups = append(resp.Groups, &identitymanagementv1.Group{Id: id})
+		}
+	}
+	return resp, nil
+}
+
+func TestIdentityManagementSuite(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		lostGroups []string
+		wantFailed string
+	}{
+		{name: "consistent"},
+		{name: "inconsistent", lostGroups: []string{"id-admins"}, wantFailed: "UsersForGroup"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			plugin := plugintest.Load[*identitymanagementv1.IdentityManagementServiceFacade](t,
+				identitymanagementv1.IdentityManagementServicePluginServer(&directory{
+					members: map[string][]string{
+						"id-admins":  {"alice", "bob"},
+						"id-readers": {"bob"},
+					},
+					lostGroups: tc.lostGroups,
+				}))
+			suite := plugintest.IdentityManagementSuite{UserID: "alice", GroupName: "admins"}
+
+			// Act
+			report := suite.Run(t.Context(), plugin)
+
+			// Assert
+			if got := failedChecks(report); got != tc.wantFailed {
+				t.Fatalf("failed checks %q, want %q:\n%s", got, tc.wantFailed, report)
+			}
+		})
+	}
+}
+
+// notifier is a notification plugin accepting email recipients only.
+type notifier struct {
+	notificationv1.UnimplementedNotificationServiceServer
+
+	// silent reports unsuccessful notifications without message.
+	silent bool
+}
+
+func (n *notifier) SendNotification(_ context.Context, req *notificationv1.SendNotificationRequest) (*notificationv1.SendNotificationResponse, error) {
+	if req.GetNotificationType() != notificationv1.NotificationType_NOTIFICATION_TYPE_EMAIL {
+		return nil, status.Error(codes.InvalidArgument, "unsupported notification type")
+	}
+	if len(req.GetRecipients()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "no recipients")
+	}
+	for _, recipient := range req.GetRecipients() {
+		if !strings.Contains(recipient, "@") {
+			resp := &notificationv1.SendNotificationResponse{}
+			if !n.silent {
+				resp.Message = "invalid recipient " + recipient
+			}
+			return resp, nil
+		}
+	}
+	return &notificationv1.SendNotificationResponse{Success: true}, nil
+}
+
+func TestNotificationSuite(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		silent     bool
+		wantFailed string
+	}{
+		{name: "explained failures"},
+		{name: "silent failures", silent: true, wantFailed: "InvalidRecipient"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			plugin := plugintest.Load[*notificationv1.NotificationServiceFacade](t,
+				notificationv1.NotificationServicePluginServer(&notifier{silent: tc.silent}))
+			suite := plugintest.NotificationSuite{Recipients: []string{"alice@example.com"}}
+
+			// Act
+			report := suite.Run(t.Context(), plugin)
+
+			// Assert
+			if got := failedChecks(report); got != tc.wantFailed {
+				t.Fatalf("failed checks %q, want %q:\n%s", got, tc.wantFailed, report)
+			}
+		})
+	}
+}
+
+// issuer is a certificate issuer plugin self-signing certificates.
+type issuer struct {
+	certificateissuerv1.UnimplementedCertificateIssuerServiceServer
+
+	// validity overrides the requested validity, if set.
+	validity time.Duration
+}
+
+func (i *issuer) GetCertificate(_ context.Context, req *certificateissuerv1.GetCertificateRequest) (*certificateissuerv1.GetCertificateResponse, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	notBefore := time.Now().Add(-time.Hour)
+	notAfter := notBefore.AddDate(0, 0, int(req.GetValidity().GetValue()))
+	if i.validity != 0 {
+		notAfter = notBefore.Add(i.validity)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: req.GetCommonName(), Locality: req.GetLocality()},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, err
+	}
+	chain := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	return &certificateissuerv1.GetCertificateResponse{CertificateChain: string(chain)}, nil
+}
+
+func TestCertificateIssuerSuite(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		validity   time.Duration
+		wantFailed string
+	}{
+		{name: "honoured validity"},
+		{name: "fixed validity", validity: 365 * 24 * time.Hour, wantFailed: "Validity"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			plugin := plugintest.Load[*certificateissuerv1.CertificateIssuerServiceFacade](t,
+				certificateissuerv1.CertificateIssuerServicePluginServer(&issuer{validity: tc.validity}))
+
+			// Act
+			report := plugintest.CertificateIssuerSuite{}.Run(t.Context(), plugin)
+
+			// Assert
+			if got := failedChecks(report); got != tc.wantFailed {
+				t.Fatalf("failed checks %q, want %q:\n%s", got, tc.wantFailed, report)
+			}
+		})
+	}
+}
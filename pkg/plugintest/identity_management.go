@@ -0,0 +1,161 @@
+package plugintest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+
+	identitymanagementv1 "github.com/openkcm/plugin-sdk/proto/plugin/identity_management/v1"
+)
+
+// DefaultUnknownID is the ID, or name, of the missing users and groups looked
+// up by the conformance suites.
+const DefaultUnknownID = "conformance-unknown"
+
+// IdentityManagementSuite is the conformance suite of the
+// IdentityManagementService plugins. It gets a known user and group, checks
+// that the groups of the user and the users of the group are consistent with
+// each other, and that missing users and groups are reported with
+// codes.NotFound.
+type IdentityManagementSuite struct {
+	// AuthContext is the authentication context sent with the requests.
+	AuthContext *identitymanagementv1.AuthContext
+
+	// UserID is the ID of a known user, member of the group GroupName.
+	UserID string
+
+	// GroupName is the name of a known group.
+	GroupName string
+
+	// UnknownID is the ID of a missing user and the name of a missing group,
+	// DefaultUnknownID by default.
+	UnknownID string
+}
+
+// Run runs the suite against the plugin bound to the facade, e.g. loaded
+// with Load, and returns the report of its checks.
+func (s IdentityManagementSuite) Run(ctx context.Context, plugin *identitymanagementv1.IdentityManagementServiceFacade) Report {
+	if s.UnknownID == "" {
+		s.UnknownID = DefaultUnknownID
+	}
+
+	var report Report
+	report.check("GetUser", s.checkGetUser(ctx, plugin))
+	group, err := s.getGroup(ctx, plugin)
+	if report.check("GetGroup", err) {
+		report.check("GetAllGroups", s.checkGetAllGroups(ctx, plugin, group))
+		report.check("UsersForGroup", s.checkUsersForGroup(ctx, plugin, group))
+	} else {
+		report.skip("GetAllGroups", "UsersForGroup")
+	}
+	report.check("GroupsForUser", s.checkGroupsForUser(ctx, plugin))
+	report.check("UserNotFound", s.checkUserNotFound(ctx, plugin))
+	report.check("GroupNotFound", s.checkGroupNotFound(ctx, plugin))
+	return report
+}
+
+func (s IdentityManagementSuite) checkGetUser(ctx context.Context, plugin *identitymanagementv1.IdentityManagementServiceFacade) error {
+	resp, err := plugin.GetUser(ctx, &identitymanagementv1.GetUserRequest{UserId: s.UserID, AuthContext: s.AuthContext})
+	switch {
+	case err != nil:
+		return err
+	case resp.GetUser().GetId() != s.UserID:
+		return fmt.Errorf("got user %q, want %q", resp.GetUser().GetId(), s.UserID)
+	}
+	return nil
+}
+
+func (s IdentityManagementSuite) getGroup(ctx context.Context, plugin *identitymanagementv1.IdentityManagementServiceFacade) (*identitymanagementv1.Group, error) {
+	resp, err := plugin.GetGroup(ctx, &identitymanagementv1.GetGroupRequest{GroupName: s.GroupName, AuthContext: s.AuthContext})
+	switch {
+	case err != nil:
+		return nil, err
+	case resp.GetGroup().GetName() != s.GroupName:
+		return nil, fmt.Errorf("got group %q, want %q", resp.GetGroup().GetName(), s.GroupName)
+	case resp.GetGroup().GetId() == "":
+		return nil, errors.New("group has no ID")
+	}
+	return resp.GetGroup(), nil
+}
+
+func (s IdentityManagementSuite) checkGetAllGroups(ctx context.Context, plugin *identitymanagementv1.IdentityManagementServiceFacade, group *identitymanagementv1.Group) error {
+	resp, err := plugin.GetAllGroups(ctx, &identitymanagementv1.GetAllGroupsRequest{AuthContext: s.AuthContext})
+	switch {
+	case err != nil:
+		return err
+	case !containsGroup(resp.GetGroups(), group.GetId()):
+		return fmt.Errorf("group %q missing from all the groups", group.GetId())
+	}
+	return nil
+}
+
+// checkUsersForGroup checks that the known user is a member of the group,
+// and that each member of the group has the group among its groups.
+func (s IdentityManagementSuite) checkUsersForGroup(ctx context.Context, plugin *identitymanagementv1.IdentityManagementServiceFacade, group *identitymanagementv1.Group) error {
+	resp, err := plugin.GetUsersForGroup(ctx, &identitymanagementv1.GetUsersForGroupRequest{GroupId: group.GetId(), AuthContext: s.AuthContext})
+	if err != nil {
+		return err
+	}
+	if !containsUser(resp.GetUsers(), s.UserID) {
+		return fmt.Errorf("user %q missing from the users of group %q", s.UserID, group.GetId())
+	}
+	for _, user := range resp.GetUsers() {
+		groups, err := plugin.GetGroupsForUser(ctx, &identitymanagementv1.GetGroupsForUserRequest{UserId: user.GetId(), AuthContext: s.AuthContext})
+		if err != nil {
+			return fmt.Errorf("failed to get the groups of user %q: %w", user.GetId(), err)
+		}
+		if !containsGroup(groups.GetGroups(), group.GetId()) {
+			return fmt.Errorf("group %q missing from the groups of its user %q", group.GetId(), user.GetId())
+		}
+	}
+	return nil
+}
+
+// checkGroupsForUser checks that the known user is a member of each of its
+// groups.
+func (s IdentityManagementSuite) checkGroupsForUser(ctx context.Context, plugin *identitymanagementv1.IdentityManagementServiceFacade) error {
+	resp, err := plugin.GetGroupsForUser(ctx, &identitymanagementv1.GetGroupsForUserRequest{UserId: s.UserID, AuthContext: s.AuthContext})
+	if err != nil {
+		return err
+	}
+	for _, group := range resp.GetGroups() {
+		users, err := plugin.GetUsersForGroup(ctx, &identitymanagementv1.GetUsersForGroupRequest{GroupId: group.GetId(), AuthContext: s.AuthContext})
+		if err != nil {
+			return fmt.Errorf("failed to get the users of group %q: %w", group.GetId(), err)
+		}
+		if !containsUser(users.GetUsers(), s.UserID) {
+			return fmt.Errorf("user %q missing from the users of its group %q", s.UserID, group.GetId())
+		}
+	}
+	return nil
+}
+
+func (s IdentityManagementSuite) checkUserNotFound(ctx context.Context, plugin *identitymanagementv1.IdentityManagementServiceFacade) error {
+	_, err := plugin.GetUser(ctx, &identitymanagementv1.GetUserRequest{UserId: s.UnknownID, AuthContext: s.AuthContext})
+	return wantCode(err, codes.NotFound)
+}
+
+func (s IdentityManagementSuite) checkGroupNotFound(ctx context.Context, plugin *identitymanagementv1.IdentityManagementServiceFacade) error {
+	_, err := plugin.GetGroup(ctx, &identitymanagementv1.GetGroupRequest{GroupName: s.UnknownID, AuthContext: s.AuthContext})
+	return wantCode(err, codes.NotFound)
+}
+
+func containsUser(users []*identitymanagementv1.User, id string) bool {
+	for _, user := range users {
+		if user.GetId() == id {
+			return true
+		}
+	}
+	return false
+}
+
+func containsGroup(groups []*identitymanagementv1.Group, id string) bool {
+	for _, group := range groups {
+		if group.GetId() == id {
+			return true
+		}
+	}
+	return false
+}
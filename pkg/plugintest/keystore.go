@@ -0,0 +1,239 @@
+package plugintest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	keystoreErrs "github.com/openkcm/plugin-sdk/pkg/plugin/keystore/errors"
+	commonv1 "github.com/openkcm/plugin-sdk/proto/plugin/keystore/common/v1"
+	operationsv1 "github.com/openkcm/plugin-sdk/proto/plugin/keystore/operations/v1"
+)
+
+// DefaultUnknownKeyID is the ID of the key looked up by the keystore
+// conformance suite to check that missing keys are not found.
+const DefaultUnknownKeyID = "conformance-unknown-key"
+
+// KeystoreOperationSuite is the conformance suite of the
+// KeystoreInstanceKeyOperation plugins. It creates, gets, disables, enables
+// and deletes a key, round trips the import parameters of a BYOK key,
+// validates key attributes, and checks that missing keys and invalid key
+// access data are reported with the statuses of the keystore errors package.
+type KeystoreOperationSuite struct {
+	// Config is the keystore instance configuration sent with the requests.
+	Config *commonv1.KeystoreInstanceConfig
+
+	// Algorithm is the algorithm of the created keys, AES256 by default.
+	Algorithm operationsv1.KeyAlgorithm
+
+	// Region is the region the keys are created and validated in.
+	Region string
+
+	// EnabledStatus and DisabledStatus are the statuses reported by GetKey
+	// for enabled and disabled keys. If empty, the statuses only have to
+	// differ.
+	EnabledStatus  string
+	DisabledStatus string
+
+	// UnknownKeyID is the ID of a missing key, DefaultUnknownKeyID by
+	// default.
+	UnknownKeyID string
+
+	// EncryptKeyMaterial, if set, returns key material encrypted with the
+	// given import parameters, which is then imported into a BYOK key.
+	// Otherwise, no key material is imported.
+	EncryptKeyMaterial func(importParameters *structpb.Struct) (string, error)
+}
+
+// Run runs the suite against the plugin bound to the facade, e.g. loaded
+// with Load, and returns the report of its checks.
+func (s KeystoreOperationSuite) Run(ctx context.Context, plugin *operationsv1.KeystoreInstanceKeyOperationFacade) Report {
+	if s.Algorithm == operationsv1.KeyAlgorithm_KEY_ALGORITHM_UNSPECIFIED {
+		s.Algorithm = operationsv1.KeyAlgorithm_KEY_ALGORITHM_AES256
+	}
+	if s.UnknownKeyID == "" {
+		s.UnknownKeyID = DefaultUnknownKeyID
+	}
+
+	var report Report
+	s.checkLifecycle(ctx, plugin, &report)
+	report.check("KeyNotFound", s.checkKeyNotFound(ctx, plugin))
+	report.check("ImportParameters", s.checkImportParameters(ctx, plugin))
+	report.check("ValidateKey", s.checkValidateKey(ctx, plugin))
+	report.check("InvalidKeyAccessData", s.checkInvalidKeyAccessData(ctx, plugin))
+	return report
+}
+
+func (s KeystoreOperationSuite) parameters(keyID string) *operationsv1.RequestParameters {
+	return &operationsv1.RequestParameters{Config: s.Config, KeyId: keyID}
+}
+
+func (s KeystoreOperationSuite) createKey(ctx context.Context, plugin *operationsv1.KeystoreInstanceKeyOperationFacade, keyType operationsv1.KeyType) (string, error) {
+	resp, err := plugin.CreateKey(ctx, &operationsv1.CreateKeyRequest{
+		Config:    s.Config,
+		Algorithm: s.Algorithm,
+		Region:    s.Region,
+		KeyType:   keyType,
+	})
+	if err != nil {
+		return "", err
+	}
+	if resp.GetKeyId() == "" {
+		return "", errors.New("created key has no ID")
+	}
+	return resp.GetKeyId(), nil
+}
+
+func (s KeystoreOperationSuite) keyStatus(ctx context.Context, plugin *operationsv1.KeystoreInstanceKeyOperationFacade, keyID string) (string, error) {
+	resp, err := plugin.GetKey(ctx, &operationsv1.GetKeyRequest{Parameters: s.parameters(keyID)})
+	if err != nil {
+		return "", err
+	}
+	return resp.GetStatus(), nil
+}
+
+// checkLifecycle checks the lifecycle of a system managed key, each step
+// depending on the previous ones.
+func (s KeystoreOperationSuite) checkLifecycle(ctx context.Context, plugin *operationsv1.KeystoreInstanceKeyOperationFacade, report *Report) {
+	keyID, err := s.createKey(ctx, plugin, operationsv1.KeyType_KEY_TYPE_SYSTEM_MANAGED)
+	if !report.check("CreateKey", err) {
+		report.skip("GetKey", "DisableKey", "EnableKey", "DeleteKey")
+		return
+	}
+
+	if !report.check("GetKey", s.checkGetKey(ctx, plugin, keyID)) {
+		report.skip("DisableKey", "EnableKey")
+	} else {
+		disabledStatus, err := s.checkDisableKey(ctx, plugin, keyID)
+		if report.check("DisableKey", err) {
+			report.check("EnableKey", s.checkEnableKey(ctx, plugin, keyID, disabledStatus))
+		} else {
+			report.skip("EnableKey")
+		}
+	}
+
+	_, err = plugin.DeleteKey(ctx, &operationsv1.DeleteKeyRequest{Parameters: s.parameters(keyID)})
+	report.check("DeleteKey", err)
+}
+
+func (s KeystoreOperationSuite) checkGetKey(ctx context.Context, plugin *operationsv1.KeystoreInstanceKeyOperationFacade, keyID string) error {
+	resp, err := plugin.GetKey(ctx, &operationsv1.GetKeyRequest{Parameters: s.parameters(keyID)})
+	switch {
+	case err != nil:
+		return err
+	case resp.GetKeyId() != keyID:
+		return fmt.Errorf("got key %q, want %q", resp.GetKeyId(), keyID)
+	case resp.GetAlgorithm() != s.Algorithm:
+		return fmt.Errorf("got algorithm %s, want %s", resp.GetAlgorithm(), s.Algorithm)
+	case resp.GetStatus() == "":
+		return errors.New("key has no status")
+	case s.EnabledStatus != "" && resp.GetStatus() != s.EnabledStatus:
+		return fmt.Errorf("created key has status %q, want %q", resp.GetStatus(), s.EnabledStatus)
+	}
+	return nil
+}
+
+func (s KeystoreOperationSuite) checkDisableKey(ctx context.Context, plugin *operationsv1.KeystoreInstanceKeyOperationFacade, keyID string) (string, error) {
+	if _, err := plugin.DisableKey(ctx, &operationsv1.DisableKeyRequest{Parameters: s.parameters(keyID)}); err != nil {
+		return "", err
+	}
+	disabledStatus, err := s.keyStatus(ctx, plugin, keyID)
+	if err != nil {
+		return "", err
+	}
+	if s.DisabledStatus != "" && disabledStatus != s.DisabledStatus {
+		return "", fmt.Errorf("disabled key has status %q, want %q", disabledStatus, s.DisabledStatus)
+	}
+	return disabledStatus, nil
+}
+
+func (s KeystoreOperationSuite) checkEnableKey(ctx context.Context, plugin *operationsv1.KeystoreInstanceKeyOperationFacade, keyID, disabledStatus string) error {
+	if _, err := plugin.EnableKey(ctx, &operationsv1.EnableKeyRequest{Parameters: s.parameters(keyID)}); err != nil {
+		return err
+	}
+	enabledStatus, err := s.keyStatus(ctx, plugin, keyID)
+	switch {
+	case err != nil:
+		return err
+	case enabledStatus == disabledStatus:
+		return fmt.Errorf("enabled and disabled keys have the same status %q", enabledStatus)
+	case s.EnabledStatus != "" && enabledStatus != s.EnabledStatus:
+		return fmt.Errorf("enabled key has status %q, want %q", enabledStatus, s.EnabledStatus)
+	}
+	return nil
+}
+
+func (s KeystoreOperationSuite) checkKeyNotFound(ctx context.Context, plugin *operationsv1.KeystoreInstanceKeyOperationFacade) error {
+	_, err := plugin.GetKey(ctx, &operationsv1.GetKeyRequest{Parameters: s.parameters(s.UnknownKeyID)})
+	return wantStatus(err, keystoreErrs.StatusKeyNotFound)
+}
+
+func (s KeystoreOperationSuite) checkImportParameters(ctx context.Context, plugin *operationsv1.KeystoreInstanceKeyOperationFacade) (err error) {
+	keyID, err := s.createKey(ctx, plugin, operationsv1.KeyType_KEY_TYPE_BYOK)
+	if err != nil {
+		return fmt.Errorf("failed to create BYOK key: %w", err)
+	}
+	defer func() {
+		_, deleteErr := plugin.DeleteKey(ctx, &operationsv1.DeleteKeyRequest{Parameters: s.parameters(keyID)})
+		err = errors.Join(err, deleteErr)
+	}()
+
+	resp, err := plugin.GetImportParameters(ctx, &operationsv1.GetImportParametersRequest{
+		Parameters: s.parameters(keyID),
+		Algorithm:  s.Algorithm,
+	})
+	switch {
+	case err != nil:
+		return err
+	case resp.GetKeyId() != keyID:
+		return fmt.Errorf("got import parameters of key %q, want %q", resp.GetKeyId(), keyID)
+	case resp.GetImportParameters() == nil:
+		return errors.New("no import parameters")
+	case s.EncryptKeyMaterial == nil:
+		return nil
+	}
+
+	material, err := s.EncryptKeyMaterial(resp.GetImportParameters())
+	if err != nil {
+		return fmt.Errorf("failed to encrypt key material: %w", err)
+	}
+	_, err = plugin.ImportKeyMaterial(ctx, &operationsv1.ImportKeyMaterialRequest{
+		Parameters:           s.parameters(keyID),
+		ImportParameters:     resp.GetImportParameters(),
+		EncryptedKeyMaterial: material,
+	})
+	return err
+}
+
+func (s KeystoreOperationSuite) checkValidateKey(ctx context.Context, plugin *operationsv1.KeystoreInstanceKeyOperationFacade) error {
+	resp, err := plugin.ValidateKey(ctx, &operationsv1.ValidateKeyRequest{
+		KeyType:   operationsv1.KeyType_KEY_TYPE_SYSTEM_MANAGED,
+		Algorithm: s.Algorithm,
+		Region:    s.Region,
+	})
+	switch {
+	case err != nil:
+		return err
+	case !resp.GetIsValid():
+		return fmt.Errorf("valid key attributes rejected: %s", resp.GetMessage())
+	}
+	return nil
+}
+
+// checkInvalidKeyAccessData checks that empty key access data is either
+// reported as invalid or rejected with StatusInvalidKeyAccessData.
+func (s KeystoreOperationSuite) checkInvalidKeyAccessData(ctx context.Context, plugin *operationsv1.KeystoreInstanceKeyOperationFacade) error {
+	resp, err := plugin.ValidateKeyAccessData(ctx, &operationsv1.ValidateKeyAccessDataRequest{
+		Management: &structpb.Struct{},
+		Crypto:     &structpb.Struct{},
+	})
+	switch {
+	case err != nil:
+		return wantStatus(err, keystoreErrs.StatusInvalidKeyAccessData)
+	case resp.GetIsValid():
+		return errors.New("empty key access data reported as valid")
+	}
+	return nil
+}
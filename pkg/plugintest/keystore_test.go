@@ -0,0 +1,191 @@
+package plugintest_test
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	keystoreErrs "github.com/openkcm/plugin-sdk/pkg/plugin/keystore/errors"
+	"github.com/openkcm/plugin-sdk/pkg/plugintest"
+	operationsv1 "github.com/openkcm/plugin-sdk/proto/plugin/keystore/operations/v1"
+)
+
+type memoryKey struct {
+	algorithm operationsv1.KeyAlgorithm
+	status    string
+	material  string
+}
+
+// memoryKeystore is a keystore keeping its keys in memory.
+type memoryKeystore struct {
+	operationsv1.UnimplementedKeystoreInstanceKeyOperationServer
+
+	mu      sync.Mutex
+	keys    map[string]*memoryKey
+	created int
+}
+
+func newMemoryKeystore() *memoryKeystore {
+	return &memoryKeystore{keys: map[string]*memoryKey{}}
+}
+
+func (k *memoryKeystore) key(params *operationsv1.RequestParameters) (*memoryKey, error) {
+	key, ok := k.keys[params.GetKeyId()]
+	if !ok {
+		return nil, keystoreErrs.StatusKeyNotFound.Err()
+	}
+	return key, nil
+}
+
+func (k *memoryKeystore) CreateKey(_ context.Context, req *operationsv1.CreateKeyRequest) (*operationsv1.CreateKeyResponse, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.created++
+	keyID := "key-" + strconv.Itoa(k.created)
+	k.keys[keyID] = &memoryKey{algorithm: req.GetAlgorithm(), status: "ENABLED"}
+	return &operationsv1.CreateKeyResponse{KeyId: keyID, Status: "ENABLED"}, nil
+}
+
+func (k *memoryKeystore) GetKey(_ context.Context, req *operationsv1.GetKeyRequest) (*operationsv1.GetKeyResponse, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	key, err := k.key(req.GetParameters())
+	if err != nil {
+		return nil, err
+	}
+	return &operationsv1.GetKeyResponse{
+		KeyId:     req.GetParameters().GetKeyId(),
+		Algorithm: key.algorithm,
+		Status:    key.status,
+	}, nil
+}
+
+func (k *memoryKeystore) setStatus(params *operationsv1.RequestParameters, status string) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	key, err := k.key(params)
+	if err != nil {
+		return err
+	}
+	key.status = status
+	return nil
+}
+
+func (k *memoryKeystore) EnableKey(_ context.Context, req *operationsv1.EnableKeyRequest) (*operationsv1.EnableKeyResponse, error) {
+	return &operationsv1.EnableKeyResponse{}, k.setStatus(req.GetParameters(), "ENABLED")
+}
+
+func (k *memoryKeystore) DisableKey(_ context.Context, req *operationsv1.DisableKeyRequest) (*operationsv1.DisableKeyResponse, error) {
+	return &operationsv1.DisableKeyResponse{}, k.setStatus(req.GetParameters(), "DISABLED")
+}
+
+func (k *memoryKeystore) DeleteKey(_ context.Context, req *operationsv1.DeleteKeyRequest) (*operationsv1.DeleteKeyResponse, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if _, err := k.key(req.GetParameters()); err != nil {
+		return nil, err
+	}
+	delete(k.keys, req.GetParameters().GetKeyId())
+	return &operationsv1.DeleteKeyResponse{}, nil
+}
+
+func (k *memoryKeystore) GetImportParameters(_ context.Context, req *operationsv1.GetImportParametersRequest) (*operationsv1.GetImportParametersResponse, error) {
+	params, err := structpb.NewStruct(map[string]any{"wrapping": "none"})
+	if err != nil {
+		return nil, err
+	}
+	return &operationsv1.GetImportParametersResponse{KeyId: req.GetParameters().GetKeyId(), ImportParameters: params}, nil
+}
+
+func (k *memoryKeystore) ImportKeyMaterial(_ context.Context, req *operationsv1.ImportKeyMaterialRequest) (*operationsv1.ImportKeyMaterialResponse, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	key, err := k.key(req.GetParameters())
+	if err != nil {
+		return nil, err
+	}
+	if req.GetImportParameters().GetFields()["wrapping"].GetStringValue() != "none" {
+		return nil, keystoreErrs.StatusInvalidKeyAccessData.Err()
+	}
+	key.material = req.GetEncryptedKeyMaterial()
+	return &operationsv1.ImportKeyMaterialResponse{}, nil
+}
+
+func (k *memoryKeystore) ValidateKey(context.Context, *operationsv1.ValidateKeyRequest) (*operationsv1.ValidateKeyResponse, error) {
+	return &operationsv1.ValidateKeyResponse{IsValid: true}, nil
+}
+
+func (k *memoryKeystore) ValidateKeyAccessData(_ context.Context, req *operationsv1.ValidateKeyAccessDataRequest) (*operationsv1.ValidateKeyAccessDataResponse, error) {
+	if len(req.GetManagement().GetFields()) == 0 {
+		return nil, keystoreErrs.StatusInvalidKeyAccessData.Err()
+	}
+	return &operationsv1.ValidateKeyAccessDataResponse{IsValid: true}, nil
+}
+
+// sloppyKeystore reports missing keys with generic errors and never disables
+// keys.
+type sloppyKeystore struct {
+	*memoryKeystore
+}
+
+func (k sloppyKeystore) GetKey(ctx context.Context, req *operationsv1.GetKeyRequest) (*operationsv1.GetKeyResponse, error) {
+	resp, err := k.memoryKeystore.GetKey(ctx, req)
+	if err != nil {
+		return nil, keystoreErrs.StatusKeyGenericErr.Err()
+	}
+	return resp, nil
+}
+
+func (k sloppyKeystore) DisableKey(context.Context, *operationsv1.DisableKeyRequest) (*operationsv1.DisableKeyResponse, error) {
+	return &operationsv1.DisableKeyResponse{}, nil
+}
+
+func TestKeystoreOperationSuite(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	plugin := plugintest.Load[*operationsv1.KeystoreInstanceKeyOperationFacade](t,
+		operationsv1.KeystoreInstanceKeyOperationPluginServer(newMemoryKeystore()))
+	suite := plugintest.KeystoreOperationSuite{
+		EnabledStatus:  "ENABLED",
+		DisabledStatus: "DISABLED",
+		EncryptKeyMaterial: func(*structpb.Struct) (string, error) {
+			return "material", nil
+		},
+	}
+
+	// Act
+	report := suite.Run(t.Context(), plugin)
+
+	// Assert
+	report.Assert(t)
+	if len(report.Checks) != 9 {
+		t.Fatalf("unexpected checks:\n%s", report)
+	}
+}
+
+func TestKeystoreOperationSuite_Failures(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	plugin := plugintest.Load[*operationsv1.KeystoreInstanceKeyOperationFacade](t,
+		operationsv1.KeystoreInstanceKeyOperationPluginServer(sloppyKeystore{newMemoryKeystore()}))
+
+	// Act
+	report := plugintest.KeystoreOperationSuite{}.Run(t.Context(), plugin)
+
+	// Assert
+	if report.Passed() {
+		t.Fatalf("sloppy keystore passed:\n%s", report)
+	}
+	if got, want := failedChecks(report), "EnableKey,KeyNotFound"; got != want {
+		t.Fatalf("failed checks %s, want %s:\n%s", got, want, report)
+	}
+	if !strings.Contains(report.String(), "PASS CreateKey\n") {
+		t.Fatalf("unexpected report:\n%s", report)
+	}
+}
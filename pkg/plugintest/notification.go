@@ -0,0 +1,87 @@
+package plugintest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+
+	notificationv1 "github.com/openkcm/plugin-sdk/proto/plugin/notification/v1"
+)
+
+// DefaultInvalidRecipient is the recipient the notification conformance
+// suite checks to be rejected, unless set otherwise.
+const DefaultInvalidRecipient = "not a valid recipient"
+
+// NotificationSuite is the conformance suite of the NotificationService
+// plugins. It sends a notification to valid recipients, and checks that
+// notifications without recipients, to invalid recipients or of an
+// unspecified type are rejected, either with codes.InvalidArgument or with
+// an unsuccessful response explaining why.
+type NotificationSuite struct {
+	// Type is the type of the sent notifications, email by default.
+	Type notificationv1.NotificationType
+
+	// Recipients are the valid recipients of the sent notification.
+	Recipients []string
+
+	// InvalidRecipient is a recipient the plugin rejects,
+	// DefaultInvalidRecipient by default.
+	InvalidRecipient string
+}
+
+// Run runs the suite against the plugin bound to the facade, e.g. loaded
+// with Load, and returns the report of its checks.
+func (s NotificationSuite) Run(ctx context.Context, plugin *notificationv1.NotificationServiceFacade) Report {
+	if s.Type == notificationv1.NotificationType_NOTIFICATION_TYPE_UNSPECIFIED {
+		s.Type = notificationv1.NotificationType_NOTIFICATION_TYPE_EMAIL
+	}
+	if s.InvalidRecipient == "" {
+		s.InvalidRecipient = DefaultInvalidRecipient
+	}
+
+	var report Report
+	report.check("SendNotification", s.checkSent(ctx, plugin))
+	report.check("NoRecipients", s.checkRejected(ctx, plugin, s.request(s.Type, nil)))
+	report.check("InvalidRecipient", s.checkRejected(ctx, plugin, s.request(s.Type, []string{s.InvalidRecipient})))
+	report.check("UnspecifiedType", s.checkRejected(ctx, plugin,
+		s.request(notificationv1.NotificationType_NOTIFICATION_TYPE_UNSPECIFIED, s.Recipients)))
+	return report
+}
+
+func (s NotificationSuite) request(typ notificationv1.NotificationType, recipients []string) *notificationv1.SendNotificationRequest {
+	return &notificationv1.SendNotificationRequest{
+		NotificationType: typ,
+		Recipients:       recipients,
+		Subject:          "Conformance",
+		Body:             "Notification sent by the conformance suite.",
+	}
+}
+
+func (s NotificationSuite) checkSent(ctx context.Context, plugin *notificationv1.NotificationServiceFacade) error {
+	if len(s.Recipients) == 0 {
+		return errors.New("no recipients configured")
+	}
+	resp, err := plugin.SendNotification(ctx, s.request(s.Type, s.Recipients))
+	switch {
+	case err != nil:
+		return err
+	case !resp.GetSuccess():
+		return fmt.Errorf("notification not sent: %s", resp.GetMessage())
+	}
+	return nil
+}
+
+func (s NotificationSuite) checkRejected(ctx context.Context, plugin *notificationv1.NotificationServiceFacade, req *notificationv1.SendNotificationRequest) error {
+	resp, err := plugin.SendNotification(ctx, req)
+	switch {
+	case err != nil:
+		return wantCode(err, codes.InvalidArgument)
+	case resp.GetSuccess():
+		return errors.New("notification sent")
+	case resp.GetMessage() == "":
+		return errors.New("unsuccessful response without message")
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+// Package plugintest loads plugins in tests the way the catalog loads them,
+// so that plugin implementations are tested through the facades used by the
+// hosts rather than by calling their servers directly.
+package plugintest
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+	"testing"
+
+	"github.com/openkcm/plugin-sdk/api"
+	pluginoption "github.com/openkcm/plugin-sdk/api/plugin-option"
+	"github.com/openkcm/plugin-sdk/pkg/catalog"
+)
+
+// DefaultName is the name of the loaded plugin unless set with Name.
+const DefaultName = "test"
+
+// Option configures how a plugin is loaded.
+type Option func(*config)
+
+type config struct {
+	name          string
+	configuration string
+	hostServices  []api.ServiceServer
+	serverOptions []pluginoption.ServerOption
+	logger        *slog.Logger
+}
+
+// Name sets the name of the loaded plugin.
+func Name(name string) Option {
+	return func(c *config) {
+		c.name = name
+	}
+}
+
+// Configuration sets the YAML configuration the plugin is configured with.
+func Configuration(yaml string) Option {
+	return func(c *config) {
+		c.configuration = yaml
+	}
+}
+
+// HostServices sets the host services brokered to the plugin, usually fakes.
+func HostServices(serviceServers ...api.ServiceServer) Option {
+	return func(c *config) {
+		c.hostServices = append(c.hostServices, serviceServers...)
+	}
+}
+
+// ServerOptions sets the options the plugin is served with, e.g.
+// pluginoption.WithServiceServer to serve the config service.
+func ServerOptions(opts ...pluginoption.ServerOption) Option {
+	return func(c *config) {
+		c.serverOptions = append(c.serverOptions, opts...)
+	}
+}
+
+// Logger sets the logger of the catalog and the plugin. Logs are discarded
+// by default.
+func Logger(logger *slog.Logger) Option {
+	return func(c *config) {
+		c.logger = logger
+	}
+}
+
+// Load loads the plugin server in-process over the same pipe network as the
+// built-in plugins, initializes and configures it, and returns the facade it
+// is bound to. F is the pointer type of the generated facade of the plugin,
+// e.g. *testv1.TestServiceFacade. The plugin is closed when the test ends.
+func Load[F api.Facade](t testing.TB, pluginServer api.PluginServer, opts ...Option) F {
+	t.Helper()
+
+	cfg := newConfig(opts)
+
+	repo := &facadeRepo[F]{}
+	builtIn := catalog.MakeBuiltInWithOptions(cfg.name, pluginServer, cfg.serverOptions...)
+	cat, err := catalog.New(context.Background(), catalog.Config{
+		Logger: cfg.logger,
+		PluginConfigs: []catalog.PluginConfig{{
+			Name:              cfg.name,
+			Type:              pluginServer.Type(),
+			YamlConfiguration: cfg.configuration,
+		}},
+		HostServices: cfg.hostServices,
+	}, repository{plugins: map[string]api.PluginRepo{pluginServer.Type(): repo}}, builtIn)
+	if err != nil {
+		t.Fatalf("Failed to load plugin %q: %v", cfg.name, err)
+	}
+	t.Cleanup(func() {
+		if err := cat.Close(); err != nil {
+			t.Errorf("Failed to close plugin %q: %v", cfg.name, err)
+		}
+	})
+	return repo.facade
+}
+
+func newConfig(opts []Option) *config {
+	cfg := &config{
+		name:   DefaultName,
+		logger: slog.New(slog.DiscardHandler),
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+type repository struct {
+	plugins map[string]api.PluginRepo
+}
+
+func (r repository) Plugins() map[string]api.PluginRepo { return r.plugins }
+func (r repository) Services() []api.ServiceRepo        { return nil }
+
+// facadeRepo is the repository of a single plugin bound to a facade of type
+// F.
+type facadeRepo[F api.Facade] struct {
+	facade F
+}
+
+func (r *facadeRepo[F]) Binder() any {
+	return func(f F) {
+		r.facade = f
+	}
+}
+
+func (r *facadeRepo[F]) Versions() []api.Version {
+	return []api.Version{facadeVersion[F]{}}
+}
+
+func (r *facadeRepo[F]) Clear() {
+	var zero F
+	r.facade = zero
+}
+
+func (r *facadeRepo[F]) Constraints() api.Constraints {
+	return api.Constraints{Min: 1, Max: 1}
+}
+
+type facadeVersion[F api.Facade] struct{}
+
+func (facadeVersion[F]) New() api.Facade {
+	return reflect.New(reflect.TypeFor[F]().Elem()).Interface().(api.Facade)
+}
+
+func (facadeVersion[F]) Deprecated() bool { return false }
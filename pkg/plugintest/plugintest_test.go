@@ -0,0 +1,153 @@
+package plugintest_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/openkcm/plugin-sdk/api"
+	pluginoption "github.com/openkcm/plugin-sdk/api/plugin-option"
+	"github.com/openkcm/plugin-sdk/pkg/catalog"
+	"github.com/openkcm/plugin-sdk/pkg/plugintest"
+	testv1 "github.com/openkcm/plugin-sdk/proto/plugin/test/v1"
+	"github.com/openkcm/plugin-sdk/proto/plugin/test/v1/testv1fake"
+	configv1 "github.com/openkcm/plugin-sdk/proto/service/common/config/v1"
+)
+
+// hostedPlugin is configured by the catalog and answers its calls with the
+// configuration sent by the host service.
+type hostedPlugin struct {
+	testv1.UnimplementedTestServiceServer
+	configv1.UnimplementedConfigServer
+
+	configuration string
+	host          configv1.ConfigServiceClient
+}
+
+func (p *hostedPlugin) Configure(_ context.Context, req *configv1.ConfigureRequest) (*configv1.ConfigureResponse, error) {
+	p.configuration = req.GetYamlConfiguration()
+	return &configv1.ConfigureResponse{}, nil
+}
+
+func (p *hostedPlugin) BrokerHostServices(broker api.ServiceBroker) error {
+	if !broker.BrokerClient(&p.host) {
+		return errors.New("config host service not brokered")
+	}
+	return nil
+}
+
+func (p *hostedPlugin) Test(ctx context.Context, req *testv1.TestRequest) (*testv1.TestResponse, error) {
+	if _, err := p.host.Configure(ctx, &configv1.ConfigureRequest{YamlConfiguration: req.GetRequest()}); err != nil {
+		return nil, err
+	}
+	return &testv1.TestResponse{Response: p.configuration}, nil
+}
+
+// hostConfig is a fake host service recording the configuration it is sent.
+type hostConfig struct {
+	configv1.UnimplementedConfigServer
+
+	configuration string
+}
+
+func (h *hostConfig) Configure(_ context.Context, req *configv1.ConfigureRequest) (*configv1.ConfigureResponse, error) {
+	h.configuration = req.GetYamlConfiguration()
+	return &configv1.ConfigureResponse{}, nil
+}
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	impl := &hostedPlugin{}
+	host := &hostConfig{}
+
+	// Act
+	facade := plugintest.Load[*testv1.TestServiceFacade](t, testv1.TestServicePluginServer(impl),
+		plugintest.Name("hosted"),
+		plugintest.Configuration("key: value"),
+		plugintest.ServerOptions(pluginoption.WithServiceServer(configv1.ConfigServiceServer(impl))),
+		plugintest.HostServices(configv1.ConfigServiceServer(host)),
+	)
+	resp, err := facade.Test(t.Context(), &testv1.TestRequest{Request: "from plugin"})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Test(): %v", err)
+	}
+	if facade.Name() != "hosted" || facade.Type() != testv1.Type {
+		t.Fatalf("unexpected plugin %q of type %q", facade.Name(), facade.Type())
+	}
+	if resp.GetResponse() != "key: value" {
+		t.Fatalf("plugin configured with %q, want %q", resp.GetResponse(), "key: value")
+	}
+	if host.configuration != "from plugin" {
+		t.Fatalf("host service called with %q, want %q", host.configuration, "from plugin")
+	}
+}
+
+func TestLoad_Fake(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	fake := &testv1fake.TestService{}
+	fake.ReturnTest(&testv1.TestResponse{Response: "scripted"}, nil)
+
+	// Act
+	facade := plugintest.Load[*testv1.TestServiceFacade](t, fake.PluginServer())
+	resp, err := facade.Test(t.Context(), &testv1.TestRequest{Request: "request"})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Test(): %v", err)
+	}
+	if resp.GetResponse() != "scripted" || facade.Name() != plugintest.DefaultName {
+		t.Fatalf("unexpected response %q of plugin %q", resp.GetResponse(), facade.Name())
+	}
+	if calls := fake.TestCalls(); len(calls) != 1 || calls[0].GetRequest() != "request" {
+		t.Fatalf("unexpected calls %v", calls)
+	}
+}
+
+func TestBuildAndLoad(t *testing.T) {
+	t.Parallel()
+
+	// Act
+	cat, facade := plugintest.BuildAndLoad[*testv1.TestServiceFacade](t, "../catalog/internal/testplugin", catalog.PluginConfig{
+		YamlConfiguration: "key: value",
+	})
+	resp, err := facade.Test(t.Context(), &testv1.TestRequest{})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Test(): %v", err)
+	}
+	if resp.GetResponse() != "test" {
+		t.Fatalf("unexpected response %q", resp.GetResponse())
+	}
+	if plugin := cat.LookupByTypeAndName(testv1.Type, plugintest.DefaultName); plugin == nil || plugin.Info().Version() != 1 {
+		t.Fatalf("plugin %q not found in the catalog", plugintest.DefaultName)
+	}
+}
+
+func TestBuild(t *testing.T) {
+	t.Parallel()
+
+	// Act
+	path := plugintest.Build(t, "../catalog/internal/testplugin/")
+
+	// Assert
+	want := "plugin"
+	if runtime.GOOS == "windows" {
+		want += ".exe"
+	}
+	if filepath.Base(path) != want {
+		t.Fatalf("unexpected binary %q, want %q", path, want)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("binary not built: %v", err)
+	}
+}
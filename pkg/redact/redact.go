@@ -0,0 +1,77 @@
+// Package redact redacts the fields of proto messages marked with the
+// (openkcm.options.v1.sensitive) option, e.g. key material or access data,
+// so that the messages can be logged.
+package redact
+
+import (
+	"log/slog"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	optionsv1 "github.com/openkcm/plugin-sdk/proto/openkcm/options/v1"
+)
+
+// Placeholder replaces the values of the sensitive string and bytes fields.
+// The sensitive fields of other types are cleared.
+const Placeholder = "[REDACTED]"
+
+// Message returns a copy of the message with its sensitive fields redacted,
+// including those of nested messages.
+func Message[M proto.Message](m M) M {
+	redacted, _ := proto.Clone(m).(M)
+	if redacted.ProtoReflect().IsValid() {
+		redactMessage(redacted.ProtoReflect())
+	}
+	return redacted
+}
+
+// LogValue returns the value of the message logged by slog, i.e. the message
+// redacted and formatted as JSON.
+func LogValue(m proto.Message) slog.Value {
+	return slog.StringValue(protojson.MarshalOptions{}.Format(Message(m)))
+}
+
+// IsSensitive reports whether the field is marked with the
+// (openkcm.options.v1.sensitive) option.
+func IsSensitive(field protoreflect.FieldDescriptor) bool {
+	sensitive, _ := proto.GetExtension(field.Options(), optionsv1.E_Sensitive).(bool)
+	return sensitive
+}
+
+func redactMessage(m protoreflect.Message) {
+	var sensitive []protoreflect.FieldDescriptor
+	m.Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		switch {
+		case IsSensitive(field):
+			sensitive = append(sensitive, field)
+		case field.IsList() && field.Message() != nil:
+			list := value.List()
+			for i := range list.Len() {
+				redactMessage(list.Get(i).Message())
+			}
+		case field.IsMap() && field.MapValue().Message() != nil:
+			value.Map().Range(func(_ protoreflect.MapKey, value protoreflect.Value) bool {
+				redactMessage(value.Message())
+				return true
+			})
+		case field.Message() != nil && !field.IsMap():
+			redactMessage(value.Message())
+		}
+		return true
+	})
+
+	for _, field := range sensitive {
+		switch {
+		case field.IsList() || field.IsMap():
+			m.Clear(field)
+		case field.Kind() == protoreflect.StringKind:
+			m.Set(field, protoreflect.ValueOfString(Placeholder))
+		case field.Kind() == protoreflect.BytesKind:
+			m.Set(field, protoreflect.ValueOfBytes([]byte(Placeholder)))
+		default:
+			m.Clear(field)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package redact_test
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/openkcm/plugin-sdk/pkg/redact"
+	commonv1 "github.com/openkcm/plugin-sdk/proto/plugin/keystore/common/v1"
+	operationsv1 "github.com/openkcm/plugin-sdk/proto/plugin/keystore/operations/v1"
+)
+
+func newImportKeyMaterialRequest(t *testing.T) *operationsv1.ImportKeyMaterialRequest {
+	t.Helper()
+	values, err := structpb.NewStruct(map[string]any{"secret": "s3cr3t"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &operationsv1.ImportKeyMaterialRequest{
+		Parameters: &operationsv1.RequestParameters{
+			Config: &commonv1.KeystoreInstanceConfig{Values: values},
+			KeyId:  "key-1",
+		},
+		EncryptedKeyMaterial: "material",
+	}
+}
+
+func TestMessage(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	req := newImportKeyMaterialRequest(t)
+
+	// Act
+	redacted := redact.Message(req)
+
+	// Assert
+	if redacted.GetEncryptedKeyMaterial() != redact.Placeholder {
+		t.Errorf("encrypted key material %q, want %q", redacted.GetEncryptedKeyMaterial(), redact.Placeholder)
+	}
+	if redacted.GetParameters().GetConfig().GetValues() != nil {
+		t.Errorf("nested sensitive values not cleared: %v", redacted.GetParameters().GetConfig().GetValues())
+	}
+	if redacted.GetParameters().GetKeyId() != "key-1" {
+		t.Errorf("key id %q, want it kept", redacted.GetParameters().GetKeyId())
+	}
+	if req.GetEncryptedKeyMaterial() != "material" || req.GetParameters().GetConfig().GetValues() == nil {
+		t.Errorf("original message modified: %v", req)
+	}
+}
+
+func TestMessage_Nil(t *testing.T) {
+	t.Parallel()
+
+	// Act
+	redacted := redact.Message((*operationsv1.ImportKeyMaterialRequest)(nil))
+
+	// Assert
+	if redacted != nil {
+		t.Errorf("redacted %v, want nil", redacted)
+	}
+}
+
+func TestLogValue(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	req := newImportKeyMaterialRequest(t)
+
+	// Act
+	value := req.LogValue().String()
+
+	// Assert
+	for _, secret := range []string{"material", "s3cr3t"} {
+		if strings.Contains(value, secret) {
+			t.Errorf("logged value %q contains %q", value, secret)
+		}
+	}
+	if !strings.Contains(value, "key-1") {
+		t.Errorf("logged value %q does not contain the key id", value)
+	}
+}
@@ -0,0 +1,181 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v7.35.1
+// source: openkcm/options/v1/options.proto
+
+package optionsv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// PluginConstraints are the number of plugins of a type required by a host.
+type PluginConstraints struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// min is the minimum number of plugins. If zero, the plugin type is
+	// optional.
+	Min uint32 `protobuf:"varint,1,opt,name=min,proto3" json:"min,omitempty"`
+	// max is the maximum number of plugins. If zero, there is no upper bound.
+	Max           uint32 `protobuf:"varint,2,opt,name=max,proto3" json:"max,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PluginConstraints) Reset() {
+	*x = PluginConstraints{}
+	mi := &file_openkcm_options_v1_options_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PluginConstraints) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PluginConstraints) ProtoMessage() {}
+
+func (x *PluginConstraints) ProtoReflect() protoreflect.Message {
+	mi := &file_openkcm_options_v1_options_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PluginConstraints.ProtoReflect.Descriptor instead.
+func (*PluginConstraints) Descriptor() ([]byte, []int) {
+	return file_openkcm_options_v1_options_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PluginConstraints) GetMin() uint32 {
+	if x != nil {
+		return x.Min
+	}
+	return 0
+}
+
+func (x *PluginConstraints) GetMax() uint32 {
+	if x != nil {
+		return x.Max
+	}
+	return 0
+}
+
+var file_openkcm_options_v1_options_proto_extTypes = []protoimpl.ExtensionInfo{
+	{
+		ExtendedType:  (*descriptorpb.ServiceOptions)(nil),
+		ExtensionType: (*PluginConstraints)(nil),
+		Field:         50100,
+		Name:          "openkcm.options.v1.constraints",
+		Tag:           "bytes,50100,opt,name=constraints",
+		Filename:      "openkcm/options/v1/options.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         50101,
+		Name:          "openkcm.options.v1.sensitive",
+		Tag:           "varint,50101,opt,name=sensitive",
+		Filename:      "openkcm/options/v1/options.proto",
+	},
+}
+
+// Extension fields to descriptorpb.ServiceOptions.
+var (
+	// constraints are the constraints of the plugin repository generated for
+	// a plugin service. Defaults to zero or more plugins.
+	//
+	// optional openkcm.options.v1.PluginConstraints constraints = 50100;
+	E_Constraints = &file_openkcm_options_v1_options_proto_extTypes[0]
+)
+
+// Extension fields to descriptorpb.FieldOptions.
+var (
+	// sensitive marks fields which must never be logged, e.g. key material or
+	// access data. They are redacted when the generated messages are logged.
+	//
+	// optional bool sensitive = 50101;
+	E_Sensitive = &file_openkcm_options_v1_options_proto_extTypes[1]
+)
+
+var File_openkcm_options_v1_options_proto protoreflect.FileDescriptor
+
+const file_openkcm_options_v1_options_proto_rawDesc = "" +
+	"\n" +
+	" openkcm/options/v1/options.proto\x12\x12openkcm.options.v1\x1a google/protobuf/descriptor.proto\"7\n" +
+	"\x11PluginConstraints\x12\x10\n" +
+	"\x03min\x18\x01 \x01(\rR\x03min\x12\x10\n" +
+	"\x03max\x18\x02 \x01(\rR\x03max:j\n" +
+	"\vconstraints\x12\x1f.google.protobuf.ServiceOptions\x18\xb4\x87\x03 \x01(\v2%.openkcm.options.v1.PluginConstraintsR\vconstraints:=\n" +
+	"\tsensitive\x12\x1d.google.protobuf.FieldOptions\x18\xb5\x87\x03 \x01(\bR\tsensitiveBBZ@github.com/openkcm/plugin-sdk/proto/openkcm/options/v1;optionsv1b\x06proto3"
+
+var (
+	file_openkcm_options_v1_options_proto_rawDescOnce sync.Once
+	file_openkcm_options_v1_options_proto_rawDescData []byte
+)
+
+func file_openkcm_options_v1_options_proto_rawDescGZIP() []byte {
+	file_openkcm_options_v1_options_proto_rawDescOnce.Do(func() {
+		file_openkcm_options_v1_options_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_openkcm_options_v1_options_proto_rawDesc), len(file_openkcm_options_v1_options_proto_rawDesc)))
+	})
+	return file_openkcm_options_v1_options_proto_rawDescData
+}
+
+var file_openkcm_options_v1_options_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_openkcm_options_v1_options_proto_goTypes = []any{
+	(*PluginConstraints)(nil),           // 0: openkcm.options.v1.PluginConstraints
+	(*descriptorpb.ServiceOptions)(nil), // 1: google.protobuf.ServiceOptions
+	(*descriptorpb.FieldOptions)(nil),   // 2: google.protobuf.FieldOptions
+}
+var file_openkcm_options_v1_options_proto_depIdxs = []int32{
+	1, // 0: openkcm.options.v1.constraints:extendee -> google.protobuf.ServiceOptions
+	2, // 1: openkcm.options.v1.sensitive:extendee -> google.protobuf.FieldOptions
+	0, // 2: openkcm.options.v1.constraints:type_name -> openkcm.options.v1.PluginConstraints
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	2, // [2:3] is the sub-list for extension type_name
+	0, // [0:2] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_openkcm_options_v1_options_proto_init() }
+func file_openkcm_options_v1_options_proto_init() {
+	if File_openkcm_options_v1_options_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_openkcm_options_v1_options_proto_rawDesc), len(file_openkcm_options_v1_options_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 2,
+			NumServices:   0,
+		},
+		GoTypes:           file_openkcm_options_v1_options_proto_goTypes,
+		DependencyIndexes: file_openkcm_options_v1_options_proto_depIdxs,
+		MessageInfos:      file_openkcm_options_v1_options_proto_msgTypes,
+		ExtensionInfos:    file_openkcm_options_v1_options_proto_extTypes,
+	}.Build()
+	File_openkcm_options_v1_options_proto = out.File
+	file_openkcm_options_v1_options_proto_goTypes = nil
+	file_openkcm_options_v1_options_proto_depIdxs = nil
+}
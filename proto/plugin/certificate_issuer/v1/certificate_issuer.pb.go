@@ -7,6 +7,7 @@
 package certificate_issuerv1
 
 import (
+	_ "github.com/openkcm/plugin-sdk/proto/openkcm/options/v1"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
@@ -285,7 +286,7 @@ var File_plugin_certificate_issuer_v1_certificate_issuer_proto protoreflect.File
 
 const file_plugin_certificate_issuer_v1_certificate_issuer_proto_rawDesc = "" +
 	"\n" +
-	"5plugin/certificate_issuer/v1/certificate_issuer.proto\x12\x1cplugin.certificate_issuer.v1\"\xf1\x01\n" +
+	"5plugin/certificate_issuer/v1/certificate_issuer.proto\x12\x1cplugin.certificate_issuer.v1\x1a openkcm/options/v1/options.proto\"\xf1\x01\n" +
 	"\x15GetCertificateRequest\x12\x1f\n" +
 	"\vcommon_name\x18\x01 \x01(\tR\n" +
 	"commonName\x12\x1a\n" +
@@ -295,10 +296,10 @@ const file_plugin_certificate_issuer_v1_certificate_issuer_proto_rawDesc = "" +
 	"privateKey\"n\n" +
 	"\x16GetCertificateValidity\x12\x14\n" +
 	"\x05value\x18\x01 \x01(\x03R\x05value\x12>\n" +
-	"\x04type\x18\x02 \x01(\x0e2*.plugin.certificate_issuer.v1.ValidityTypeR\x04type\" \n" +
+	"\x04type\x18\x02 \x01(\x0e2*.plugin.certificate_issuer.v1.ValidityTypeR\x04type\"&\n" +
 	"\n" +
-	"PrivateKey\x12\x12\n" +
-	"\x04data\x18\x01 \x01(\fR\x04data\"E\n" +
+	"PrivateKey\x12\x18\n" +
+	"\x04data\x18\x01 \x01(\fB\x04\xa8\xbb\x18\x01R\x04data\"E\n" +
 	"\x16GetCertificateResponse\x12+\n" +
 	"\x11certificate_chain\x18\x01 \x01(\tR\x10certificateChain*x\n" +
 	"\fValidityType\x12\x1d\n" +
@@ -3,8 +3,12 @@
 package certificate_issuerv1
 
 import (
+	context "context"
 	api "github.com/openkcm/plugin-sdk/api"
+	plugin "github.com/openkcm/plugin-sdk/pkg/plugin"
+	redact "github.com/openkcm/plugin-sdk/pkg/redact"
 	grpc "google.golang.org/grpc"
+	slog "log/slog"
 )
 
 const (
@@ -53,3 +57,84 @@ func (c *CertificateIssuerServicePluginClient) InitClient(conn grpc.ClientConnIn
 	c.CertificateIssuerServiceClient = NewCertificateIssuerServiceClient(conn)
 	return c.CertificateIssuerServiceClient
 }
+
+// CertificateIssuerServiceRepo is the repository of the CertificateIssuerService plugins loaded by
+// the catalog. It is registered for the plugin Type.
+type CertificateIssuerServiceRepo struct {
+	facades []*CertificateIssuerServiceFacade
+}
+
+var _ api.PluginRepo = (*CertificateIssuerServiceRepo)(nil)
+
+func (r *CertificateIssuerServiceRepo) Binder() any {
+	return func(f *CertificateIssuerServiceFacade) {
+		r.facades = append(r.facades, f)
+	}
+}
+
+func (r *CertificateIssuerServiceRepo) Versions() []api.Version {
+	return []api.Version{CertificateIssuerServiceVersion{}}
+}
+
+func (r *CertificateIssuerServiceRepo) Constraints() api.Constraints {
+	return api.Constraints{Min: 0, Max: 0}
+}
+
+func (r *CertificateIssuerServiceRepo) Clear() {
+	r.facades = nil
+}
+
+// Facades returns the facades of the loaded plugins.
+func (r *CertificateIssuerServiceRepo) Facades() []*CertificateIssuerServiceFacade {
+	return r.facades
+}
+
+// CertificateIssuerServiceVersion is the version of the CertificateIssuerService plugin facade.
+type CertificateIssuerServiceVersion struct{}
+
+func (CertificateIssuerServiceVersion) New() api.Facade  { return new(CertificateIssuerServiceFacade) }
+func (CertificateIssuerServiceVersion) Deprecated() bool { return false }
+
+// CertificateIssuerServiceFacade is the facade of a loaded CertificateIssuerService plugin.
+type CertificateIssuerServiceFacade struct {
+	plugin.Facade
+	CertificateIssuerServicePluginClient
+}
+
+func (f *CertificateIssuerServiceFacade) Version() uint {
+	return 1
+}
+
+// GetCertificate calls GetCertificate on the plugin, prefixing errors with the plugin name.
+func (f *CertificateIssuerServiceFacade) GetCertificate(ctx context.Context, req *GetCertificateRequest) (*GetCertificateResponse, error) {
+	f.LogCall(ctx, CertificateIssuerService_GetCertificate_FullMethodName, req)
+	resp, err := f.CertificateIssuerServicePluginClient.GetCertificate(ctx, req)
+	return resp, f.WrapErr(err)
+}
+
+// CallGetCertificate calls GetCertificate on the plugin with the fields of the
+// request, prefixing errors with the plugin name.
+func (f *CertificateIssuerServiceFacade) CallGetCertificate(ctx context.Context, commonName string, locality []string, validity *GetCertificateValidity, privateKey *PrivateKey) (certificateChain string, err error) {
+	resp, err := f.GetCertificate(ctx, &GetCertificateRequest{CommonName: commonName, Locality: locality, Validity: validity, PrivateKey: privateKey})
+	return resp.GetCertificateChain(), err
+}
+
+// Redacted returns a copy of the message with its sensitive fields redacted.
+func (x *GetCertificateRequest) Redacted() *GetCertificateRequest {
+	return redact.Message(x)
+}
+
+// LogValue implements slog.LogValuer, logging the message redacted.
+func (x *GetCertificateRequest) LogValue() slog.Value {
+	return redact.LogValue(x)
+}
+
+// Redacted returns a copy of the message with its sensitive fields redacted.
+func (x *PrivateKey) Redacted() *PrivateKey {
+	return redact.Message(x)
+}
+
+// LogValue implements slog.LogValuer, logging the message redacted.
+func (x *PrivateKey) LogValue() slog.Value {
+	return redact.LogValue(x)
+}
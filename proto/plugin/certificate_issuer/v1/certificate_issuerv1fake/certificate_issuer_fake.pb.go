@@ -0,0 +1,67 @@
+// Code generated by protoc-gen-go-extension. DO NOT EDIT.
+
+// Package certificate_issuerv1fake provides fakes of the plugins of package certificate_issuerv1.
+package certificate_issuerv1fake
+
+import (
+	context "context"
+	api "github.com/openkcm/plugin-sdk/api"
+	v1 "github.com/openkcm/plugin-sdk/proto/plugin/certificate_issuer/v1"
+	sync "sync"
+)
+
+// CertificateIssuerService is a fake CertificateIssuerService plugin. The calls of its unary
+// methods are recorded and answered by the next response scripted with the
+// Return methods, or else by the function of the method, if set, or else
+// with an empty response. Streaming methods are only answered by their
+// function.
+type CertificateIssuerService struct {
+	v1.UnimplementedCertificateIssuerServiceServer
+
+	// GetCertificateFunc answers the calls of GetCertificate, if set.
+	GetCertificateFunc func(ctx context.Context, req *v1.GetCertificateRequest) (*v1.GetCertificateResponse, error)
+
+	mu                    sync.Mutex
+	getCertificateCalls   []*v1.GetCertificateRequest
+	getCertificateResults []func() (*v1.GetCertificateResponse, error)
+}
+
+// PluginServer returns the plugin server of the fake, e.g. to load it as a
+// built-in plugin with catalog.MakeBuiltIn.
+func (f *CertificateIssuerService) PluginServer() api.PluginServer {
+	return v1.CertificateIssuerServicePluginServer(f)
+}
+
+func (f *CertificateIssuerService) GetCertificate(ctx context.Context, req *v1.GetCertificateRequest) (*v1.GetCertificateResponse, error) {
+	f.mu.Lock()
+	f.getCertificateCalls = append(f.getCertificateCalls, req)
+	var result func() (*v1.GetCertificateResponse, error)
+	if len(f.getCertificateResults) > 0 {
+		result, f.getCertificateResults = f.getCertificateResults[0], f.getCertificateResults[1:]
+	}
+	fn := f.GetCertificateFunc
+	f.mu.Unlock()
+
+	switch {
+	case result != nil:
+		return result()
+	case fn != nil:
+		return fn(ctx, req)
+	}
+	return &v1.GetCertificateResponse{}, nil
+}
+
+// ReturnGetCertificate scripts the response of the next call of GetCertificate not
+// answered by a previously scripted response.
+func (f *CertificateIssuerService) ReturnGetCertificate(resp *v1.GetCertificateResponse, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getCertificateResults = append(f.getCertificateResults, func() (*v1.GetCertificateResponse, error) { return resp, err })
+}
+
+// GetCertificateCalls returns the requests of the calls of GetCertificate.
+func (f *CertificateIssuerService) GetCertificateCalls() []*v1.GetCertificateRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*v1.GetCertificateRequest(nil), f.getCertificateCalls...)
+}
@@ -0,0 +1,32 @@
+// Code generated by protoc-gen-go-extension. DO NOT EDIT.
+
+package identity_managementv1
+
+import (
+	fmt "fmt"
+	api "github.com/openkcm/plugin-sdk/api"
+	v1 "github.com/openkcm/plugin-sdk/proto/service/common/config/v1"
+)
+
+// NewBuiltIn registers impl as the built-in plugin of the given name with
+// api.RegisterBuiltIn. The plugin serves every plugin service of the package
+// impl implements, the first one being the plugin type, and the config
+// service if impl implements configv1.ConfigServer.
+func NewBuiltIn(name string, impl any) error {
+	var servers []api.PluginServer
+	if server, ok := impl.(IdentityManagementServiceServer); ok {
+		servers = append(servers, IdentityManagementServicePluginServer(server))
+	}
+	if len(servers) == 0 {
+		return fmt.Errorf("built-in plugin %q does not implement any plugin service of package identity_managementv1", name)
+	}
+	var services []api.ServiceServer
+	for _, server := range servers[1:] {
+		services = append(services, server)
+	}
+	if config, ok := impl.(v1.ConfigServer); ok {
+		services = append(services, v1.ConfigServiceServer(config))
+	}
+	api.RegisterBuiltIn(name, servers[0], services...)
+	return nil
+}
@@ -3,7 +3,9 @@
 package identity_managementv1
 
 import (
+	context "context"
 	api "github.com/openkcm/plugin-sdk/api"
+	plugin "github.com/openkcm/plugin-sdk/pkg/plugin"
 	grpc "google.golang.org/grpc"
 )
 
@@ -53,3 +55,120 @@ func (c *IdentityManagementServicePluginClient) InitClient(conn grpc.ClientConnI
 	c.IdentityManagementServiceClient = NewIdentityManagementServiceClient(conn)
 	return c.IdentityManagementServiceClient
 }
+
+// IdentityManagementServiceRepo is the repository of the IdentityManagementService plugins loaded by
+// the catalog. It is registered for the plugin Type.
+type IdentityManagementServiceRepo struct {
+	facades []*IdentityManagementServiceFacade
+}
+
+var _ api.PluginRepo = (*IdentityManagementServiceRepo)(nil)
+
+func (r *IdentityManagementServiceRepo) Binder() any {
+	return func(f *IdentityManagementServiceFacade) {
+		r.facades = append(r.facades, f)
+	}
+}
+
+func (r *IdentityManagementServiceRepo) Versions() []api.Version {
+	return []api.Version{IdentityManagementServiceVersion{}}
+}
+
+func (r *IdentityManagementServiceRepo) Constraints() api.Constraints {
+	return api.Constraints{Min: 0, Max: 0}
+}
+
+func (r *IdentityManagementServiceRepo) Clear() {
+	r.facades = nil
+}
+
+// Facades returns the facades of the loaded plugins.
+func (r *IdentityManagementServiceRepo) Facades() []*IdentityManagementServiceFacade {
+	return r.facades
+}
+
+// IdentityManagementServiceVersion is the version of the IdentityManagementService plugin facade.
+type IdentityManagementServiceVersion struct{}
+
+func (IdentityManagementServiceVersion) New() api.Facade  { return new(IdentityManagementServiceFacade) }
+func (IdentityManagementServiceVersion) Deprecated() bool { return false }
+
+// IdentityManagementServiceFacade is the facade of a loaded IdentityManagementService plugin.
+type IdentityManagementServiceFacade struct {
+	plugin.Facade
+	IdentityManagementServicePluginClient
+}
+
+func (f *IdentityManagementServiceFacade) Version() uint {
+	return 1
+}
+
+// GetUser calls GetUser on the plugin, prefixing errors with the plugin name.
+func (f *IdentityManagementServiceFacade) GetUser(ctx context.Context, req *GetUserRequest) (*GetUserResponse, error) {
+	f.LogCall(ctx, IdentityManagementService_GetUser_FullMethodName, req)
+	resp, err := f.IdentityManagementServicePluginClient.GetUser(ctx, req)
+	return resp, f.WrapErr(err)
+}
+
+// CallGetUser calls GetUser on the plugin with the fields of the
+// request, prefixing errors with the plugin name.
+func (f *IdentityManagementServiceFacade) CallGetUser(ctx context.Context, userId string, authContext *AuthContext) (user *User, err error) {
+	resp, err := f.GetUser(ctx, &GetUserRequest{UserId: userId, AuthContext: authContext})
+	return resp.GetUser(), err
+}
+
+// GetGroup calls GetGroup on the plugin, prefixing errors with the plugin name.
+func (f *IdentityManagementServiceFacade) GetGroup(ctx context.Context, req *GetGroupRequest) (*GetGroupResponse, error) {
+	f.LogCall(ctx, IdentityManagementService_GetGroup_FullMethodName, req)
+	resp, err := f.IdentityManagementServicePluginClient.GetGroup(ctx, req)
+	return resp, f.WrapErr(err)
+}
+
+// CallGetGroup calls GetGroup on the plugin with the fields of the
+// request, prefixing errors with the plugin name.
+func (f *IdentityManagementServiceFacade) CallGetGroup(ctx context.Context, groupName string, authContext *AuthContext) (group *Group, err error) {
+	resp, err := f.GetGroup(ctx, &GetGroupRequest{GroupName: groupName, AuthContext: authContext})
+	return resp.GetGroup(), err
+}
+
+// GetAllGroups calls GetAllGroups on the plugin, prefixing errors with the plugin name.
+func (f *IdentityManagementServiceFacade) GetAllGroups(ctx context.Context, req *GetAllGroupsRequest) (*GetAllGroupsResponse, error) {
+	f.LogCall(ctx, IdentityManagementService_GetAllGroups_FullMethodName, req)
+	resp, err := f.IdentityManagementServicePluginClient.GetAllGroups(ctx, req)
+	return resp, f.WrapErr(err)
+}
+
+// CallGetAllGroups calls GetAllGroups on the plugin with the fields of the
+// request, prefixing errors with the plugin name.
+func (f *IdentityManagementServiceFacade) CallGetAllGroups(ctx context.Context, authContext *AuthContext) (groups []*Group, err error) {
+	resp, err := f.GetAllGroups(ctx, &GetAllGroupsRequest{AuthContext: authContext})
+	return resp.GetGroups(), err
+}
+
+// GetUsersForGroup calls GetUsersForGroup on the plugin, prefixing errors with the plugin name.
+func (f *IdentityManagementServiceFacade) GetUsersForGroup(ctx context.Context, req *GetUsersForGroupRequest) (*GetUsersForGroupResponse, error) {
+	f.LogCall(ctx, IdentityManagementService_GetUsersForGroup_FullMethodName, req)
+	resp, err := f.IdentityManagementServicePluginClient.GetUsersForGroup(ctx, req)
+	return resp, f.WrapErr(err)
+}
+
+// CallGetUsersForGroup calls GetUsersForGroup on the plugin with the fields of the
+// request, prefixing errors with the plugin name.
+func (f *IdentityManagementServiceFacade) CallGetUsersForGroup(ctx context.Context, groupId string, authContext *AuthContext) (users []*User, err error) {
+	resp, err := f.GetUsersForGroup(ctx, &GetUsersForGroupRequest{GroupId: groupId, AuthContext: authContext})
+	return resp.GetUsers(), err
+}
+
+// GetGroupsForUser calls GetGroupsForUser on the plugin, prefixing errors with the plugin name.
+func (f *IdentityManagementServiceFacade) GetGroupsForUser(ctx context.Context, req *GetGroupsForUserRequest) (*GetGroupsForUserResponse, error) {
+	f.LogCall(ctx, IdentityManagementService_GetGroupsForUser_FullMethodName, req)
+	resp, err := f.IdentityManagementServicePluginClient.GetGroupsForUser(ctx, req)
+	return resp, f.WrapErr(err)
+}
+
+// CallGetGroupsForUser calls GetGroupsForUser on the plugin with the fields of the
+// request, prefixing errors with the plugin name.
+func (f *IdentityManagementServiceFacade) CallGetGroupsForUser(ctx context.Context, userId string, authContext *AuthContext) (groups []*Group, err error) {
+	resp, err := f.GetGroupsForUser(ctx, &GetGroupsForUserRequest{UserId: userId, AuthContext: authContext})
+	return resp.GetGroups(), err
+}
@@ -0,0 +1,219 @@
+// Code generated by protoc-gen-go-extension. DO NOT EDIT.
+
+// Package identity_managementv1fake provides fakes of the plugins of package identity_managementv1.
+package identity_managementv1fake
+
+import (
+	context "context"
+	api "github.com/openkcm/plugin-sdk/api"
+	v1 "github.com/openkcm/plugin-sdk/proto/plugin/identity_management/v1"
+	sync "sync"
+)
+
+// IdentityManagementService is a fake IdentityManagementService plugin. The calls of its unary
+// methods are recorded and answered by the next response scripted with the
+// Return methods, or else by the function of the method, if set, or else
+// with an empty response. Streaming methods are only answered by their
+// function.
+type IdentityManagementService struct {
+	v1.UnimplementedIdentityManagementServiceServer
+
+	// GetUserFunc answers the calls of GetUser, if set.
+	GetUserFunc func(ctx context.Context, req *v1.GetUserRequest) (*v1.GetUserResponse, error)
+	// GetGroupFunc answers the calls of GetGroup, if set.
+	GetGroupFunc func(ctx context.Context, req *v1.GetGroupRequest) (*v1.GetGroupResponse, error)
+	// GetAllGroupsFunc answers the calls of GetAllGroups, if set.
+	GetAllGroupsFunc func(ctx context.Context, req *v1.GetAllGroupsRequest) (*v1.GetAllGroupsResponse, error)
+	// GetUsersForGroupFunc answers the calls of GetUsersForGroup, if set.
+	GetUsersForGroupFunc func(ctx context.Context, req *v1.GetUsersForGroupRequest) (*v1.GetUsersForGroupResponse, error)
+	// GetGroupsForUserFunc answers the calls of GetGroupsForUser, if set.
+	GetGroupsForUserFunc func(ctx context.Context, req *v1.GetGroupsForUserRequest) (*v1.GetGroupsForUserResponse, error)
+
+	mu                      sync.Mutex
+	getUserCalls            []*v1.GetUserRequest
+	getUserResults          []func() (*v1.GetUserResponse, error)
+	getGroupCalls           []*v1.GetGroupRequest
+	getGroupResults         []func() (*v1.GetGroupResponse, error)
+	getAllGroupsCalls       []*v1.GetAllGroupsRequest
+	getAllGroupsResults     []func() (*v1.GetAllGroupsResponse, error)
+	getUsersForGroupCalls   []*v1.GetUsersForGroupRequest
+	getUsersForGroupResults []func() (*v1.GetUsersForGroupResponse, error)
+	getGroupsForUserCalls   []*v1.GetGroupsForUserRequest
+	getGroupsForUserResults []func() (*v1.GetGroupsForUserResponse, error)
+}
+
+// PluginServer returns the plugin server of the fake, e.g. to load it as a
+// built-in plugin with catalog.MakeBuiltIn.
+func (f *IdentityManagementService) PluginServer() api.PluginServer {
+	return v1.IdentityManagementServicePluginServer(f)
+}
+
+func (f *IdentityManagementService) GetUser(ctx context.Context, req *v1.GetUserRequest) (*v1.GetUserResponse, error) {
+	f.mu.Lock()
+	f.getUserCalls = append(f.getUserCalls, req)
+	var result func() (*v1.GetUserResponse, error)
+	if len(f.getUserResults) > 0 {
+		result, f.getUserResults = f.getUserResults[0], f.getUserResults[1:]
+	}
+	fn := f.GetUserFunc
+	f.mu.Unlock()
+
+	switch {
+	case result != nil:
+		return result()
+	case fn != nil:
+		return fn(ctx, req)
+	}
+	return &v1.GetUserResponse{}, nil
+}
+
+// ReturnGetUser scripts the response of the next call of GetUser not
+// answered by a previously scripted response.
+func (f *IdentityManagementService) ReturnGetUser(resp *v1.GetUserResponse, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getUserResults = append(f.getUserResults, func() (*v1.GetUserResponse, error) { return resp, err })
+}
+
+// GetUserCalls returns the requests of the calls of GetUser.
+func (f *IdentityManagementService) GetUserCalls() []*v1.GetUserRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*v1.GetUserRequest(nil), f.getUserCalls...)
+}
+
+func (f *IdentityManagementService) GetGroup(ctx context.Context, req *v1.GetGroupRequest) (*v1.GetGroupResponse, error) {
+	f.mu.Lock()
+	f.getGroupCalls = append(f.getGroupCalls, req)
+	var result func() (*v1.GetGroupResponse, error)
+	if len(f.getGroupResults) > 0 {
+		result, f.getGroupResults = f.getGroupResults[0], f.getGroupResults[1:]
+	}
+	fn := f.GetGroupFunc
+	f.mu.Unlock()
+
+	switch {
+	case result != nil:
+		return result()
+	case fn != nil:
+		return fn(ctx, req)
+	}
+	return &v1.GetGroupResponse{}, nil
+}
+
+// ReturnGetGroup scripts the response of the next call of GetGroup not
+// answered by a previously scripted response.
+func (f *IdentityManagementService) ReturnGetGroup(resp *v1.GetGroupResponse, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getGroupResults = append(f.getGroupResults, func() (*v1.GetGroupResponse, error) { return resp, err })
+}
+
+// GetGroupCalls returns the requests of the calls of GetGroup.
+func (f *IdentityManagementService) GetGroupCalls() []*v1.GetGroupRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*v1.GetGroupRequest(nil), f.getGroupCalls...)
+}
+
+func (f *IdentityManagementService) GetAllGroups(ctx context.Context, req *v1.GetAllGroupsRequest) (*v1.GetAllGroupsResponse, error) {
+	f.mu.Lock()
+	f.getAllGroupsCalls = append(f.getAllGroupsCalls, req)
+	var result func() (*v1.GetAllGroupsResponse, error)
+	if len(f.getAllGroupsResults) > 0 {
+		result, f.getAllGroupsResults = f.getAllGroupsResults[0], f.getAllGroupsResults[1:]
+	}
+	fn := f.GetAllGroupsFunc
+	f.mu.Unlock()
+
+	switch {
+	case result != nil:
+		return result()
+	case fn != nil:
+		return fn(ctx, req)
+	}
+	return &v1.GetAllGroupsResponse{}, nil
+}
+
+// ReturnGetAllGroups scripts the response of the next call of GetAllGroups not
+// answered by a previously scripted response.
+func (f *IdentityManagementService) ReturnGetAllGroups(resp *v1.GetAllGroupsResponse, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getAllGroupsResults = append(f.getAllGroupsResults, func() (*v1.GetAllGroupsResponse, error) { return resp, err })
+}
+
+// GetAllGroupsCalls returns the requests of the calls of GetAllGroups.
+func (f *IdentityManagementService) GetAllGroupsCalls() []*v1.GetAllGroupsRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*v1.GetAllGroupsRequest(nil), f.getAllGroupsCalls...)
+}
+
+func (f *IdentityManagementService) GetUsersForGroup(ctx context.Context, req *v1.GetUsersForGroupRequest) (*v1.GetUsersForGroupResponse, error) {
+	f.mu.Lock()
+	f.getUsersForGroupCalls = append(f.getUsersForGroupCalls, req)
+	var result func() (*v1.GetUsersForGroupResponse, error)
+	if len(f.getUsersForGroupResults) > 0 {
+		result, f.getUsersForGroupResults = f.getUsersForGroupResults[0], f.getUsersForGroupResults[1:]
+	}
+	fn := f.GetUsersForGroupFunc
+	f.mu.Unlock()
+
+	switch {
+	case result != nil:
+		return result()
+	case fn != nil:
+		return fn(ctx, req)
+	}
+	return &v1.GetUsersForGroupResponse{}, nil
+}
+
+// ReturnGetUsersForGroup scripts the response of the next call of GetUsersForGroup not
+// answered by a previously scripted response.
+func (f *IdentityManagementService) ReturnGetUsersForGroup(resp *v1.GetUsersForGroupResponse, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getUsersForGroupResults = append(f.getUsersForGroupResults, func() (*v1.GetUsersForGroupResponse, error) { return resp, err })
+}
+
+// GetUsersForGroupCalls returns the requests of the calls of GetUsersForGroup.
+func (f *IdentityManagementService) GetUsersForGroupCalls() []*v1.GetUsersForGroupRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*v1.GetUsersForGroupRequest(nil), f.getUsersForGroupCalls...)
+}
+
+func (f *IdentityManagementService) GetGroupsForUser(ctx context.Context, req *v1.GetGroupsForUserRequest) (*v1.GetGroupsForUserResponse, error) {
+	f.mu.Lock()
+	f.getGroupsForUserCalls = append(f.getGroupsForUserCalls, req)
+	var result func() (*v1.GetGroupsForUserResponse, error)
+	if len(f.getGroupsForUserResults) > 0 {
+		result, f.getGroupsForUserResults = f.getGroupsForUserResults[0], f.getGroupsForUserResults[1:]
+	}
+	fn := f.GetGroupsForUserFunc
+	f.mu.Unlock()
+
+	switch {
+	case result != nil:
+		return result()
+	case fn != nil:
+		return fn(ctx, req)
+	}
+	return &v1.GetGroupsForUserResponse{}, nil
+}
+
+// ReturnGetGroupsForUser scripts the response of the next call of GetGroupsForUser not
+// answered by a previously scripted response.
+func (f *IdentityManagementService) ReturnGetGroupsForUser(resp *v1.GetGroupsForUserResponse, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getGroupsForUserResults = append(f.getGroupsForUserResults, func() (*v1.GetGroupsForUserResponse, error) { return resp, err })
+}
+
+// GetGroupsForUserCalls returns the requests of the calls of GetGroupsForUser.
+func (f *IdentityManagementService) GetGroupsForUserCalls() []*v1.GetGroupsForUserRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*v1.GetGroupsForUserRequest(nil), f.getGroupsForUserCalls...)
+}
@@ -7,6 +7,7 @@
 package commonv1
 
 import (
+	_ "github.com/openkcm/plugin-sdk/proto/openkcm/options/v1"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	structpb "google.golang.org/protobuf/types/known/structpb"
@@ -72,9 +73,9 @@ var File_plugin_keystore_common_v1_common_proto protoreflect.FileDescriptor
 
 const file_plugin_keystore_common_v1_common_proto_rawDesc = "" +
 	"\n" +
-	"&plugin/keystore/common/v1/common.proto\x12\x19plugin.keystore.common.v1\x1a\x1cgoogle/protobuf/struct.proto\"I\n" +
-	"\x16KeystoreInstanceConfig\x12/\n" +
-	"\x06values\x18\x01 \x01(\v2\x17.google.protobuf.StructR\x06valuesBHZFgithub.com/openkcm/plugin-sdk/proto/plugin/keystore/common/v1;commonv1b\x06proto3"
+	"&plugin/keystore/common/v1/common.proto\x12\x19plugin.keystore.common.v1\x1a\x1cgoogle/protobuf/struct.proto\x1a openkcm/options/v1/options.proto\"O\n" +
+	"\x16KeystoreInstanceConfig\x125\n" +
+	"\x06values\x18\x01 \x01(\v2\x17.google.protobuf.StructB\x04\xa8\xbb\x18\x01R\x06valuesBHZFgithub.com/openkcm/plugin-sdk/proto/plugin/keystore/common/v1;commonv1b\x06proto3"
 
 var (
 	file_plugin_keystore_common_v1_common_proto_rawDescOnce sync.Once
@@ -7,6 +7,7 @@
 package managementv1
 
 import (
+	_ "github.com/openkcm/plugin-sdk/proto/openkcm/options/v1"
 	v1 "github.com/openkcm/plugin-sdk/proto/plugin/keystore/common/v1"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
@@ -605,7 +606,7 @@ var File_plugin_keystore_management_v1_management_proto protoreflect.FileDescrip
 
 const file_plugin_keystore_management_v1_management_proto_rawDesc = "" +
 	"\n" +
-	".plugin/keystore/management/v1/management.proto\x12\x1dplugin.keystore.management.v1\x1a\x1cgoogle/protobuf/struct.proto\x1a&plugin/keystore/common/v1/common.proto\"L\n" +
+	".plugin/keystore/management/v1/management.proto\x12\x1dplugin.keystore.management.v1\x1a\x1cgoogle/protobuf/struct.proto\x1a&plugin/keystore/common/v1/common.proto\x1a openkcm/options/v1/options.proto\"L\n" +
 	"\x0fSupportedRegion\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12%\n" +
 	"\x0etechnical_name\x18\x02 \x01(\tR\rtechnicalName\"\xa8\x01\n" +
@@ -615,9 +616,9 @@ const file_plugin_keystore_management_v1_management_proto_rawDesc = "" +
 	"\vcommon_name\x18\x02 \x01(\tR\n" +
 	"commonName\x12R\n" +
 	"\vaccess_data\x18\x03 \x01(\v21.plugin.keystore.common.v1.KeystoreInstanceConfigR\n" +
-	"accessData\"H\n" +
-	"\x15CreateKeystoreRequest\x12/\n" +
-	"\x06values\x18\x01 \x01(\v2\x17.google.protobuf.StructR\x06values\"\x90\x03\n" +
+	"accessData\"N\n" +
+	"\x15CreateKeystoreRequest\x125\n" +
+	"\x06values\x18\x01 \x01(\v2\x17.google.protobuf.StructB\x04\xa8\xbb\x18\x01R\x06values\"\x90\x03\n" +
 	"\x16CreateKeystoreResponse\x12M\n" +
 	"\x06config\x18\x01 \x01(\v21.plugin.keystore.common.v1.KeystoreInstanceConfigB\x02\x18\x01R\x06config\x12e\n" +
 	"\x16role_management_config\x18\x02 \x01(\v2/.plugin.keystore.management.v1.ManagementConfigR\x14roleManagementConfig\x12c\n" +
@@ -630,13 +631,13 @@ const file_plugin_keystore_management_v1_management_proto_rawDesc = "" +
 	"\x06config\x18\x01 \x01(\v21.plugin.keystore.common.v1.KeystoreInstanceConfigR\x06config\x12\x18\n" +
 	"\asubject\x18\x02 \x01(\tR\asubject\x12\x16\n" +
 	"\x06region\x18\x03 \x01(\tR\x06region\x12<\n" +
-	"\x04type\x18\x04 \x01(\x0e2(.plugin.keystore.management.v1.TrustTypeR\x04type\"N\n" +
-	"\x12GrantTrustResponse\x128\n" +
-	"\vaccess_data\x18\x01 \x01(\v2\x17.google.protobuf.StructR\n" +
-	"accessData\"\x99\x01\n" +
+	"\x04type\x18\x04 \x01(\x0e2(.plugin.keystore.management.v1.TrustTypeR\x04type\"T\n" +
+	"\x12GrantTrustResponse\x12>\n" +
+	"\vaccess_data\x18\x01 \x01(\v2\x17.google.protobuf.StructB\x04\xa8\xbb\x18\x01R\n" +
+	"accessData\"\x9f\x01\n" +
 	"\x12RemoveTrustRequest\x12I\n" +
-	"\x06config\x18\x01 \x01(\v21.plugin.keystore.common.v1.KeystoreInstanceConfigR\x06config\x128\n" +
-	"\vaccess_data\x18\x02 \x01(\v2\x17.google.protobuf.StructR\n" +
+	"\x06config\x18\x01 \x01(\v21.plugin.keystore.common.v1.KeystoreInstanceConfigR\x06config\x12>\n" +
+	"\vaccess_data\x18\x02 \x01(\v2\x17.google.protobuf.StructB\x04\xa8\xbb\x18\x01R\n" +
 	"accessData\"\x15\n" +
 	"\x13RemoveTrustResponse*Y\n" +
 	"\tTrustType\x12\x1a\n" +
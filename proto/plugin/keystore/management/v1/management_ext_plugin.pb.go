@@ -3,8 +3,14 @@
 package managementv1
 
 import (
+	context "context"
 	api "github.com/openkcm/plugin-sdk/api"
+	plugin "github.com/openkcm/plugin-sdk/pkg/plugin"
+	redact "github.com/openkcm/plugin-sdk/pkg/redact"
+	v1 "github.com/openkcm/plugin-sdk/proto/plugin/keystore/common/v1"
 	grpc "google.golang.org/grpc"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	slog "log/slog"
 )
 
 const (
@@ -53,3 +59,176 @@ func (c *KeystoreProviderPluginClient) InitClient(conn grpc.ClientConnInterface)
 	c.KeystoreProviderClient = NewKeystoreProviderClient(conn)
 	return c.KeystoreProviderClient
 }
+
+// KeystoreProviderRepo is the repository of the KeystoreProvider plugins loaded by
+// the catalog. It is registered for the plugin Type.
+type KeystoreProviderRepo struct {
+	facades []*KeystoreProviderFacade
+}
+
+var _ api.PluginRepo = (*KeystoreProviderRepo)(nil)
+
+func (r *KeystoreProviderRepo) Binder() any {
+	return func(f *KeystoreProviderFacade) {
+		r.facades = append(r.facades, f)
+	}
+}
+
+func (r *KeystoreProviderRepo) Versions() []api.Version {
+	return []api.Version{KeystoreProviderVersion{}}
+}
+
+func (r *KeystoreProviderRepo) Constraints() api.Constraints {
+	return api.Constraints{Min: 0, Max: 0}
+}
+
+func (r *KeystoreProviderRepo) Clear() {
+	r.facades = nil
+}
+
+// Facades returns the facades of the loaded plugins.
+func (r *KeystoreProviderRepo) Facades() []*KeystoreProviderFacade {
+	return r.facades
+}
+
+// KeystoreProviderVersion is the version of the KeystoreProvider plugin facade.
+type KeystoreProviderVersion struct{}
+
+func (KeystoreProviderVersion) New() api.Facade  { return new(KeystoreProviderFacade) }
+func (KeystoreProviderVersion) Deprecated() bool { return false }
+
+// KeystoreProviderFacade is the facade of a loaded KeystoreProvider plugin.
+type KeystoreProviderFacade struct {
+	plugin.Facade
+	KeystoreProviderPluginClient
+}
+
+func (f *KeystoreProviderFacade) Version() uint {
+	return 1
+}
+
+// CreateKeystore calls CreateKeystore on the plugin, prefixing errors with the plugin name.
+func (f *KeystoreProviderFacade) CreateKeystore(ctx context.Context, req *CreateKeystoreRequest) (*CreateKeystoreResponse, error) {
+	f.LogCall(ctx, KeystoreProvider_CreateKeystore_FullMethodName, req)
+	resp, err := f.KeystoreProviderPluginClient.CreateKeystore(ctx, req)
+	return resp, f.WrapErr(err)
+}
+
+// CallCreateKeystore calls CreateKeystore on the plugin with the fields of the
+// request, prefixing errors with the plugin name.
+func (f *KeystoreProviderFacade) CallCreateKeystore(ctx context.Context, values *structpb.Struct) (*CreateKeystoreResponse, error) {
+	resp, err := f.CreateKeystore(ctx, &CreateKeystoreRequest{Values: values})
+	return resp, err
+}
+
+// DeleteKeystore calls DeleteKeystore on the plugin, prefixing errors with the plugin name.
+func (f *KeystoreProviderFacade) DeleteKeystore(ctx context.Context, req *DeleteKeystoreRequest) (*DeleteKeystoreResponse, error) {
+	f.LogCall(ctx, KeystoreProvider_DeleteKeystore_FullMethodName, req)
+	resp, err := f.KeystoreProviderPluginClient.DeleteKeystore(ctx, req)
+	return resp, f.WrapErr(err)
+}
+
+// CallDeleteKeystore calls DeleteKeystore on the plugin with the fields of the
+// request, prefixing errors with the plugin name.
+func (f *KeystoreProviderFacade) CallDeleteKeystore(ctx context.Context, config *v1.KeystoreInstanceConfig) error {
+	_, err := f.DeleteKeystore(ctx, &DeleteKeystoreRequest{Config: config})
+	return err
+}
+
+// GrantTrust calls GrantTrust on the plugin, prefixing errors with the plugin name.
+func (f *KeystoreProviderFacade) GrantTrust(ctx context.Context, req *GrantTrustRequest) (*GrantTrustResponse, error) {
+	f.LogCall(ctx, KeystoreProvider_GrantTrust_FullMethodName, req)
+	resp, err := f.KeystoreProviderPluginClient.GrantTrust(ctx, req)
+	return resp, f.WrapErr(err)
+}
+
+// CallGrantTrust calls GrantTrust on the plugin with the fields of the
+// request, prefixing errors with the plugin name.
+func (f *KeystoreProviderFacade) CallGrantTrust(ctx context.Context, config *v1.KeystoreInstanceConfig, subject string, region string, type_ TrustType) (accessData *structpb.Struct, err error) {
+	resp, err := f.GrantTrust(ctx, &GrantTrustRequest{Config: config, Subject: subject, Region: region, Type: type_})
+	return resp.GetAccessData(), err
+}
+
+// RemoveTrust calls RemoveTrust on the plugin, prefixing errors with the plugin name.
+func (f *KeystoreProviderFacade) RemoveTrust(ctx context.Context, req *RemoveTrustRequest) (*RemoveTrustResponse, error) {
+	f.LogCall(ctx, KeystoreProvider_RemoveTrust_FullMethodName, req)
+	resp, err := f.KeystoreProviderPluginClient.RemoveTrust(ctx, req)
+	return resp, f.WrapErr(err)
+}
+
+// CallRemoveTrust calls RemoveTrust on the plugin with the fields of the
+// request, prefixing errors with the plugin name.
+func (f *KeystoreProviderFacade) CallRemoveTrust(ctx context.Context, config *v1.KeystoreInstanceConfig, accessData *structpb.Struct) error {
+	_, err := f.RemoveTrust(ctx, &RemoveTrustRequest{Config: config, AccessData: accessData})
+	return err
+}
+
+// Redacted returns a copy of the message with its sensitive fields redacted.
+func (x *ManagementConfig) Redacted() *ManagementConfig {
+	return redact.Message(x)
+}
+
+// LogValue implements slog.LogValuer, logging the message redacted.
+func (x *ManagementConfig) LogValue() slog.Value {
+	return redact.LogValue(x)
+}
+
+// Redacted returns a copy of the message with its sensitive fields redacted.
+func (x *CreateKeystoreRequest) Redacted() *CreateKeystoreRequest {
+	return redact.Message(x)
+}
+
+// LogValue implements slog.LogValuer, logging the message redacted.
+func (x *CreateKeystoreRequest) LogValue() slog.Value {
+	return redact.LogValue(x)
+}
+
+// Redacted returns a copy of the message with its sensitive fields redacted.
+func (x *CreateKeystoreResponse) Redacted() *CreateKeystoreResponse {
+	return redact.Message(x)
+}
+
+// LogValue implements slog.LogValuer, logging the message redacted.
+func (x *CreateKeystoreResponse) LogValue() slog.Value {
+	return redact.LogValue(x)
+}
+
+// Redacted returns a copy of the message with its sensitive fields redacted.
+func (x *DeleteKeystoreRequest) Redacted() *DeleteKeystoreRequest {
+	return redact.Message(x)
+}
+
+// LogValue implements slog.LogValuer, logging the message redacted.
+func (x *DeleteKeystoreRequest) LogValue() slog.Value {
+	return redact.LogValue(x)
+}
+
+// Redacted returns a copy of the message with its sensitive fields redacted.
+func (x *GrantTrustRequest) Redacted() *GrantTrustRequest {
+	return redact.Message(x)
+}
+
+// LogValue implements slog.LogValuer, logging the message redacted.
+func (x *GrantTrustRequest) LogValue() slog.Value {
+	return redact.LogValue(x)
+}
+
+// Redacted returns a copy of the message with its sensitive fields redacted.
+func (x *GrantTrustResponse) Redacted() *GrantTrustResponse {
+	return redact.Message(x)
+}
+
+// LogValue implements slog.LogValuer, logging the message redacted.
+func (x *GrantTrustResponse) LogValue() slog.Value {
+	return redact.LogValue(x)
+}
+
+// Redacted returns a copy of the message with its sensitive fields redacted.
+func (x *RemoveTrustRequest) Redacted() *RemoveTrustRequest {
+	return redact.Message(x)
+}
+
+// LogValue implements slog.LogValuer, logging the message redacted.
+func (x *RemoveTrustRequest) LogValue() slog.Value {
+	return redact.LogValue(x)
+}
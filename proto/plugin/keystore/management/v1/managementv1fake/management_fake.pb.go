@@ -0,0 +1,181 @@
+// Code generated by protoc-gen-go-extension. DO NOT EDIT.
+
+// Package managementv1fake provides fakes of the plugins of package managementv1.
+package managementv1fake
+
+import (
+	context "context"
+	api "github.com/openkcm/plugin-sdk/api"
+	v1 "github.com/openkcm/plugin-sdk/proto/plugin/keystore/management/v1"
+	sync "sync"
+)
+
+// KeystoreProvider is a fake KeystoreProvider plugin. The calls of its unary
+// methods are recorded and answered by the next response scripted with the
+// Return methods, or else by the function of the method, if set, or else
+// with an empty response. Streaming methods are only answered by their
+// function.
+type KeystoreProvider struct {
+	v1.UnimplementedKeystoreProviderServer
+
+	// CreateKeystoreFunc answers the calls of CreateKeystore, if set.
+	CreateKeystoreFunc func(ctx context.Context, req *v1.CreateKeystoreRequest) (*v1.CreateKeystoreResponse, error)
+	// DeleteKeystoreFunc answers the calls of DeleteKeystore, if set.
+	DeleteKeystoreFunc func(ctx context.Context, req *v1.DeleteKeystoreRequest) (*v1.DeleteKeystoreResponse, error)
+	// GrantTrustFunc answers the calls of GrantTrust, if set.
+	GrantTrustFunc func(ctx context.Context, req *v1.GrantTrustRequest) (*v1.GrantTrustResponse, error)
+	// RemoveTrustFunc answers the calls of RemoveTrust, if set.
+	RemoveTrustFunc func(ctx context.Context, req *v1.RemoveTrustRequest) (*v1.RemoveTrustResponse, error)
+
+	mu                    sync.Mutex
+	createKeystoreCalls   []*v1.CreateKeystoreRequest
+	createKeystoreResults []func() (*v1.CreateKeystoreResponse, error)
+	deleteKeystoreCalls   []*v1.DeleteKeystoreRequest
+	deleteKeystoreResults []func() (*v1.DeleteKeystoreResponse, error)
+	grantTrustCalls       []*v1.GrantTrustRequest
+	grantTrustResults     []func() (*v1.GrantTrustResponse, error)
+	removeTrustCalls      []*v1.RemoveTrustRequest
+	removeTrustResults    []func() (*v1.RemoveTrustResponse, error)
+}
+
+// PluginServer returns the plugin server of the fake, e.g. to load it as a
+// built-in plugin with catalog.MakeBuiltIn.
+func (f *KeystoreProvider) PluginServer() api.PluginServer {
+	return v1.KeystoreProviderPluginServer(f)
+}
+
+func (f *KeystoreProvider) CreateKeystore(ctx context.Context, req *v1.CreateKeystoreRequest) (*v1.CreateKeystoreResponse, error) {
+	f.mu.Lock()
+	f.createKeystoreCalls = append(f.createKeystoreCalls, req)
+	var result func() (*v1.CreateKeystoreResponse, error)
+	if len(f.createKeystoreResults) > 0 {
+		result, f.createKeystoreResults = f.createKeystoreResults[0], f.createKeystoreResults[1:]
+	}
+	fn := f.CreateKeystoreFunc
+	f.mu.Unlock()
+
+	switch {
+	case result != nil:
+		return result()
+	case fn != nil:
+		return fn(ctx, req)
+	}
+	return &v1.CreateKeystoreResponse{}, nil
+}
+
+// ReturnCreateKeystore scripts the response of the next call of CreateKeystore not
+// answered by a previously scripted response.
+func (f *KeystoreProvider) ReturnCreateKeystore(resp *v1.CreateKeystoreResponse, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.createKeystoreResults = append(f.createKeystoreResults, func() (*v1.CreateKeystoreResponse, error) { return resp, err })
+}
+
+// CreateKeystoreCalls returns the requests of the calls of CreateKeystore.
+func (f *KeystoreProvider) CreateKeystoreCalls() []*v1.CreateKeystoreRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*v1.CreateKeystoreRequest(nil), f.createKeystoreCalls...)
+}
+
+func (f *KeystoreProvider) DeleteKeystore(ctx context.Context, req *v1.DeleteKeystoreRequest) (*v1.DeleteKeystoreResponse, error) {
+	f.mu.Lock()
+	f.deleteKeystoreCalls = append(f.deleteKeystoreCalls, req)
+	var result func() (*v1.DeleteKeystoreResponse, error)
+	if len(f.deleteKeystoreResults) > 0 {
+		result, f.deleteKeystoreResults = f.deleteKeystoreResults[0], f.deleteKeystoreResults[1:]
+	}
+	fn := f.DeleteKeystoreFunc
+	f.mu.Unlock()
+
+	switch {
+	case result != nil:
+		return result()
+	case fn != nil:
+		return fn(ctx, req)
+	}
+	return &v1.DeleteKeystoreResponse{}, nil
+}
+
+// ReturnDeleteKeystore scripts the response of the next call of DeleteKeystore not
+// answered by a previously scripted response.
+func (f *KeystoreProvider) ReturnDeleteKeystore(resp *v1.DeleteKeystoreResponse, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.deleteKeystoreResults = append(f.deleteKeystoreResults, func() (*v1.DeleteKeystoreResponse, error) { return resp, err })
+}
+
+// DeleteKeystoreCalls returns the requests of the calls of DeleteKeystore.
+func (f *KeystoreProvider) DeleteKeystoreCalls() []*v1.DeleteKeystoreRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*v1.DeleteKeystoreRequest(nil), f.deleteKeystoreCalls...)
+}
+
+func (f *KeystoreProvider) GrantTrust(ctx context.Context, req *v1.GrantTrustRequest) (*v1.GrantTrustResponse, error) {
+	f.mu.Lock()
+	f.grantTrustCalls = append(f.grantTrustCalls, req)
+	var result func() (*v1.GrantTrustResponse, error)
+	if len(f.grantTrustResults) > 0 {
+		result, f.grantTrustResults = f.grantTrustResults[0], f.grantTrustResults[1:]
+	}
+	fn := f.GrantTrustFunc
+	f.mu.Unlock()
+
+	switch {
+	case result != nil:
+		return result()
+	case fn != nil:
+		return fn(ctx, req)
+	}
+	return &v1.GrantTrustResponse{}, nil
+}
+
+// ReturnGrantTrust scripts the response of the next call of GrantTrust not
+// answered by a previously scripted response.
+func (f *KeystoreProvider) ReturnGrantTrust(resp *v1.GrantTrustResponse, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.grantTrustResults = append(f.grantTrustResults, func() (*v1.GrantTrustResponse, error) { return resp, err })
+}
+
+// GrantTrustCalls returns the requests of the calls of GrantTrust.
+func (f *KeystoreProvider) GrantTrustCalls() []*v1.GrantTrustRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*v1.GrantTrustRequest(nil), f.grantTrustCalls...)
+}
+
+func (f *KeystoreProvider) RemoveTrust(ctx context.Context, req *v1.RemoveTrustRequest) (*v1.RemoveTrustResponse, error) {
+	f.mu.Lock()
+	f.removeTrustCalls = append(f.removeTrustCalls, req)
+	var result func() (*v1.RemoveTrustResponse, error)
+	if len(f.removeTrustResults) > 0 {
+		result, f.removeTrustResults = f.removeTrustResults[0], f.removeTrustResults[1:]
+	}
+	fn := f.RemoveTrustFunc
+	f.mu.Unlock()
+
+	switch {
+	case result != nil:
+		return result()
+	case fn != nil:
+		return fn(ctx, req)
+	}
+	return &v1.RemoveTrustResponse{}, nil
+}
+
+// ReturnRemoveTrust scripts the response of the next call of RemoveTrust not
+// answered by a previously scripted response.
+func (f *KeystoreProvider) ReturnRemoveTrust(resp *v1.RemoveTrustResponse, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.removeTrustResults = append(f.removeTrustResults, func() (*v1.RemoveTrustResponse, error) { return resp, err })
+}
+
+// RemoveTrustCalls returns the requests of the calls of RemoveTrust.
+func (f *KeystoreProvider) RemoveTrustCalls() []*v1.RemoveTrustRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*v1.RemoveTrustRequest(nil), f.removeTrustCalls...)
+}
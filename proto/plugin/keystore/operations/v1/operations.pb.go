@@ -7,6 +7,7 @@
 package operationsv1
 
 import (
+	_ "github.com/openkcm/plugin-sdk/proto/openkcm/options/v1"
 	v1 "github.com/openkcm/plugin-sdk/proto/plugin/keystore/common/v1"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
@@ -1323,7 +1324,7 @@ var File_plugin_keystore_operations_v1_operations_proto protoreflect.FileDescrip
 
 const file_plugin_keystore_operations_v1_operations_proto_rawDesc = "" +
 	"\n" +
-	".plugin/keystore/operations/v1/operations.proto\x12\x1dplugin.keystore.operations.v1\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a&plugin/keystore/common/v1/common.proto\"u\n" +
+	".plugin/keystore/operations/v1/operations.proto\x12\x1dplugin.keystore.operations.v1\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a&plugin/keystore/common/v1/common.proto\x1a openkcm/options/v1/options.proto\"u\n" +
 	"\x11RequestParameters\x12I\n" +
 	"\x06config\x18\x01 \x01(\v21.plugin.keystore.common.v1.KeystoreInstanceConfigR\x06config\x12\x15\n" +
 	"\x06key_id\x18\x02 \x01(\tR\x05keyId\"a\n" +
@@ -1373,13 +1374,13 @@ const file_plugin_keystore_operations_v1_operations_proto_rawDesc = "" +
 	"\talgorithm\x18\x02 \x01(\x0e2+.plugin.keystore.operations.v1.KeyAlgorithmR\talgorithm\"z\n" +
 	"\x1bGetImportParametersResponse\x12\x15\n" +
 	"\x06key_id\x18\x01 \x01(\tR\x05keyId\x12D\n" +
-	"\x11import_parameters\x18\x02 \x01(\v2\x17.google.protobuf.StructR\x10importParameters\"\xe8\x01\n" +
+	"\x11import_parameters\x18\x02 \x01(\v2\x17.google.protobuf.StructR\x10importParameters\"\xee\x01\n" +
 	"\x18ImportKeyMaterialRequest\x12P\n" +
 	"\n" +
 	"parameters\x18\x01 \x01(\v20.plugin.keystore.operations.v1.RequestParametersR\n" +
 	"parameters\x12D\n" +
-	"\x11import_parameters\x18\x02 \x01(\v2\x17.google.protobuf.StructR\x10importParameters\x124\n" +
-	"\x16encrypted_key_material\x18\x03 \x01(\tR\x14encryptedKeyMaterial\"\x1b\n" +
+	"\x11import_parameters\x18\x02 \x01(\v2\x17.google.protobuf.StructR\x10importParameters\x12:\n" +
+	"\x16encrypted_key_material\x18\x03 \x01(\tB\x04\xa8\xbb\x18\x01R\x14encryptedKeyMaterial\"\x1b\n" +
 	"\x19ImportKeyMaterialResponse\"\xde\x01\n" +
 	"\x12ValidateKeyRequest\x12A\n" +
 	"\bkey_type\x18\x01 \x01(\x0e2&.plugin.keystore.operations.v1.KeyTypeR\akeyType\x12I\n" +
@@ -1388,27 +1389,27 @@ const file_plugin_keystore_operations_v1_operations_proto_rawDesc = "" +
 	"\rnative_key_id\x18\x04 \x01(\tR\vnativeKeyId\"J\n" +
 	"\x13ValidateKeyResponse\x12\x19\n" +
 	"\bis_valid\x18\x01 \x01(\bR\aisValid\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"\x88\x01\n" +
-	"\x1cValidateKeyAccessDataRequest\x127\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x94\x01\n" +
+	"\x1cValidateKeyAccessDataRequest\x12=\n" +
 	"\n" +
-	"management\x18\x01 \x01(\v2\x17.google.protobuf.StructR\n" +
-	"management\x12/\n" +
-	"\x06crypto\x18\x02 \x01(\v2\x17.google.protobuf.StructR\x06crypto\"T\n" +
+	"management\x18\x01 \x01(\v2\x17.google.protobuf.StructB\x04\xa8\xbb\x18\x01R\n" +
+	"management\x125\n" +
+	"\x06crypto\x18\x02 \x01(\v2\x17.google.protobuf.StructB\x04\xa8\xbb\x18\x01R\x06crypto\"T\n" +
 	"\x1dValidateKeyAccessDataResponse\x12\x19\n" +
 	"\bis_valid\x18\x01 \x01(\bR\aisValid\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"g\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"m\n" +
 	" TransformCryptoAccessDataRequest\x12\"\n" +
-	"\rnative_key_id\x18\x01 \x01(\tR\vnativeKeyId\x12\x1f\n" +
-	"\vaccess_data\x18\x02 \x01(\fR\n" +
-	"accessData\"\x83\x02\n" +
-	"!TransformCryptoAccessDataResponse\x12\x93\x01\n" +
-	"\x17transformed_access_data\x18\x01 \x03(\v2[.plugin.keystore.operations.v1.TransformCryptoAccessDataResponse.TransformedAccessDataEntryR\x15transformedAccessData\x1aH\n" +
+	"\rnative_key_id\x18\x01 \x01(\tR\vnativeKeyId\x12%\n" +
+	"\vaccess_data\x18\x02 \x01(\fB\x04\xa8\xbb\x18\x01R\n" +
+	"accessData\"\x89\x02\n" +
+	"!TransformCryptoAccessDataResponse\x12\x99\x01\n" +
+	"\x17transformed_access_data\x18\x01 \x03(\v2[.plugin.keystore.operations.v1.TransformCryptoAccessDataResponse.TransformedAccessDataEntryB\x04\xa8\xbb\x18\x01R\x15transformedAccessData\x1aH\n" +
 	"\x1aTransformedAccessDataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\fR\x05value:\x028\x01\"\x8c\x01\n" +
+	"\x05value\x18\x02 \x01(\fR\x05value:\x028\x01\"\x92\x01\n" +
 	"\x17ExtractKeyRegionRequest\x12\"\n" +
-	"\rnative_key_id\x18\x01 \x01(\tR\vnativeKeyId\x12M\n" +
-	"\x16management_access_data\x18\x02 \x01(\v2\x17.google.protobuf.StructR\x14managementAccessData\"2\n" +
+	"\rnative_key_id\x18\x01 \x01(\tR\vnativeKeyId\x12S\n" +
+	"\x16management_access_data\x18\x02 \x01(\v2\x17.google.protobuf.StructB\x04\xa8\xbb\x18\x01R\x14managementAccessData\"2\n" +
 	"\x18ExtractKeyRegionResponse\x12\x16\n" +
 	"\x06region\x18\x01 \x01(\tR\x06region*}\n" +
 	"\fKeyAlgorithm\x12\x1d\n" +
@@ -3,8 +3,14 @@
 package operationsv1
 
 import (
+	context "context"
 	api "github.com/openkcm/plugin-sdk/api"
+	plugin "github.com/openkcm/plugin-sdk/pkg/plugin"
+	redact "github.com/openkcm/plugin-sdk/pkg/redact"
+	v1 "github.com/openkcm/plugin-sdk/proto/plugin/keystore/common/v1"
 	grpc "google.golang.org/grpc"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	slog "log/slog"
 )
 
 const (
@@ -53,3 +59,326 @@ func (c *KeystoreInstanceKeyOperationPluginClient) InitClient(conn grpc.ClientCo
 	c.KeystoreInstanceKeyOperationClient = NewKeystoreInstanceKeyOperationClient(conn)
 	return c.KeystoreInstanceKeyOperationClient
 }
+
+// KeystoreInstanceKeyOperationRepo is the repository of the KeystoreInstanceKeyOperation plugins loaded by
+// the catalog. It is registered for the plugin Type.
+type KeystoreInstanceKeyOperationRepo struct {
+	facades []*KeystoreInstanceKeyOperationFacade
+}
+
+var _ api.PluginRepo = (*KeystoreInstanceKeyOperationRepo)(nil)
+
+func (r *KeystoreInstanceKeyOperationRepo) Binder() any {
+	return func(f *KeystoreInstanceKeyOperationFacade) {
+		r.facades = append(r.facades, f)
+	}
+}
+
+func (r *KeystoreInstanceKeyOperationRepo) Versions() []api.Version {
+	return []api.Version{KeystoreInstanceKeyOperationVersion{}}
+}
+
+func (r *KeystoreInstanceKeyOperationRepo) Constraints() api.Constraints {
+	return api.Constraints{Min: 0, Max: 0}
+}
+
+func (r *KeystoreInstanceKeyOperationRepo) Clear() {
+	r.facades = nil
+}
+
+// Facades returns the facades of the loaded plugins.
+func (r *KeystoreInstanceKeyOperationRepo) Facades() []*KeystoreInstanceKeyOperationFacade {
+	return r.facades
+}
+
+// KeystoreInstanceKeyOperationVersion is the version of the KeystoreInstanceKeyOperation plugin facade.
+type KeystoreInstanceKeyOperationVersion struct{}
+
+func (KeystoreInstanceKeyOperationVersion) New() api.Facade {
+	return new(KeystoreInstanceKeyOperationFacade)
+}
+func (KeystoreInstanceKeyOperationVersion) Deprecated() bool { return false }
+
+// KeystoreInstanceKeyOperationFacade is the facade of a loaded KeystoreInstanceKeyOperation plugin.
+type KeystoreInstanceKeyOperationFacade struct {
+	plugin.Facade
+	KeystoreInstanceKeyOperationPluginClient
+}
+
+func (f *KeystoreInstanceKeyOperationFacade) Version() uint {
+	return 1
+}
+
+// GetKey calls GetKey on the plugin, prefixing errors with the plugin name.
+func (f *KeystoreInstanceKeyOperationFacade) GetKey(ctx context.Context, req *GetKeyRequest) (*GetKeyResponse, error) {
+	f.LogCall(ctx, KeystoreInstanceKeyOperation_GetKey_FullMethodName, req)
+	resp, err := f.KeystoreInstanceKeyOperationPluginClient.GetKey(ctx, req)
+	return resp, f.WrapErr(err)
+}
+
+// CallGetKey calls GetKey on the plugin with the fields of the
+// request, prefixing errors with the plugin name.
+func (f *KeystoreInstanceKeyOperationFacade) CallGetKey(ctx context.Context, parameters *RequestParameters) (*GetKeyResponse, error) {
+	resp, err := f.GetKey(ctx, &GetKeyRequest{Parameters: parameters})
+	return resp, err
+}
+
+// CreateKey calls CreateKey on the plugin, prefixing errors with the plugin name.
+func (f *KeystoreInstanceKeyOperationFacade) CreateKey(ctx context.Context, req *CreateKeyRequest) (*CreateKeyResponse, error) {
+	f.LogCall(ctx, KeystoreInstanceKeyOperation_CreateKey_FullMethodName, req)
+	resp, err := f.KeystoreInstanceKeyOperationPluginClient.CreateKey(ctx, req)
+	return resp, f.WrapErr(err)
+}
+
+// CallCreateKey calls CreateKey on the plugin with the fields of the
+// request, prefixing errors with the plugin name.
+func (f *KeystoreInstanceKeyOperationFacade) CallCreateKey(ctx context.Context, config *v1.KeystoreInstanceConfig, algorithm KeyAlgorithm, id *string, region string, keyType KeyType) (keyId string, status string, err error) {
+	resp, err := f.CreateKey(ctx, &CreateKeyRequest{Config: config, Algorithm: algorithm, Id: id, Region: region, KeyType: keyType})
+	return resp.GetKeyId(), resp.GetStatus(), err
+}
+
+// DeleteKey calls DeleteKey on the plugin, prefixing errors with the plugin name.
+func (f *KeystoreInstanceKeyOperationFacade) DeleteKey(ctx context.Context, req *DeleteKeyRequest) (*DeleteKeyResponse, error) {
+	f.LogCall(ctx, KeystoreInstanceKeyOperation_DeleteKey_FullMethodName, req)
+	resp, err := f.KeystoreInstanceKeyOperationPluginClient.DeleteKey(ctx, req)
+	return resp, f.WrapErr(err)
+}
+
+// CallDeleteKey calls DeleteKey on the plugin with the fields of the
+// request, prefixing errors with the plugin name.
+func (f *KeystoreInstanceKeyOperationFacade) CallDeleteKey(ctx context.Context, parameters *RequestParameters, window *int32) error {
+	_, err := f.DeleteKey(ctx, &DeleteKeyRequest{Parameters: parameters, Window: window})
+	return err
+}
+
+// EnableKey calls EnableKey on the plugin, prefixing errors with the plugin name.
+func (f *KeystoreInstanceKeyOperationFacade) EnableKey(ctx context.Context, req *EnableKeyRequest) (*EnableKeyResponse, error) {
+	f.LogCall(ctx, KeystoreInstanceKeyOperation_EnableKey_FullMethodName, req)
+	resp, err := f.KeystoreInstanceKeyOperationPluginClient.EnableKey(ctx, req)
+	return resp, f.WrapErr(err)
+}
+
+// CallEnableKey calls EnableKey on the plugin with the fields of the
+// request, prefixing errors with the plugin name.
+func (f *KeystoreInstanceKeyOperationFacade) CallEnableKey(ctx context.Context, parameters *RequestParameters) error {
+	_, err := f.EnableKey(ctx, &EnableKeyRequest{Parameters: parameters})
+	return err
+}
+
+// DisableKey calls DisableKey on the plugin, prefixing errors with the plugin name.
+func (f *KeystoreInstanceKeyOperationFacade) DisableKey(ctx context.Context, req *DisableKeyRequest) (*DisableKeyResponse, error) {
+	f.LogCall(ctx, KeystoreInstanceKeyOperation_DisableKey_FullMethodName, req)
+	resp, err := f.KeystoreInstanceKeyOperationPluginClient.DisableKey(ctx, req)
+	return resp, f.WrapErr(err)
+}
+
+// CallDisableKey calls DisableKey on the plugin with the fields of the
+// request, prefixing errors with the plugin name.
+func (f *KeystoreInstanceKeyOperationFacade) CallDisableKey(ctx context.Context, parameters *RequestParameters) error {
+	_, err := f.DisableKey(ctx, &DisableKeyRequest{Parameters: parameters})
+	return err
+}
+
+// GetImportParameters calls GetImportParameters on the plugin, prefixing errors with the plugin name.
+func (f *KeystoreInstanceKeyOperationFacade) GetImportParameters(ctx context.Context, req *GetImportParametersRequest) (*GetImportParametersResponse, error) {
+	f.LogCall(ctx, KeystoreInstanceKeyOperation_GetImportParameters_FullMethodName, req)
+	resp, err := f.KeystoreInstanceKeyOperationPluginClient.GetImportParameters(ctx, req)
+	return resp, f.WrapErr(err)
+}
+
+// CallGetImportParameters calls GetImportParameters on the plugin with the fields of the
+// request, prefixing errors with the plugin name.
+func (f *KeystoreInstanceKeyOperationFacade) CallGetImportParameters(ctx context.Context, parameters *RequestParameters, algorithm KeyAlgorithm) (keyId string, importParameters *structpb.Struct, err error) {
+	resp, err := f.GetImportParameters(ctx, &GetImportParametersRequest{Parameters: parameters, Algorithm: algorithm})
+	return resp.GetKeyId(), resp.GetImportParameters(), err
+}
+
+// ImportKeyMaterial calls ImportKeyMaterial on the plugin, prefixing errors with the plugin name.
+func (f *KeystoreInstanceKeyOperationFacade) ImportKeyMaterial(ctx context.Context, req *ImportKeyMaterialRequest) (*ImportKeyMaterialResponse, error) {
+	f.LogCall(ctx, KeystoreInstanceKeyOperation_ImportKeyMaterial_FullMethodName, req)
+	resp, err := f.KeystoreInstanceKeyOperationPluginClient.ImportKeyMaterial(ctx, req)
+	return resp, f.WrapErr(err)
+}
+
+// CallImportKeyMaterial calls ImportKeyMaterial on the plugin with the fields of the
+// request, prefixing errors with the plugin name.
+func (f *KeystoreInstanceKeyOperationFacade) CallImportKeyMaterial(ctx context.Context, parameters *RequestParameters, importParameters *structpb.Struct, encryptedKeyMaterial string) error {
+	_, err := f.ImportKeyMaterial(ctx, &ImportKeyMaterialRequest{Parameters: parameters, ImportParameters: importParameters, EncryptedKeyMaterial: encryptedKeyMaterial})
+	return err
+}
+
+// ValidateKey calls ValidateKey on the plugin, prefixing errors with the plugin name.
+func (f *KeystoreInstanceKeyOperationFacade) ValidateKey(ctx context.Context, req *ValidateKeyRequest) (*ValidateKeyResponse, error) {
+	f.LogCall(ctx, KeystoreInstanceKeyOperation_ValidateKey_FullMethodName, req)
+	resp, err := f.KeystoreInstanceKeyOperationPluginClient.ValidateKey(ctx, req)
+	return resp, f.WrapErr(err)
+}
+
+// CallValidateKey calls ValidateKey on the plugin with the fields of the
+// request, prefixing errors with the plugin name.
+func (f *KeystoreInstanceKeyOperationFacade) CallValidateKey(ctx context.Context, keyType KeyType, algorithm KeyAlgorithm, region string, nativeKeyId string) (isValid bool, message string, err error) {
+	resp, err := f.ValidateKey(ctx, &ValidateKeyRequest{KeyType: keyType, Algorithm: algorithm, Region: region, NativeKeyId: nativeKeyId})
+	return resp.GetIsValid(), resp.GetMessage(), err
+}
+
+// ValidateKeyAccessData calls ValidateKeyAccessData on the plugin, prefixing errors with the plugin name.
+func (f *KeystoreInstanceKeyOperationFacade) ValidateKeyAccessData(ctx context.Context, req *ValidateKeyAccessDataRequest) (*ValidateKeyAccessDataResponse, error) {
+	f.LogCall(ctx, KeystoreInstanceKeyOperation_ValidateKeyAccessData_FullMethodName, req)
+	resp, err := f.KeystoreInstanceKeyOperationPluginClient.ValidateKeyAccessData(ctx, req)
+	return resp, f.WrapErr(err)
+}
+
+// CallValidateKeyAccessData calls ValidateKeyAccessData on the plugin with the fields of the
+// request, prefixing errors with the plugin name.
+func (f *KeystoreInstanceKeyOperationFacade) CallValidateKeyAccessData(ctx context.Context, management *structpb.Struct, crypto *structpb.Struct) (isValid bool, message string, err error) {
+	resp, err := f.ValidateKeyAccessData(ctx, &ValidateKeyAccessDataRequest{Management: management, Crypto: crypto})
+	return resp.GetIsValid(), resp.GetMessage(), err
+}
+
+// TransformCryptoAccessData calls TransformCryptoAccessData on the plugin, prefixing errors with the plugin name.
+func (f *KeystoreInstanceKeyOperationFacade) TransformCryptoAccessData(ctx context.Context, req *TransformCryptoAccessDataRequest) (*TransformCryptoAccessDataResponse, error) {
+	f.LogCall(ctx, KeystoreInstanceKeyOperation_TransformCryptoAccessData_FullMethodName, req)
+	resp, err := f.KeystoreInstanceKeyOperationPluginClient.TransformCryptoAccessData(ctx, req)
+	return resp, f.WrapErr(err)
+}
+
+// CallTransformCryptoAccessData calls TransformCryptoAccessData on the plugin with the fields of the
+// request, prefixing errors with the plugin name.
+func (f *KeystoreInstanceKeyOperationFacade) CallTransformCryptoAccessData(ctx context.Context, nativeKeyId string, accessData []byte) (transformedAccessData map[string][]byte, err error) {
+	resp, err := f.TransformCryptoAccessData(ctx, &TransformCryptoAccessDataRequest{NativeKeyId: nativeKeyId, AccessData: accessData})
+	return resp.GetTransformedAccessData(), err
+}
+
+// ExtractKeyRegion calls ExtractKeyRegion on the plugin, prefixing errors with the plugin name.
+func (f *KeystoreInstanceKeyOperationFacade) ExtractKeyRegion(ctx context.Context, req *ExtractKeyRegionRequest) (*ExtractKeyRegionResponse, error) {
+	f.LogCall(ctx, KeystoreInstanceKeyOperation_ExtractKeyRegion_FullMethodName, req)
+	resp, err := f.KeystoreInstanceKeyOperationPluginClient.ExtractKeyRegion(ctx, req)
+	return resp, f.WrapErr(err)
+}
+
+// CallExtractKeyRegion calls ExtractKeyRegion on the plugin with the fields of the
+// request, prefixing errors with the plugin name.
+func (f *KeystoreInstanceKeyOperationFacade) CallExtractKeyRegion(ctx context.Context, nativeKeyId string, managementAccessData *structpb.Struct) (region string, err error) {
+	resp, err := f.ExtractKeyRegion(ctx, &ExtractKeyRegionRequest{NativeKeyId: nativeKeyId, ManagementAccessData: managementAccessData})
+	return resp.GetRegion(), err
+}
+
+// Redacted returns a copy of the message with its sensitive fields redacted.
+func (x *RequestParameters) Redacted() *RequestParameters {
+	return redact.Message(x)
+}
+
+// LogValue implements slog.LogValuer, logging the message redacted.
+func (x *RequestParameters) LogValue() slog.Value {
+	return redact.LogValue(x)
+}
+
+// Redacted returns a copy of the message with its sensitive fields redacted.
+func (x *GetKeyRequest) Redacted() *GetKeyRequest {
+	return redact.Message(x)
+}
+
+// LogValue implements slog.LogValuer, logging the message redacted.
+func (x *GetKeyRequest) LogValue() slog.Value {
+	return redact.LogValue(x)
+}
+
+// Redacted returns a copy of the message with its sensitive fields redacted.
+func (x *CreateKeyRequest) Redacted() *CreateKeyRequest {
+	return redact.Message(x)
+}
+
+// LogValue implements slog.LogValuer, logging the message redacted.
+func (x *CreateKeyRequest) LogValue() slog.Value {
+	return redact.LogValue(x)
+}
+
+// Redacted returns a copy of the message with its sensitive fields redacted.
+func (x *DeleteKeyRequest) Redacted() *DeleteKeyRequest {
+	return redact.Message(x)
+}
+
+// LogValue implements slog.LogValuer, logging the message redacted.
+func (x *DeleteKeyRequest) LogValue() slog.Value {
+	return redact.LogValue(x)
+}
+
+// Redacted returns a copy of the message with its sensitive fields redacted.
+func (x *EnableKeyRequest) Redacted() *EnableKeyRequest {
+	return redact.Message(x)
+}
+
+// LogValue implements slog.LogValuer, logging the message redacted.
+func (x *EnableKeyRequest) LogValue() slog.Value {
+	return redact.LogValue(x)
+}
+
+// Redacted returns a copy of the message with its sensitive fields redacted.
+func (x *DisableKeyRequest) Redacted() *DisableKeyRequest {
+	return redact.Message(x)
+}
+
+// LogValue implements slog.LogValuer, logging the message redacted.
+func (x *DisableKeyRequest) LogValue() slog.Value {
+	return redact.LogValue(x)
+}
+
+// Redacted returns a copy of the message with its sensitive fields redacted.
+func (x *GetImportParametersRequest) Redacted() *GetImportParametersRequest {
+	return redact.Message(x)
+}
+
+// LogValue implements slog.LogValuer, logging the message redacted.
+func (x *GetImportParametersRequest) LogValue() slog.Value {
+	return redact.LogValue(x)
+}
+
+// Redacted returns a copy of the message with its sensitive fields redacted.
+func (x *ImportKeyMaterialRequest) Redacted() *ImportKeyMaterialRequest {
+	return redact.Message(x)
+}
+
+// LogValue implements slog.LogValuer, logging the message redacted.
+func (x *ImportKeyMaterialRequest) LogValue() slog.Value {
+	return redact.LogValue(x)
+}
+
+// Redacted returns a copy of the message with its sensitive fields redacted.
+func (x *ValidateKeyAccessDataRequest) Redacted() *ValidateKeyAccessDataRequest {
+	return redact.Message(x)
+}
+
+// LogValue implements slog.LogValuer, logging the message redacted.
+func (x *ValidateKeyAccessDataRequest) LogValue() slog.Value {
+	return redact.LogValue(x)
+}
+
+// Redacted returns a copy of the message with its sensitive fields redacted.
+func (x *TransformCryptoAccessDataRequest) Redacted() *TransformCryptoAccessDataRequest {
+	return redact.Message(x)
+}
+
+// LogValue implements slog.LogValuer, logging the message redacted.
+func (x *TransformCryptoAccessDataRequest) LogValue() slog.Value {
+	return redact.LogValue(x)
+}
+
+// Redacted returns a copy of the message with its sensitive fields redacted.
+func (x *TransformCryptoAccessDataResponse) Redacted() *TransformCryptoAccessDataResponse {
+	return redact.Message(x)
+}
+
+// LogValue implements slog.LogValuer, logging the message redacted.
+func (x *TransformCryptoAccessDataResponse) LogValue() slog.Value {
+	return redact.LogValue(x)
+}
+
+// Redacted returns a copy of the message with its sensitive fields redacted.
+func (x *ExtractKeyRegionRequest) Redacted() *ExtractKeyRegionRequest {
+	return redact.Message(x)
+}
+
+// LogValue implements slog.LogValuer, logging the message redacted.
+func (x *ExtractKeyRegionRequest) LogValue() slog.Value {
+	return redact.LogValue(x)
+}
@@ -0,0 +1,447 @@
+// Code generated by protoc-gen-go-extension. DO NOT EDIT.
+
+// Package operationsv1fake provides fakes of the plugins of package operationsv1.
+package operationsv1fake
+
+import (
+	context "context"
+	api "github.com/openkcm/plugin-sdk/api"
+	v1 "github.com/openkcm/plugin-sdk/proto/plugin/keystore/operations/v1"
+	sync "sync"
+)
+
+// KeystoreInstanceKeyOperation is a fake KeystoreInstanceKeyOperation plugin. The calls of its unary
+// methods are recorded and answered by the next response scripted with the
+// Return methods, or else by the function of the method, if set, or else
+// with an empty response. Streaming methods are only answered by their
+// function.
+type KeystoreInstanceKeyOperation struct {
+	v1.UnimplementedKeystoreInstanceKeyOperationServer
+
+	// GetKeyFunc answers the calls of GetKey, if set.
+	GetKeyFunc func(ctx context.Context, req *v1.GetKeyRequest) (*v1.GetKeyResponse, error)
+	// CreateKeyFunc answers the calls of CreateKey, if set.
+	CreateKeyFunc func(ctx context.Context, req *v1.CreateKeyRequest) (*v1.CreateKeyResponse, error)
+	// DeleteKeyFunc answers the calls of DeleteKey, if set.
+	DeleteKeyFunc func(ctx context.Context, req *v1.DeleteKeyRequest) (*v1.DeleteKeyResponse, error)
+	// EnableKeyFunc answers the calls of EnableKey, if set.
+	EnableKeyFunc func(ctx context.Context, req *v1.EnableKeyRequest) (*v1.EnableKeyResponse, error)
+	// DisableKeyFunc answers the calls of DisableKey, if set.
+	DisableKeyFunc func(ctx context.Context, req *v1.DisableKeyRequest) (*v1.DisableKeyResponse, error)
+	// GetImportParametersFunc answers the calls of GetImportParameters, if set.
+	GetImportParametersFunc func(ctx context.Context, req *v1.GetImportParametersRequest) (*v1.GetImportParametersResponse, error)
+	// ImportKeyMaterialFunc answers the calls of ImportKeyMaterial, if set.
+	ImportKeyMaterialFunc func(ctx context.Context, req *v1.ImportKeyMaterialRequest) (*v1.ImportKeyMaterialResponse, error)
+	// ValidateKeyFunc answers the calls of ValidateKey, if set.
+	ValidateKeyFunc func(ctx context.Context, req *v1.ValidateKeyRequest) (*v1.ValidateKeyResponse, error)
+	// ValidateKeyAccessDataFunc answers the calls of ValidateKeyAccessData, if set.
+	ValidateKeyAccessDataFunc func(ctx context.Context, req *v1.ValidateKeyAccessDataRequest) (*v1.ValidateKeyAccessDataResponse, error)
+	// TransformCryptoAccessDataFunc answers the calls of TransformCryptoAccessData, if set.
+	TransformCryptoAccessDataFunc func(ctx context.Context, req *v1.TransformCryptoAccessDataRequest) (*v1.TransformCryptoAccessDataResponse, error)
+	// ExtractKeyRegionFunc answers the calls of ExtractKeyRegion, if set.
+	ExtractKeyRegionFunc func(ctx context.Context, req *v1.ExtractKeyRegionRequest) (*v1.ExtractKeyRegionResponse, error)
+
+	mu                               sync.Mutex
+	getKeyCalls                      []*v1.GetKeyRequest
+	getKeyResults                    []func() (*v1.GetKeyResponse, error)
+	createKeyCalls                   []*v1.CreateKeyRequest
+	createKeyResults                 []func() (*v1.CreateKeyResponse, error)
+	deleteKeyCalls                   []*v1.DeleteKeyRequest
+	deleteKeyResults                 []func() (*v1.DeleteKeyResponse, error)
+	enableKeyCalls                   []*v1.EnableKeyRequest
+	enableKeyResults                 []func() (*v1.EnableKeyResponse, error)
+	disableKeyCalls                  []*v1.DisableKeyRequest
+	disableKeyResults                []func() (*v1.DisableKeyResponse, error)
+	getImportParametersCalls         []*v1.GetImportParametersRequest
+	getImportParametersResults       []func() (*v1.GetImportParametersResponse, error)
+	importKeyMaterialCalls           []*v1.ImportKeyMaterialRequest
+	importKeyMaterialResults         []func() (*v1.ImportKeyMaterialResponse, error)
+	validateKeyCalls                 []*v1.ValidateKeyRequest
+	validateKeyResults               []func() (*v1.ValidateKeyResponse, error)
+	validateKeyAccessDataCalls       []*v1.ValidateKeyAccessDataRequest
+	validateKeyAccessDataResults     []func() (*v1.ValidateKeyAccessDataResponse, error)
+	transformCryptoAccessDataCalls   []*v1.TransformCryptoAccessDataRequest
+	transformCryptoAccessDataResults []func() (*v1.TransformCryptoAccessDataResponse, error)
+	extractKeyRegionCalls            []*v1.ExtractKeyRegionRequest
+	extractKeyRegionResults          []func() (*v1.ExtractKeyRegionResponse, error)
+}
+
+// PluginServer returns the plugin server of the fake, e.g. to load it as a
+// built-in plugin with catalog.MakeBuiltIn.
+func (f *KeystoreInstanceKeyOperation) PluginServer() api.PluginServer {
+	return v1.KeystoreInstanceKeyOperationPluginServer(f)
+}
+
+func (f *KeystoreInstanceKeyOperation) GetKey(ctx context.Context, req *v1.GetKeyRequest) (*v1.GetKeyResponse, error) {
+	f.mu.Lock()
+	f.getKeyCalls = append(f.getKeyCalls, req)
+	var result func() (*v1.GetKeyResponse, error)
+	if len(f.getKeyResults) > 0 {
+		result, f.getKeyResults = f.getKeyResults[0], f.getKeyResults[1:]
+	}
+	fn := f.GetKeyFunc
+	f.mu.Unlock()
+
+	switch {
+	case result != nil:
+		return result()
+	case fn != nil:
+		return fn(ctx, req)
+	}
+	return &v1.GetKeyResponse{}, nil
+}
+
+// ReturnGetKey scripts the response of the next call of GetKey not
+// answered by a previously scripted response.
+func (f *KeystoreInstanceKeyOperation) ReturnGetKey(resp *v1.GetKeyResponse, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getKeyResults = append(f.getKeyResults, func() (*v1.GetKeyResponse, error) { return resp, err })
+}
+
+// GetKeyCalls returns the requests of the calls of GetKey.
+func (f *KeystoreInstanceKeyOperation) GetKeyCalls() []*v1.GetKeyRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*v1.GetKeyRequest(nil), f.getKeyCalls...)
+}
+
+func (f *KeystoreInstanceKeyOperation) CreateKey(ctx context.Context, req *v1.CreateKeyRequest) (*v1.CreateKeyResponse, error) {
+	f.mu.Lock()
+	f.createKeyCalls = append(f.createKeyCalls, req)
+	var result func() (*v1.CreateKeyResponse, error)
+	if len(f.createKeyResults) > 0 {
+		result, f.createKeyResults = f.createKeyResults[0], f.createKeyResults[1:]
+	}
+	fn := f.CreateKeyFunc
+	f.mu.Unlock()
+
+	switch {
+	case result != nil:
+		return result()
+	case fn != nil:
+		return fn(ctx, req)
+	}
+	return &v1.CreateKeyResponse{}, nil
+}
+
+// ReturnCreateKey scripts the response of the next call of CreateKey not
+// answered by a previously scripted response.
+func (f *KeystoreInstanceKeyOperation) ReturnCreateKey(resp *v1.CreateKeyResponse, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.createKeyResults = append(f.createKeyResults, func() (*v1.CreateKeyResponse, error) { return resp, err })
+}
+
+// CreateKeyCalls returns the requests of the calls of CreateKey.
+func (f *KeystoreInstanceKeyOperation) CreateKeyCalls() []*v1.CreateKeyRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*v1.CreateKeyRequest(nil), f.createKeyCalls...)
+}
+
+func (f *KeystoreInstanceKeyOperation) DeleteKey(ctx context.Context, req *v1.DeleteKeyRequest) (*v1.DeleteKeyResponse, error) {
+	f.mu.Lock()
+	f.deleteKeyCalls = append(f.deleteKeyCalls, req)
+	var result func() (*v1.DeleteKeyResponse, error)
+	if len(f.deleteKeyResults) > 0 {
+		result, f.deleteKeyResults = f.deleteKeyResults[0], f.deleteKeyResults[1:]
+	}
+	fn := f.DeleteKeyFunc
+	f.mu.Unlock()
+
+	switch {
+	case result != nil:
+		return result()
+	case fn != nil:
+		return fn(ctx, req)
+	}
+	return &v1.DeleteKeyResponse{}, nil
+}
+
+// ReturnDeleteKey scripts the response of the next call of DeleteKey not
+// answered by a previously scripted response.
+func (f *KeystoreInstanceKeyOperation) ReturnDeleteKey(resp *v1.DeleteKeyResponse, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.deleteKeyResults = append(f.deleteKeyResults, func() (*v1.DeleteKeyResponse, error) { return resp, err })
+}
+
+// DeleteKeyCalls returns the requests of the calls of DeleteKey.
+func (f *KeystoreInstanceKeyOperation) DeleteKeyCalls() []*v1.DeleteKeyRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*v1.DeleteKeyRequest(nil), f.deleteKeyCalls...)
+}
+
+func (f *KeystoreInstanceKeyOperation) EnableKey(ctx context.Context, req *v1.EnableKeyRequest) (*v1.EnableKeyResponse, error) {
+	f.mu.Lock()
+	f.enableKeyCalls = append(f.enableKeyCalls, req)
+	var result func() (*v1.EnableKeyResponse, error)
+	if len(f.enableKeyResults) > 0 {
+		result, f.enableKeyResults = f.enableKeyResults[0], f.enableKeyResults[1:]
+	}
+	fn := f.EnableKeyFunc
+	f.mu.Unlock()
+
+	switch {
+	case result != nil:
+		return result()
+	case fn != nil:
+		return fn(ctx, req)
+	}
+	return &v1.EnableKeyResponse{}, nil
+}
+
+// ReturnEnableKey scripts the response of the next call of EnableKey not
+// answered by a previously scripted response.
+func (f *KeystoreInstanceKeyOperation) ReturnEnableKey(resp *v1.EnableKeyResponse, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.enableKeyResults = append(f.enableKeyResults, func() (*v1.EnableKeyResponse, error) { return resp, err })
+}
+
+// EnableKeyCalls returns the requests of the calls of EnableKey.
+func (f *KeystoreInstanceKeyOperation) EnableKeyCalls() []*v1.EnableKeyRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*v1.EnableKeyRequest(nil), f.enableKeyCalls...)
+}
+
+func (f *KeystoreInstanceKeyOperation) DisableKey(ctx context.Context, req *v1.DisableKeyRequest) (*v1.DisableKeyResponse, error) {
+	f.mu.Lock()
+	f.disableKeyCalls = append(f.disableKeyCalls, req)
+	var result func() (*v1.DisableKeyResponse, error)
+	if len(f.disableKeyResults) > 0 {
+		result, f.disableKeyResults = f.disableKeyResults[0], f.disableKeyResults[1:]
+	}
+	fn := f.DisableKeyFunc
+	f.mu.Unlock()
+
+	switch {
+	case result != nil:
+		return result()
+	case fn != nil:
+		return fn(ctx, req)
+	}
+	return &v1.DisableKeyResponse{}, nil
+}
+
+// ReturnDisableKey scripts the response of the next call of DisableKey not
+// answered by a previously scripted response.
+func (f *KeystoreInstanceKeyOperation) ReturnDisableKey(resp *v1.DisableKeyResponse, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.disableKeyResults = append(f.disableKeyResults, func() (*v1.DisableKeyResponse, error) { return resp, err })
+}
+
+// DisableKeyCalls returns the requests of the calls of DisableKey.
+func (f *KeystoreInstanceKeyOperation) DisableKeyCalls() []*v1.DisableKeyRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*v1.DisableKeyRequest(nil), f.disableKeyCalls...)
+}
+
+func (f *KeystoreInstanceKeyOperation) GetImportParameters(ctx context.Context, req *v1.GetImportParametersRequest) (*v1.GetImportParametersResponse, error) {
+	f.mu.Lock()
+	f.getImportParametersCalls = append(f.getImportParametersCalls, req)
+	var result func() (*v1.GetImportParametersResponse, error)
+	if len(f.getImportParametersResults) > 0 {
+		result, f.getImportParametersResults = f.getImportParametersResults[0], f.getImportParametersResults[1:]
+	}
+	fn := f.GetImportParametersFunc
+	f.mu.Unlock()
+
+	switch {
+	case result != nil:
+		return result()
+	case fn != nil:
+		return fn(ctx, req)
+	}
+	return &v1.GetImportParametersResponse{}, nil
+}
+
+// ReturnGetImportParameters scripts the response of the next call of GetImportParameters not
+// answered by a previously scripted response.
+func (f *KeystoreInstanceKeyOperation) ReturnGetImportParameters(resp *v1.GetImportParametersResponse, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getImportParametersResults = append(f.getImportParametersResults, func() (*v1.GetImportParametersResponse, error) { return resp, err })
+}
+
+// GetImportParametersCalls returns the requests of the calls of GetImportParameters.
+func (f *KeystoreInstanceKeyOperation) GetImportParametersCalls() []*v1.GetImportParametersRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*v1.GetImportParametersRequest(nil), f.getImportParametersCalls...)
+}
+
+func (f *KeystoreInstanceKeyOperation) ImportKeyMaterial(ctx context.Context, req *v1.ImportKeyMaterialRequest) (*v1.ImportKeyMaterialResponse, error) {
+	f.mu.Lock()
+	f.importKeyMaterialCalls = append(f.importKeyMaterialCalls, req)
+	var result func() (*v1.ImportKeyMaterialResponse, error)
+	if len(f.importKeyMaterialResults) > 0 {
+		result, f.importKeyMaterialResults = f.importKeyMaterialResults[0], f.importKeyMaterialResults[1:]
+	}
+	fn := f.ImportKeyMaterialFunc
+	f.mu.Unlock()
+
+	switch {
+	case result != nil:
+		return result()
+	case fn != nil:
+		return fn(ctx, req)
+	}
+	return &v1.ImportKeyMaterialResponse{}, nil
+}
+
+// ReturnImportKeyMaterial scripts the response of the next call of ImportKeyMaterial not
+// answered by a previously scripted response.
+func (f *KeystoreInstanceKeyOperation) ReturnImportKeyMaterial(resp *v1.ImportKeyMaterialResponse, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.importKeyMaterialResults = append(f.importKeyMaterialResults, func() (*v1.ImportKeyMaterialResponse, error) { return resp, err })
+}
+
+// ImportKeyMaterialCalls returns the requests of the calls of ImportKeyMaterial.
+func (f *KeystoreInstanceKeyOperation) ImportKeyMaterialCalls() []*v1.ImportKeyMaterialRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*v1.ImportKeyMaterialRequest(nil), f.importKeyMaterialCalls...)
+}
+
+func (f *KeystoreInstanceKeyOperation) ValidateKey(ctx context.Context, req *v1.ValidateKeyRequest) (*v1.ValidateKeyResponse, error) {
+	f.mu.Lock()
+	f.validateKeyCalls = append(f.validateKeyCalls, req)
+	var result func() (*v1.ValidateKeyResponse, error)
+	if len(f.validateKeyResults) > 0 {
+		result, f.validateKeyResults = f.validateKeyResults[0], f.validateKeyResults[1:]
+	}
+	fn := f.ValidateKeyFunc
+	f.mu.Unlock()
+
+	switch {
+	case result != nil:
+		return result()
+	case fn != nil:
+		return fn(ctx, req)
+	}
+	return &v1.ValidateKeyResponse{}, nil
+}
+
+// ReturnValidateKey scripts the response of the next call of ValidateKey not
+// answered by a previously scripted response.
+func (f *KeystoreInstanceKeyOperation) ReturnValidateKey(resp *v1.ValidateKeyResponse, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.validateKeyResults = append(f.validateKeyResults, func() (*v1.ValidateKeyResponse, error) { return resp, err })
+}
+
+// ValidateKeyCalls returns the requests of the calls of ValidateKey.
+func (f *KeystoreInstanceKeyOperation) ValidateKeyCalls() []*v1.ValidateKeyRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*v1.ValidateKeyRequest(nil), f.validateKeyCalls...)
+}
+
+func (f *KeystoreInstanceKeyOperation) ValidateKeyAccessData(ctx context.Context, req *v1.ValidateKeyAccessDataRequest) (*v1.ValidateKeyAccessDataResponse, error) {
+	f.mu.Lock()
+	f.validateKeyAccessDataCalls = append(f.validateKeyAccessDataCalls, req)
+	var result func() (*v1.ValidateKeyAccessDataResponse, error)
+	if len(f.validateKeyAccessDataResults) > 0 {
+		result, f.validateKeyAccessDataResults = f.validateKeyAccessDataResults[0], f.validateKeyAccessDataResults[1:]
+	}
+	fn := f.ValidateKeyAccessDataFunc
+	f.mu.Unlock()
+
+	switch {
+	case result != nil:
+		return result()
+	case fn != nil:
+		return fn(ctx, req)
+	}
+	return &v1.ValidateKeyAccessDataResponse{}, nil
+}
+
+// ReturnValidateKeyAccessData scripts the response of the next call of ValidateKeyAccessData not
+// answered by a previously scripted response.
+func (f *KeystoreInstanceKeyOperation) ReturnValidateKeyAccessData(resp *v1.ValidateKeyAccessDataResponse, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.validateKeyAccessDataResults = append(f.validateKeyAccessDataResults, func() (*v1.ValidateKeyAccessDataResponse, error) { return resp, err })
+}
+
+// ValidateKeyAccessDataCalls returns the requests of the calls of ValidateKeyAccessData.
+func (f *KeystoreInstanceKeyOperation) ValidateKeyAccessDataCalls() []*v1.ValidateKeyAccessDataRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*v1.ValidateKeyAccessDataRequest(nil), f.validateKeyAccessDataCalls...)
+}
+
+func (f *KeystoreInstanceKeyOperation) TransformCryptoAccessData(ctx context.Context, req *v1.TransformCryptoAccessDataRequest) (*v1.TransformCryptoAccessDataResponse, error) {
+	f.mu.Lock()
+	f.transformCryptoAccessDataCalls = append(f.transformCryptoAccessDataCalls, req)
+	var result func() (*v1.TransformCryptoAccessDataResponse, error)
+	if len(f.transformCryptoAccessDataResults) > 0 {
+		result, f.transformCryptoAccessDataResults = f.transformCryptoAccessDataResults[0], f.transformCryptoAccessDataResults[1:]
+	}
+	fn := f.TransformCryptoAccessDataFunc
+	f.mu.Unlock()
+
+	switch {
+	case result != nil:
+		return result()
+	case fn != nil:
+		return fn(ctx, req)
+	}
+	return &v1.TransformCryptoAccessDataResponse{}, nil
+}
+
+// ReturnTransformCryptoAccessData scripts the response of the next call of TransformCryptoAccessData not
+// answered by a previously scripted response.
+func (f *KeystoreInstanceKeyOperation) ReturnTransformCryptoAccessData(resp *v1.TransformCryptoAccessDataResponse, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.transformCryptoAccessDataResults = append(f.transformCryptoAccessDataResults, func() (*v1.TransformCryptoAccessDataResponse, error) { return resp, err })
+}
+
+// TransformCryptoAccessDataCalls returns the requests of the calls of TransformCryptoAccessData.
+func (f *KeystoreInstanceKeyOperation) TransformCryptoAccessDataCalls() []*v1.TransformCryptoAccessDataRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*v1.TransformCryptoAccessDataRequest(nil), f.transformCryptoAccessDataCalls...)
+}
+
+func (f *KeystoreInstanceKeyOperation) ExtractKeyRegion(ctx context.Context, req *v1.ExtractKeyRegionRequest) (*v1.ExtractKeyRegionResponse, error) {
+	f.mu.Lock()
+	f.extractKeyRegionCalls = append(f.extractKeyRegionCalls, req)
+	var result func() (*v1.ExtractKeyRegionResponse, error)
+	if len(f.extractKeyRegionResults) > 0 {
+		result, f.extractKeyRegionResults = f.extractKeyRegionResults[0], f.extractKeyRegionResults[1:]
+	}
+	fn := f.ExtractKeyRegionFunc
+	f.mu.Unlock()
+
+	switch {
+	case result != nil:
+		return result()
+	case fn != nil:
+		return fn(ctx, req)
+	}
+	return &v1.ExtractKeyRegionResponse{}, nil
+}
+
+// ReturnExtractKeyRegion scripts the response of the next call of ExtractKeyRegion not
+// answered by a previously scripted response.
+func (f *KeystoreInstanceKeyOperation) ReturnExtractKeyRegion(resp *v1.ExtractKeyRegionResponse, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.extractKeyRegionResults = append(f.extractKeyRegionResults, func() (*v1.ExtractKeyRegionResponse, error) { return resp, err })
+}
+
+// ExtractKeyRegionCalls returns the requests of the calls of ExtractKeyRegion.
+func (f *KeystoreInstanceKeyOperation) ExtractKeyRegionCalls() []*v1.ExtractKeyRegionRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*v1.ExtractKeyRegionRequest(nil), f.extractKeyRegionCalls...)
+}
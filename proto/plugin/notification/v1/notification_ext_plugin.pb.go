@@ -3,7 +3,9 @@
 package notificationv1
 
 import (
+	context "context"
 	api "github.com/openkcm/plugin-sdk/api"
+	plugin "github.com/openkcm/plugin-sdk/pkg/plugin"
 	grpc "google.golang.org/grpc"
 )
 
@@ -53,3 +55,64 @@ func (c *NotificationServicePluginClient) InitClient(conn grpc.ClientConnInterfa
 	c.NotificationServiceClient = NewNotificationServiceClient(conn)
 	return c.NotificationServiceClient
 }
+
+// NotificationServiceRepo is the repository of the NotificationService plugins loaded by
+// the catalog. It is registered for the plugin Type.
+type NotificationServiceRepo struct {
+	facades []*NotificationServiceFacade
+}
+
+var _ api.PluginRepo = (*NotificationServiceRepo)(nil)
+
+func (r *NotificationServiceRepo) Binder() any {
+	return func(f *NotificationServiceFacade) {
+		r.facades = append(r.facades, f)
+	}
+}
+
+func (r *NotificationServiceRepo) Versions() []api.Version {
+	return []api.Version{NotificationServiceVersion{}}
+}
+
+func (r *NotificationServiceRepo) Constraints() api.Constraints {
+	return api.Constraints{Min: 0, Max: 0}
+}
+
+func (r *NotificationServiceRepo) Clear() {
+	r.facades = nil
+}
+
+// Facades returns the facades of the loaded plugins.
+func (r *NotificationServiceRepo) Facades() []*NotificationServiceFacade {
+	return r.facades
+}
+
+// NotificationServiceVersion is the version of the NotificationService plugin facade.
+type NotificationServiceVersion struct{}
+
+func (NotificationServiceVersion) New() api.Facade  { return new(NotificationServiceFacade) }
+func (NotificationServiceVersion) Deprecated() bool { return false }
+
+// NotificationServiceFacade is the facade of a loaded NotificationService plugin.
+type NotificationServiceFacade struct {
+	plugin.Facade
+	NotificationServicePluginClient
+}
+
+func (f *NotificationServiceFacade) Version() uint {
+	return 1
+}
+
+// SendNotification calls SendNotification on the plugin, prefixing errors with the plugin name.
+func (f *NotificationServiceFacade) SendNotification(ctx context.Context, req *SendNotificationRequest) (*SendNotificationResponse, error) {
+	f.LogCall(ctx, NotificationService_SendNotification_FullMethodName, req)
+	resp, err := f.NotificationServicePluginClient.SendNotification(ctx, req)
+	return resp, f.WrapErr(err)
+}
+
+// CallSendNotification calls SendNotification on the plugin with the fields of the
+// request, prefixing errors with the plugin name.
+func (f *NotificationServiceFacade) CallSendNotification(ctx context.Context, notificationType NotificationType, recipients []string, subject string, body string) (success bool, message string, err error) {
+	resp, err := f.SendNotification(ctx, &SendNotificationRequest{NotificationType: notificationType, Recipients: recipients, Subject: subject, Body: body})
+	return resp.GetSuccess(), resp.GetMessage(), err
+}
@@ -0,0 +1,67 @@
+// Code generated by protoc-gen-go-extension. DO NOT EDIT.
+
+// Package notificationv1fake provides fakes of the plugins of package notificationv1.
+package notificationv1fake
+
+import (
+	context "context"
+	api "github.com/openkcm/plugin-sdk/api"
+	v1 "github.com/openkcm/plugin-sdk/proto/plugin/notification/v1"
+	sync "sync"
+)
+
+// NotificationService is a fake NotificationService plugin. The calls of its unary
+// methods are recorded and answered by the next response scripted with the
+// Return methods, or else by the function of the method, if set, or else
+// with an empty response. Streaming methods are only answered by their
+// function.
+type NotificationService struct {
+	v1.UnimplementedNotificationServiceServer
+
+	// SendNotificationFunc answers the calls of SendNotification, if set.
+	SendNotificationFunc func(ctx context.Context, req *v1.SendNotificationRequest) (*v1.SendNotificationResponse, error)
+
+	mu                      sync.Mutex
+	sendNotificationCalls   []*v1.SendNotificationRequest
+	sendNotificationResults []func() (*v1.SendNotificationResponse, error)
+}
+
+// PluginServer returns the plugin server of the fake, e.g. to load it as a
+// built-in plugin with catalog.MakeBuiltIn.
+func (f *NotificationService) PluginServer() api.PluginServer {
+	return v1.NotificationServicePluginServer(f)
+}
+
+func (f *NotificationService) SendNotification(ctx context.Context, req *v1.SendNotificationRequest) (*v1.SendNotificationResponse, error) {
+	f.mu.Lock()
+	f.sendNotificationCalls = append(f.sendNotificationCalls, req)
+	var result func() (*v1.SendNotificationResponse, error)
+	if len(f.sendNotificationResults) > 0 {
+		result, f.sendNotificationResults = f.sendNotificationResults[0], f.sendNotificationResults[1:]
+	}
+	fn := f.SendNotificationFunc
+	f.mu.Unlock()
+
+	switch {
+	case result != nil:
+		return result()
+	case fn != nil:
+		return fn(ctx, req)
+	}
+	return &v1.SendNotificationResponse{}, nil
+}
+
+// ReturnSendNotification scripts the response of the next call of SendNotification not
+// answered by a previously scripted response.
+func (f *NotificationService) ReturnSendNotification(resp *v1.SendNotificationResponse, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sendNotificationResults = append(f.sendNotificationResults, func() (*v1.SendNotificationResponse, error) { return resp, err })
+}
+
+// SendNotificationCalls returns the requests of the calls of SendNotification.
+func (f *NotificationService) SendNotificationCalls() []*v1.SendNotificationRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*v1.SendNotificationRequest(nil), f.sendNotificationCalls...)
+}
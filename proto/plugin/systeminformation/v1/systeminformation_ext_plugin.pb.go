@@ -3,7 +3,9 @@
 package systeminformationv1
 
 import (
+	context "context"
 	api "github.com/openkcm/plugin-sdk/api"
+	plugin "github.com/openkcm/plugin-sdk/pkg/plugin"
 	grpc "google.golang.org/grpc"
 )
 
@@ -53,3 +55,64 @@ func (c *SystemInformationServicePluginClient) InitClient(conn grpc.ClientConnIn
 	c.SystemInformationServiceClient = NewSystemInformationServiceClient(conn)
 	return c.SystemInformationServiceClient
 }
+
+// SystemInformationServiceRepo is the repository of the SystemInformationService plugins loaded by
+// the catalog. It is registered for the plugin Type.
+type SystemInformationServiceRepo struct {
+	facades []*SystemInformationServiceFacade
+}
+
+var _ api.PluginRepo = (*SystemInformationServiceRepo)(nil)
+
+func (r *SystemInformationServiceRepo) Binder() any {
+	return func(f *SystemInformationServiceFacade) {
+		r.facades = append(r.facades, f)
+	}
+}
+
+func (r *SystemInformationServiceRepo) Versions() []api.Version {
+	return []api.Version{SystemInformationServiceVersion{}}
+}
+
+func (r *SystemInformationServiceRepo) Constraints() api.Constraints {
+	return api.Constraints{Min: 0, Max: 0}
+}
+
+func (r *SystemInformationServiceRepo) Clear() {
+	r.facades = nil
+}
+
+// Facades returns the facades of the loaded plugins.
+func (r *SystemInformationServiceRepo) Facades() []*SystemInformationServiceFacade {
+	return r.facades
+}
+
+// SystemInformationServiceVersion is the version of the SystemInformationService plugin facade.
+type SystemInformationServiceVersion struct{}
+
+func (SystemInformationServiceVersion) New() api.Facade  { return new(SystemInformationServiceFacade) }
+func (SystemInformationServiceVersion) Deprecated() bool { return false }
+
+// SystemInformationServiceFacade is the facade of a loaded SystemInformationService plugin.
+type SystemInformationServiceFacade struct {
+	plugin.Facade
+	SystemInformationServicePluginClient
+}
+
+func (f *SystemInformationServiceFacade) Version() uint {
+	return 1
+}
+
+// Get calls Get on the plugin, prefixing errors with the plugin name.
+func (f *SystemInformationServiceFacade) Get(ctx context.Context, req *GetRequest) (*GetResponse, error) {
+	f.LogCall(ctx, SystemInformationService_Get_FullMethodName, req)
+	resp, err := f.SystemInformationServicePluginClient.Get(ctx, req)
+	return resp, f.WrapErr(err)
+}
+
+// CallGet calls Get on the plugin with the fields of the
+// request, prefixing errors with the plugin name.
+func (f *SystemInformationServiceFacade) CallGet(ctx context.Context, id string, type_ string) (metadata map[string]string, err error) {
+	resp, err := f.Get(ctx, &GetRequest{Id: id, Type: type_})
+	return resp.GetMetadata(), err
+}
@@ -0,0 +1,67 @@
+// Code generated by protoc-gen-go-extension. DO NOT EDIT.
+
+// Package systeminformationv1fake provides fakes of the plugins of package systeminformationv1.
+package systeminformationv1fake
+
+import (
+	context "context"
+	api "github.com/openkcm/plugin-sdk/api"
+	v1 "github.com/openkcm/plugin-sdk/proto/plugin/systeminformation/v1"
+	sync "sync"
+)
+
+// SystemInformationService is a fake SystemInformationService plugin. The calls of its unary
+// methods are recorded and answered by the next response scripted with the
+// Return methods, or else by the function of the method, if set, or else
+// with an empty response. Streaming methods are only answered by their
+// function.
+type SystemInformationService struct {
+	v1.UnimplementedSystemInformationServiceServer
+
+	// GetFunc answers the calls of Get, if set.
+	GetFunc func(ctx context.Context, req *v1.GetRequest) (*v1.GetResponse, error)
+
+	mu         sync.Mutex
+	getCalls   []*v1.GetRequest
+	getResults []func() (*v1.GetResponse, error)
+}
+
+// PluginServer returns the plugin server of the fake, e.g. to load it as a
+// built-in plugin with catalog.MakeBuiltIn.
+func (f *SystemInformationService) PluginServer() api.PluginServer {
+	return v1.SystemInformationServicePluginServer(f)
+}
+
+func (f *SystemInformationService) Get(ctx context.Context, req *v1.GetRequest) (*v1.GetResponse, error) {
+	f.mu.Lock()
+	f.getCalls = append(f.getCalls, req)
+	var result func() (*v1.GetResponse, error)
+	if len(f.getResults) > 0 {
+		result, f.getResults = f.getResults[0], f.getResults[1:]
+	}
+	fn := f.GetFunc
+	f.mu.Unlock()
+
+	switch {
+	case result != nil:
+		return result()
+	case fn != nil:
+		return fn(ctx, req)
+	}
+	return &v1.GetResponse{}, nil
+}
+
+// ReturnGet scripts the response of the next call of Get not
+// answered by a previously scripted response.
+func (f *SystemInformationService) ReturnGet(resp *v1.GetResponse, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getResults = append(f.getResults, func() (*v1.GetResponse, error) { return resp, err })
+}
+
+// GetCalls returns the requests of the calls of Get.
+func (f *SystemInformationService) GetCalls() []*v1.GetRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*v1.GetRequest(nil), f.getCalls...)
+}
@@ -3,7 +3,9 @@
 package testv1
 
 import (
+	context "context"
 	api "github.com/openkcm/plugin-sdk/api"
+	plugin "github.com/openkcm/plugin-sdk/pkg/plugin"
 	grpc "google.golang.org/grpc"
 )
 
@@ -53,3 +55,64 @@ func (c *TestServicePluginClient) InitClient(conn grpc.ClientConnInterface) any
 	c.TestServiceClient = NewTestServiceClient(conn)
 	return c.TestServiceClient
 }
+
+// TestServiceRepo is the repository of the TestService plugins loaded by
+// the catalog. It is registered for the plugin Type.
+type TestServiceRepo struct {
+	facades []*TestServiceFacade
+}
+
+var _ api.PluginRepo = (*TestServiceRepo)(nil)
+
+func (r *TestServiceRepo) Binder() any {
+	return func(f *TestServiceFacade) {
+		r.facades = append(r.facades, f)
+	}
+}
+
+func (r *TestServiceRepo) Versions() []api.Version {
+	return []api.Version{TestServiceVersion{}}
+}
+
+func (r *TestServiceRepo) Constraints() api.Constraints {
+	return api.Constraints{Min: 0, Max: 0}
+}
+
+func (r *TestServiceRepo) Clear() {
+	r.facades = nil
+}
+
+// Facades returns the facades of the loaded plugins.
+func (r *TestServiceRepo) Facades() []*TestServiceFacade {
+	return r.facades
+}
+
+// TestServiceVersion is the version of the TestService plugin facade.
+type TestServiceVersion struct{}
+
+func (TestServiceVersion) New() api.Facade  { return new(TestServiceFacade) }
+func (TestServiceVersion) Deprecated() bool { return false }
+
+// TestServiceFacade is the facade of a loaded TestService plugin.
+type TestServiceFacade struct {
+	plugin.Facade
+	TestServicePluginClient
+}
+
+func (f *TestServiceFacade) Version() uint {
+	return 1
+}
+
+// Test calls Test on the plugin, prefixing errors with the plugin name.
+func (f *TestServiceFacade) Test(ctx context.Context, req *TestRequest) (*TestResponse, error) {
+	f.LogCall(ctx, TestService_Test_FullMethodName, req)
+	resp, err := f.TestServicePluginClient.Test(ctx, req)
+	return resp, f.WrapErr(err)
+}
+
+// CallTest calls Test on the plugin with the fields of the
+// request, prefixing errors with the plugin name.
+func (f *TestServiceFacade) CallTest(ctx context.Context, request string) (response string, err error) {
+	resp, err := f.Test(ctx, &TestRequest{Request: request})
+	return resp.GetResponse(), err
+}
@@ -0,0 +1,67 @@
+// Code generated by protoc-gen-go-extension. DO NOT EDIT.
+
+// Package testv1fake provides fakes of the plugins of package testv1.
+package testv1fake
+
+import (
+	context "context"
+	api "github.com/openkcm/plugin-sdk/api"
+	v1 "github.com/openkcm/plugin-sdk/proto/plugin/test/v1"
+	sync "sync"
+)
+
+// TestService is a fake TestService plugin. The calls of its unary
+// methods are recorded and answered by the next response scripted with the
+// Return methods, or else by the function of the method, if set, or else
+// with an empty response. Streaming methods are only answered by their
+// function.
+type TestService struct {
+	v1.UnimplementedTestServiceServer
+
+	// TestFunc answers the calls of Test, if set.
+	TestFunc func(ctx context.Context, req *v1.TestRequest) (*v1.TestResponse, error)
+
+	mu          sync.Mutex
+	testCalls   []*v1.TestRequest
+	testResults []func() (*v1.TestResponse, error)
+}
+
+// PluginServer returns the plugin server of the fake, e.g. to load it as a
+// built-in plugin with catalog.MakeBuiltIn.
+func (f *TestService) PluginServer() api.PluginServer {
+	return v1.TestServicePluginServer(f)
+}
+
+func (f *TestService) Test(ctx context.Context, req *v1.TestRequest) (*v1.TestResponse, error) {
+	f.mu.Lock()
+	f.testCalls = append(f.testCalls, req)
+	var result func() (*v1.TestResponse, error)
+	if len(f.testResults) > 0 {
+		result, f.testResults = f.testResults[0], f.testResults[1:]
+	}
+	fn := f.TestFunc
+	f.mu.Unlock()
+
+	switch {
+	case result != nil:
+		return result()
+	case fn != nil:
+		return fn(ctx, req)
+	}
+	return &v1.TestResponse{}, nil
+}
+
+// ReturnTest scripts the response of the next call of Test not
+// answered by a previously scripted response.
+func (f *TestService) ReturnTest(resp *v1.TestResponse, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.testResults = append(f.testResults, func() (*v1.TestResponse, error) { return resp, err })
+}
+
+// TestCalls returns the requests of the calls of Test.
+func (f *TestService) TestCalls() []*v1.TestRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*v1.TestRequest(nil), f.testCalls...)
+}